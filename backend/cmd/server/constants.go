@@ -3,9 +3,26 @@ package main
 import "time"
 
 const (
-	DefaultAccessTokenTTL  = 10 * time.Minute
-	DefaultRefreshTokenTTL = 24 * 90 * time.Hour
-	DefaultUserRateLimit   = 10
-	DefaultIPRateLimit     = 20
-	DefaultRateLimitWindow = 60 * time.Second
+	DefaultAccessTokenTTL       = 10 * time.Minute
+	DefaultRefreshTokenTTL      = 24 * 90 * time.Hour
+	DefaultUserRateLimit        = 10
+	DefaultIPRateLimit          = 20
+	DefaultRateLimitWindow      = 60 * time.Second
+	EventLogMaxLength           = 1000
+	EventLogTTL                 = 24 * time.Hour
+	BlobStorageLocal            = "local"
+	BlobStorageS3               = "s3"
+	DefaultBlobLocalPath        = "data/attachments"
+	AttachmentProcessingWorkers = 2
+	AttachmentSweepInterval     = time.Minute
+	AttachmentSweepBatchSize    = 100
+	DefaultPinLimit             = 50
+	ScheduledMessageInterval    = 5 * time.Second
+	ScheduledMessageBatchSize   = 100
+	ScheduledMessageMaxAttempts = 5
+	ScheduledMessageRetryBase   = 30 * time.Second
+	ScheduledMessageRetryMax    = 30 * time.Minute
+	MessageReaperInterval       = 30 * time.Second
+	MessageReaperBatchSize      = 500
+	PresenceSweepInterval       = 30 * time.Second
 )
@@ -3,7 +3,9 @@ package main
 import (
 	"GitHub/go-chat/backend/internal/config"
 	"GitHub/go-chat/backend/internal/gracefulServer"
+	"GitHub/go-chat/backend/internal/infra/blob"
 	"GitHub/go-chat/backend/internal/infra/cache"
+	"GitHub/go-chat/backend/internal/infra/media"
 	"GitHub/go-chat/backend/internal/infra/postgres"
 	redisPubsub "GitHub/go-chat/backend/internal/infra/redis"
 	"GitHub/go-chat/backend/internal/ratelimit"
@@ -24,6 +26,7 @@ func validateConfig() error {
 	requiredVars := []string{
 		"ACCESS_TOKEN_SECRET",
 		"REFRESH_TOKEN_SECRET",
+		"INVITE_LINK_SECRET",
 		"DB_HOST",
 		"DB_PORT",
 		"DB_NAME",
@@ -48,6 +51,10 @@ func validateConfig() error {
 		return fmt.Errorf("REFRESH_TOKEN_SECRET must be set to a strong secret (run 'make secret' to generate one)")
 	}
 
+	if os.Getenv("INVITE_LINK_SECRET") == "generate-with-make-secret-or-crypto-rand" {
+		return fmt.Errorf("INVITE_LINK_SECRET must be set to a strong secret (run 'make secret' to generate one)")
+	}
+
 	if os.Getenv("REDIS_PASSWORD") == "change-this-redis-password" {
 		log.Println("WARNING: REDIS_PASSWORD should be changed from the default value")
 	}
@@ -77,9 +84,53 @@ func validateConfig() error {
 		return fmt.Errorf("WS_RATE_LIMIT_WINDOW must be positive")
 	}
 
+	if pinLimitStr := os.Getenv("PIN_LIMIT"); pinLimitStr != "" {
+		pinLimit, err := strconv.Atoi(pinLimitStr)
+		if err != nil {
+			return fmt.Errorf("PIN_LIMIT must be a valid integer")
+		}
+		if pinLimit <= 0 {
+			return fmt.Errorf("PIN_LIMIT must be positive")
+		}
+	}
+
+	switch os.Getenv("BLOB_STORAGE") {
+	case "", BlobStorageLocal:
+	case BlobStorageS3:
+		for _, envVar := range []string{"S3_ENDPOINT", "S3_ACCESS_KEY", "S3_SECRET_KEY", "S3_BUCKET"} {
+			if os.Getenv(envVar) == "" {
+				return fmt.Errorf("%s environment variable is required when BLOB_STORAGE is s3", envVar)
+			}
+		}
+	default:
+		return fmt.Errorf("BLOB_STORAGE must be either %q or %q", BlobStorageLocal, BlobStorageS3)
+	}
+
 	return nil
 }
 
+func newBlobStore(ctx context.Context) (services.BlobStore, error) {
+	if os.Getenv("BLOB_STORAGE") == BlobStorageS3 {
+		useSSL, _ := strconv.ParseBool(os.Getenv("S3_USE_SSL"))
+
+		return blob.NewS3Store(ctx, blob.S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			Region:    os.Getenv("S3_REGION"),
+			UseSSL:    useSSL,
+		})
+	}
+
+	root := os.Getenv("BLOB_LOCAL_PATH")
+	if root == "" {
+		root = DefaultBlobLocalPath
+	}
+
+	return blob.NewLocalStore(root)
+}
+
 func main() {
 	if err := validateConfig(); err != nil {
 		log.Fatalf("Configuration error: %v", err)
@@ -120,9 +171,17 @@ func main() {
 	})
 
 	messagesRepository := postgres.NewMessageRepository(pool)
+	reactionsRepository := postgres.NewReactionRepository(pool)
+	attachmentsRepository := postgres.NewAttachmentRepository(pool)
 	groupConversationsRepository := postgres.NewGroupConversationRepository(pool)
 	directConversationsRepository := postgres.NewDirectConversationRepository(pool)
 	participantRepository := postgres.NewParticipantRepository(pool)
+	inviteLinksRepository := postgres.NewInviteLinkRepository(pool)
+	joinRequestsRepository := postgres.NewJoinRequestRepository(pool)
+	blocksRepository := postgres.NewBlockRepository(pool)
+	pinsRepository := postgres.NewPinRepository(pool)
+	pollsRepository := postgres.NewPollRepository(pool)
+	scheduledMessagesRepository := postgres.NewScheduledMessageRepository(pool)
 	usersRepository := postgres.NewUserRepository(pool)
 
 	cachedUsersRepository := cache.NewUserCacheDecorator(usersRepository, cacheClient)
@@ -136,18 +195,102 @@ func main() {
 		RefreshToken: config.Token{Secret: os.Getenv("REFRESH_TOKEN_SECRET"), TTL: DefaultRefreshTokenTTL},
 	})
 
+	broker := redisPubsub.NewBroker(redisClient)
 	activeClients := ws.NewActiveClients(ctx, cachedParticipantRepository)
-	queries := postgres.NewQueriesRepository(pool)
-	notificationService := services.NewNotificationService(ctx, serverID, activeClients)
+	presenceRepository := redisPubsub.NewPresenceRepository(redisClient, ws.PresenceTTL)
+	queries := redisPubsub.NewPresenceQueriesDecorator(postgres.NewQueriesRepository(pool), presenceRepository)
+
+	presenceService := services.NewPresenceService(
+		serverID,
+		presenceRepository,
+		cachedUsersRepository,
+		cachedParticipantRepository,
+		activeClients,
+		services.NewPresenceBroker(broker),
+		PresenceSweepInterval,
+	)
+	_ = presenceService.Subscribe(ctx)
+	defer func() {
+		_ = presenceService.Close()
+	}()
 
-	broadcaster := services.NewRedisBroadcaster(redisClient, notificationService)
-	_ = broadcaster.Subscribe(ctx)
+	notificationService := services.NewNotificationService(
+		ctx,
+		serverID,
+		activeClients,
+		presenceService,
+		services.NewRedisBroadcaster(broker, serverID),
+		redisPubsub.NewEventLog(redisClient, EventLogMaxLength, EventLogTTL),
+	)
 
 	messageService := services.NewMessageService(
 		messagesRepository,
+		queries,
 		notificationService,
 	)
 
+	scheduledMessageService := services.NewScheduledMessageService(
+		scheduledMessagesRepository,
+		queries,
+	)
+
+	scheduledMessageDispatcher := services.NewScheduledMessageDispatcher(
+		ctx,
+		scheduledMessagesRepository,
+		queries,
+		messageService,
+		ScheduledMessageInterval,
+		ScheduledMessageBatchSize,
+		ScheduledMessageMaxAttempts,
+		ScheduledMessageRetryBase,
+		ScheduledMessageRetryMax,
+	)
+	go scheduledMessageDispatcher.Run()
+	defer scheduledMessageDispatcher.Shutdown()
+
+	messageReaper := services.NewMessageReaper(
+		ctx,
+		messagesRepository,
+		notificationService,
+		MessageReaperInterval,
+		MessageReaperBatchSize,
+	)
+	go messageReaper.Run()
+	defer messageReaper.Shutdown()
+
+	reactionService := services.NewReactionService(
+		reactionsRepository,
+		messagesRepository,
+		queries,
+		notificationService,
+	)
+
+	blobStore, err := newBlobStore(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	attachmentProcessor := services.NewAttachmentProcessor(
+		ctx,
+		attachmentsRepository,
+		blobStore,
+		media.NewImageProcessor(media.DefaultThumbnailSize),
+		notificationService,
+		AttachmentProcessingWorkers,
+		AttachmentSweepInterval,
+		AttachmentSweepBatchSize,
+	)
+	go attachmentProcessor.Run()
+	defer attachmentProcessor.Shutdown()
+
+	attachmentService := services.NewAttachmentService(
+		attachmentsRepository,
+		messageService,
+		queries,
+		blobStore,
+		attachmentProcessor,
+	)
+
 	groupConversationService := services.NewGroupConversationService(
 		cachedGroupConversationsRepository,
 		queries,
@@ -157,15 +300,53 @@ func main() {
 	)
 	directConversationService := services.NewDirectConversationService(
 		directConversationsRepository,
+		queries,
 		notificationService,
 		cacheService,
 	)
+	inviteTokens := services.NewInviteTokens(os.Getenv("INVITE_LINK_SECRET"))
+	inviteLinkService := services.NewInviteLinkService(
+		inviteLinksRepository,
+		queries,
+		inviteTokens,
+	)
 	membershipService := services.NewMembershipService(
 		cachedParticipantRepository,
+		inviteLinksRepository,
+		joinRequestsRepository,
+		queries,
+		messageService,
+		groupConversationService,
+		inviteTokens,
+		notificationService,
+		cacheService,
+	)
+	blockService := services.NewBlockService(
+		blocksRepository,
+		queries,
+	)
+
+	pinLimit, _ := strconv.Atoi(os.Getenv("PIN_LIMIT"))
+	if pinLimit == 0 {
+		pinLimit = DefaultPinLimit
+	}
+
+	pinService := services.NewPinService(
+		pinsRepository,
+		messagesRepository,
 		queries,
 		messageService,
 		notificationService,
 		cacheService,
+		pinLimit,
+	)
+
+	pollService := services.NewPollService(
+		pollsRepository,
+		messagesRepository,
+		queries,
+		messageService,
+		notificationService,
 	)
 
 	maxUserConnections, _ := strconv.Atoi(os.Getenv("WS_RATE_LIMIT_MAX_USER"))
@@ -202,7 +383,14 @@ func main() {
 		groupConversationService,
 		directConversationService,
 		membershipService,
+		inviteLinkService,
+		blockService,
+		pinService,
+		pollService,
 		messageService,
+		scheduledMessageService,
+		reactionService,
+		attachmentService,
 		notificationService,
 		queries,
 		ipRateLimiter,
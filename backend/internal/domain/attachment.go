@@ -0,0 +1,229 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorAttachmentEmpty          = errors.New("attachment is empty")
+	ErrorAttachmentTooLarge       = errors.New("attachment is too large")
+	ErrorAttachmentTypeNotAllowed = errors.New("attachment type is not allowed")
+	ErrorAttachmentNotFound       = errors.New("attachment not found")
+	ErrorCaptionTooLong           = errors.New("caption is too long")
+	ErrorAttachmentNotReady       = errors.New("attachment is not ready")
+	ErrorAttachmentNotProcessing  = errors.New("attachment is not being processed")
+	ErrorUnknownAttachmentStatus  = errors.New("unknown attachment status")
+)
+
+const (
+	MaxImageAttachmentSize = 10 << 20
+	MaxFileAttachmentSize  = 25 << 20
+	MaxAttachmentNameSize  = 255
+)
+
+var attachmentSizeLimits = map[string]int64{
+	"image/png":                 MaxImageAttachmentSize,
+	"image/jpeg":                MaxImageAttachmentSize,
+	"image/gif":                 MaxImageAttachmentSize,
+	"image/webp":                MaxImageAttachmentSize,
+	"application/pdf":           MaxFileAttachmentSize,
+	"application/zip":           MaxFileAttachmentSize,
+	"text/plain; charset=utf-8": MaxFileAttachmentSize,
+	"audio/mpeg":                MaxFileAttachmentSize,
+	"audio/wave":                MaxFileAttachmentSize,
+	"application/ogg":           MaxFileAttachmentSize,
+	"video/mp4":                 MaxFileAttachmentSize,
+	"video/webm":                MaxFileAttachmentSize,
+}
+
+func AttachmentSizeLimit(contentType string) (int64, error) {
+	limit, ok := attachmentSizeLimits[contentType]
+	if !ok {
+		return 0, ErrorAttachmentTypeNotAllowed
+	}
+	return limit, nil
+}
+
+type AttachmentStatus struct {
+	slug string
+}
+
+func (r AttachmentStatus) String() string {
+	return r.slug
+}
+
+var (
+	AttachmentStatusProcessing = AttachmentStatus{"processing"}
+	AttachmentStatusReady      = AttachmentStatus{"ready"}
+	AttachmentStatusFailed     = AttachmentStatus{"failed"}
+)
+
+func NewAttachmentStatus(slug string) (AttachmentStatus, error) {
+	switch slug {
+	case AttachmentStatusProcessing.slug:
+		return AttachmentStatusProcessing, nil
+	case AttachmentStatusReady.slug:
+		return AttachmentStatusReady, nil
+	case AttachmentStatusFailed.slug:
+		return AttachmentStatusFailed, nil
+	default:
+		return AttachmentStatus{}, ErrorUnknownAttachmentStatus
+	}
+}
+
+type Thumbnail struct {
+	StorageKey  string
+	ContentType string
+	Width       int
+	Height      int
+}
+
+type Attachment struct {
+	ID             uuid.UUID
+	MessageID      uuid.UUID
+	ConversationID uuid.UUID
+	UserID         uuid.UUID
+	FileName       string
+	ContentType    string
+	Size           int64
+	StorageKey     string
+	Status         AttachmentStatus
+	Width          int
+	Height         int
+	Blurhash       string
+	Thumbnail      *Thumbnail
+}
+
+func NewAttachment(conversationID uuid.UUID, userID uuid.UUID, fileName string, contentType string, size int64) (*Attachment, error) {
+	if size <= 0 {
+		return nil, ErrorAttachmentEmpty
+	}
+
+	limit, err := AttachmentSizeLimit(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	if size > limit {
+		return nil, ErrorAttachmentTooLarge
+	}
+
+	id := uuid.New()
+
+	status := AttachmentStatusReady
+	if strings.HasPrefix(contentType, "image/") {
+		status = AttachmentStatusProcessing
+	}
+
+	return &Attachment{
+		ID:             id,
+		ConversationID: conversationID,
+		UserID:         userID,
+		FileName:       normalizeAttachmentName(fileName),
+		ContentType:    contentType,
+		Size:           size,
+		StorageKey:     fmt.Sprintf("attachments/%s/%s", conversationID, id),
+		Status:         status,
+	}, nil
+}
+
+func (a *Attachment) IsImage() bool {
+	return strings.HasPrefix(a.ContentType, "image/")
+}
+
+func (a *Attachment) ThumbnailKey() string {
+	return a.StorageKey + ".thumbnail"
+}
+
+// ProcessedKey is where the sanitized image is written. The upload stays in
+// place until the attachment points at the processed copy, so a job that fails
+// before then can be retried from the original bytes; after that the original
+// is deleted and never served.
+func (a *Attachment) ProcessedKey() string {
+	return a.StorageKey + ".processed"
+}
+
+func (a *Attachment) MarkProcessed(storageKey string, size int64, width int, height int, blurhash string, thumbnail Thumbnail) error {
+	if a.Status != AttachmentStatusProcessing {
+		return ErrorAttachmentNotProcessing
+	}
+
+	a.Status = AttachmentStatusReady
+	a.StorageKey = storageKey
+	a.Size = size
+	a.Width = width
+	a.Height = height
+	a.Blurhash = blurhash
+	a.Thumbnail = &thumbnail
+
+	return nil
+}
+
+func (a *Attachment) MarkFailed() error {
+	if a.Status != AttachmentStatusProcessing {
+		return ErrorAttachmentNotProcessing
+	}
+
+	a.Status = AttachmentStatusFailed
+
+	return nil
+}
+
+func normalizeAttachmentName(fileName string) string {
+	name := path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return "file"
+	}
+
+	if len(name) > MaxAttachmentNameSize {
+		name = strings.ToValidUTF8(name[:MaxAttachmentNameSize], "")
+	}
+
+	return name
+}
+
+type attachmentMessageContent struct {
+	attachment Attachment
+	caption    string
+}
+
+func NewAttachmentMessageContent(attachment Attachment, caption string) (attachmentMessageContent, error) {
+	if len(caption) > 1000 {
+		return attachmentMessageContent{}, ErrorCaptionTooLong
+	}
+
+	return attachmentMessageContent{
+		attachment: attachment,
+		caption:    sanitizer.Sanitize(caption),
+	}, nil
+}
+
+// RestoreAttachmentMessageContent wraps a caption that was sanitized before it
+// was stored.
+func RestoreAttachmentMessageContent(attachment Attachment, caption string) attachmentMessageContent {
+	return attachmentMessageContent{
+		attachment: attachment,
+		caption:    caption,
+	}
+}
+
+func (m attachmentMessageContent) String() string {
+	return m.caption
+}
+
+func (m attachmentMessageContent) Attachment() Attachment {
+	return m.attachment
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAttachment(t *testing.T) {
+	conversationID := uuid.New()
+	userID := uuid.New()
+
+	attachment, err := NewAttachment(conversationID, userID, "../../etc/photo.png", "image/png", 1024)
+
+	assert.NoError(t, err)
+	assert.Equal(t, conversationID, attachment.ConversationID)
+	assert.Equal(t, userID, attachment.UserID)
+	assert.Equal(t, "photo.png", attachment.FileName)
+	assert.Equal(t, int64(1024), attachment.Size)
+	assert.Equal(t, fmt.Sprintf("attachments/%s/%s", conversationID, attachment.ID), attachment.StorageKey)
+	assert.True(t, attachment.IsImage())
+}
+
+func TestNewAttachment_Validation(t *testing.T) {
+	conversationID := uuid.New()
+	userID := uuid.New()
+
+	_, err := NewAttachment(conversationID, userID, "empty.txt", "text/plain; charset=utf-8", 0)
+	assert.ErrorIs(t, err, ErrorAttachmentEmpty)
+
+	_, err = NewAttachment(conversationID, userID, "page.html", "text/html; charset=utf-8", 10)
+	assert.ErrorIs(t, err, ErrorAttachmentTypeNotAllowed)
+
+	_, err = NewAttachment(conversationID, userID, "big.png", "image/png", MaxImageAttachmentSize+1)
+	assert.ErrorIs(t, err, ErrorAttachmentTooLarge)
+
+	_, err = NewAttachment(conversationID, userID, "big.pdf", "application/pdf", MaxImageAttachmentSize+1)
+	assert.NoError(t, err)
+}
+
+func TestNormalizeAttachmentName(t *testing.T) {
+	assert.Equal(t, "report.pdf", normalizeAttachmentName(`C:\Users\me\report.pdf`))
+	assert.Equal(t, "file", normalizeAttachmentName(""))
+	assert.Equal(t, "file", normalizeAttachmentName("../"))
+	assert.Equal(t, "ab.txt", normalizeAttachmentName("a\x00b\n.txt"))
+	assert.Len(t, normalizeAttachmentName(strings.Repeat("a", 300)), MaxAttachmentNameSize)
+}
+
+func TestAttachmentMessage(t *testing.T) {
+	attachment, err := NewAttachment(uuid.New(), uuid.New(), "photo.png", "image/png", 1024)
+	assert.NoError(t, err)
+
+	message, err := NewAttachmentMessage(attachment, "<script>alert(1)</script>look")
+
+	assert.NoError(t, err)
+	assert.Equal(t, attachment.ConversationID, message.ConversationID)
+	assert.Equal(t, attachment.UserID, message.UserID)
+	assert.Equal(t, "look", message.Content.String())
+	assert.Equal(t, attachment, message.Attachment())
+
+	err = message.Edit(attachment.UserID, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", message.Content.String())
+	assert.Equal(t, attachment, message.Attachment())
+
+	_, err = NewAttachmentMessage(attachment, strings.Repeat("a", 1001))
+	assert.ErrorIs(t, err, ErrorCaptionTooLong)
+}
+
+func TestAttachmentProcessing(t *testing.T) {
+	image, err := NewAttachment(uuid.New(), uuid.New(), "photo.png", "image/png", 1024)
+	assert.NoError(t, err)
+	assert.Equal(t, AttachmentStatusProcessing, image.Status)
+
+	file, err := NewAttachment(uuid.New(), uuid.New(), "report.pdf", "application/pdf", 1024)
+	assert.NoError(t, err)
+	assert.Equal(t, AttachmentStatusReady, file.Status)
+	assert.ErrorIs(t, file.MarkFailed(), ErrorAttachmentNotProcessing)
+
+	thumbnail := Thumbnail{StorageKey: image.ThumbnailKey(), ContentType: "image/png", Width: 320, Height: 240}
+	processedKey := image.ProcessedKey()
+	assert.NoError(t, image.MarkProcessed(processedKey, 900, 640, 480, "LEHV6nWB2yk8", thumbnail))
+	assert.Equal(t, AttachmentStatusReady, image.Status)
+	assert.Equal(t, processedKey, image.StorageKey)
+	assert.Equal(t, int64(900), image.Size)
+	assert.Equal(t, &thumbnail, image.Thumbnail)
+	assert.ErrorIs(t, image.MarkFailed(), ErrorAttachmentNotProcessing)
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorCannotBlockSelf = errors.New("cannot block yourself")
+	ErrorUserBlocked     = errors.New("user has blocked you")
+	ErrorBlockNotFound   = errors.New("user is not blocked")
+)
+
+type Block struct {
+	BlockerID uuid.UUID
+	BlockedID uuid.UUID
+	CreatedAt time.Time
+}
+
+func NewBlock(blockerID uuid.UUID, blockedID uuid.UUID) (*Block, error) {
+	if blockerID == blockedID {
+		return nil, ErrorCannotBlockSelf
+	}
+
+	return &Block{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+		CreatedAt: time.Now(),
+	}, nil
+}
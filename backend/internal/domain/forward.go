@@ -0,0 +1,48 @@
+package domain
+
+import "github.com/google/uuid"
+
+type ForwardedFrom struct {
+	MessageID      uuid.UUID
+	UserID         uuid.UUID
+	ConversationID uuid.UUID
+}
+
+func NewForwardedMessage(original *Message, conversationID uuid.UUID, userID uuid.UUID) (*Message, error) {
+	if original.Type != MessageTypeUser || original.Poll() != nil {
+		return nil, ErrorMessageNotForwardable
+	}
+
+	forwardedFrom := original.ForwardedFrom
+	if forwardedFrom == nil {
+		forwardedFrom = &ForwardedFrom{
+			MessageID:      original.ID,
+			UserID:         original.UserID,
+			ConversationID: original.ConversationID,
+		}
+	}
+
+	message := &Message{
+		ID:             uuid.New(),
+		ConversationID: conversationID,
+		UserID:         userID,
+		Type:           MessageTypeUser,
+		Content:        original.Content,
+		ForwardedFrom:  forwardedFrom,
+	}
+
+	if attachment := original.Attachment(); attachment != nil {
+		if attachment.Status != AttachmentStatusReady {
+			return nil, ErrorAttachmentNotReady
+		}
+
+		attachment.ID = uuid.New()
+		attachment.MessageID = message.ID
+		attachment.ConversationID = conversationID
+		attachment.UserID = userID
+
+		message.Content = RestoreAttachmentMessageContent(*attachment, original.Content.String())
+	}
+
+	return message, nil
+}
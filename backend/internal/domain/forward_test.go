@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewForwardedMessage(t *testing.T) {
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	authorID := uuid.New()
+	forwarderID := uuid.New()
+
+	t.Run("keeps original author and conversation", func(t *testing.T) {
+		original, _ := NewMessage(sourceID, authorID, MessageTypeUser, "hello")
+
+		message, err := NewForwardedMessage(original, targetID, forwarderID)
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, original.ID, message.ID)
+		assert.Equal(t, targetID, message.ConversationID)
+		assert.Equal(t, forwarderID, message.UserID)
+		assert.Equal(t, "hello", message.Content.String())
+		assert.Equal(t, &ForwardedFrom{MessageID: original.ID, UserID: authorID, ConversationID: sourceID}, message.ForwardedFrom)
+	})
+
+	t.Run("forwarding a forward keeps the first attribution", func(t *testing.T) {
+		original, _ := NewMessage(sourceID, authorID, MessageTypeUser, "hello")
+		forwarded, _ := NewForwardedMessage(original, targetID, forwarderID)
+
+		message, err := NewForwardedMessage(forwarded, uuid.New(), uuid.New())
+
+		assert.NoError(t, err)
+		assert.Equal(t, original.ID, message.ForwardedFrom.MessageID)
+		assert.Equal(t, authorID, message.ForwardedFrom.UserID)
+	})
+
+	t.Run("copies attachment without changing storage", func(t *testing.T) {
+		attachment, _ := NewAttachment(sourceID, authorID, "doc.pdf", "application/pdf", 100)
+		original, _ := NewAttachmentMessage(attachment, "caption")
+
+		message, err := NewForwardedMessage(original, targetID, forwarderID)
+
+		assert.NoError(t, err)
+		copied := message.Attachment()
+		assert.NotNil(t, copied)
+		assert.NotEqual(t, attachment.ID, copied.ID)
+		assert.Equal(t, message.ID, copied.MessageID)
+		assert.Equal(t, targetID, copied.ConversationID)
+		assert.Equal(t, attachment.StorageKey, copied.StorageKey)
+		assert.Equal(t, "caption", message.Content.String())
+	})
+
+	t.Run("attachment still processing", func(t *testing.T) {
+		attachment, _ := NewAttachment(sourceID, authorID, "photo.png", "image/png", 100)
+		original, _ := NewAttachmentMessage(attachment, "")
+
+		_, err := NewForwardedMessage(original, targetID, forwarderID)
+
+		assert.ErrorIs(t, err, ErrorAttachmentNotReady)
+	})
+
+	t.Run("forwarded message cannot be edited", func(t *testing.T) {
+		original, _ := NewMessage(sourceID, authorID, MessageTypeUser, "hello")
+		message, _ := NewForwardedMessage(original, targetID, forwarderID)
+
+		err := message.Edit(forwarderID, "changed")
+
+		assert.ErrorIs(t, err, ErrorMessageNotEditable)
+	})
+
+	t.Run("system message", func(t *testing.T) {
+		original, _ := NewMessage(sourceID, authorID, MessageTypeSystem, "joined")
+
+		_, err := NewForwardedMessage(original, targetID, forwarderID)
+
+		assert.ErrorIs(t, err, ErrorMessageNotForwardable)
+	})
+}
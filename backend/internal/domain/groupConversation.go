@@ -12,8 +12,46 @@ var (
 	ErrorCannotInviteOneself   = errors.New("cannot invite yourself")
 	ErrorCannotKickOneself     = errors.New("cannot kick yourself")
 	ErrorOwnerCannotLeave      = errors.New("owner cannot leave conversation")
+	ErrorCannotTransferToSelf  = errors.New("cannot transfer ownership to yourself")
+	ErrorNoSuccessor           = errors.New("no member to transfer ownership to")
+	ErrorConversationNotFound  = errors.New("conversation not found")
+	ErrorConversationIsPrivate = errors.New("conversation is invite-only")
+	ErrorUnknownVisibility     = errors.New("unknown conversation visibility")
+	ErrorAlreadyInConversation = errors.New("user is already in conversation")
 )
 
+type ConversationVisibility struct {
+	slug string
+}
+
+func (v ConversationVisibility) String() string {
+	return v.slug
+}
+
+var (
+	ConversationVisibilityPublic  = ConversationVisibility{"public"}
+	ConversationVisibilityPrivate = ConversationVisibility{"private"}
+)
+
+func NewConversationVisibility(slug string) (ConversationVisibility, error) {
+	switch slug {
+	case ConversationVisibilityPublic.slug:
+		return ConversationVisibilityPublic, nil
+	case ConversationVisibilityPrivate.slug:
+		return ConversationVisibilityPrivate, nil
+	default:
+		return ConversationVisibility{}, ErrorUnknownVisibility
+	}
+}
+
+func (v ConversationVisibility) AuthorizeJoin() error {
+	if v != ConversationVisibilityPublic {
+		return ErrorConversationIsPrivate
+	}
+
+	return nil
+}
+
 func ValidateConversationName(name string) error {
 	if name == "" {
 		return errors.New("name is empty")
@@ -28,22 +66,27 @@ func ValidateConversationName(name string) error {
 
 type GroupConversation struct {
 	Conversation
-	ID     uuid.UUID
-	Name   string
-	Avatar string
-	Owner  Participant
+	ID         uuid.UUID
+	Name       string
+	Avatar     string
+	Visibility ConversationVisibility
+	Owner      Participant
 }
 
-func NewGroupConversation(id uuid.UUID, name string, creatorId uuid.UUID) (*GroupConversation, error) {
+func NewGroupConversation(id uuid.UUID, name string, creatorId uuid.UUID, visibility ConversationVisibility) (*GroupConversation, error) {
+	owner := NewParticipant(uuid.New(), id, creatorId)
+	owner.Role = ParticipantRoleOwner
+
 	groupConversation := &GroupConversation{
 		Conversation: Conversation{
 			ID:   id,
 			Type: ConversationTypeGroup,
 		},
-		ID:     uuid.New(),
-		Name:   name,
-		Avatar: string(name[0]),
-		Owner:  *NewParticipant(uuid.New(), id, creatorId),
+		ID:         uuid.New(),
+		Name:       name,
+		Avatar:     string(name[0]),
+		Visibility: visibility,
+		Owner:      *owner,
 	}
 
 	return groupConversation, nil
@@ -53,33 +96,6 @@ func (groupConversation *GroupConversation) isJoined(participant *Participant) b
 	return participant.ConversationID == groupConversation.Conversation.ID
 }
 
-func (groupConversation *GroupConversation) Delete(participant *Participant) error {
-	if !groupConversation.isJoined(participant) {
-		return ErrorUserNotInConversation
-	}
-
-	if groupConversation.Owner.UserID != participant.UserID {
-		return ErrorUserNotOwner
-	}
-
-	return nil
-}
-
-func (groupConversation *GroupConversation) Rename(newName string, participant *Participant) error {
-	if !groupConversation.isJoined(participant) {
-		return ErrorUserNotInConversation
-	}
-
-	if groupConversation.Owner.UserID != participant.UserID {
-		return ErrorUserNotOwner
-	}
-
-	groupConversation.Name = newName
-	groupConversation.Avatar = string(newName[0])
-
-	return nil
-}
-
 func (groupConversation *GroupConversation) Invite(inviter *Participant, invitee *User) (*Participant, error) {
 	if !groupConversation.isJoined(inviter) {
 		return nil, ErrorUserNotInConversation
@@ -89,6 +105,10 @@ func (groupConversation *GroupConversation) Invite(inviter *Participant, invitee
 		return nil, ErrorCannotInviteOneself
 	}
 
+	if err := inviter.Authorize(PermissionInvite); err != nil {
+		return nil, err
+	}
+
 	participant := NewParticipant(uuid.New(), groupConversation.Conversation.ID, invitee.ID)
 
 	return participant, nil
@@ -99,13 +119,13 @@ func (groupConversation *GroupConversation) Kick(kicker *Participant, target *Pa
 		return nil, ErrorUserNotInConversation
 	}
 
-	if groupConversation.Owner.UserID != kicker.UserID {
-		return nil, ErrorUserNotOwner
-	}
-
 	if kicker.UserID == target.UserID {
 		return nil, ErrorCannotKickOneself
 	}
 
+	if err := kicker.Moderate(target, PermissionKick); err != nil {
+		return nil, err
+	}
+
 	return target, nil
 }
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorInviteLinkNotFound       = errors.New("invite link not found")
+	ErrorInvalidInviteToken       = errors.New("invalid invite token")
+	ErrorInviteLinkExpired        = errors.New("invite link has expired")
+	ErrorInviteLinkRevoked        = errors.New("invite link has been revoked")
+	ErrorInviteLinkExhausted      = errors.New("invite link has reached its usage limit")
+	ErrorInvalidInviteLinkMaxUses = errors.New("invite link max uses must be positive")
+	ErrorInvalidInviteLinkExpiry  = errors.New("invite link expiry must be in the future")
+)
+
+type InviteLink struct {
+	ID             uuid.UUID
+	ConversationID uuid.UUID
+	CreatorID      uuid.UUID
+	MaxUses        *int
+	UseCount       int
+	ExpiresAt      *time.Time
+	RevokedAt      *time.Time
+	CreatedAt      time.Time
+}
+
+func NewInviteLink(conversationID uuid.UUID, creatorID uuid.UUID, maxUses *int, expiresAt *time.Time, now time.Time) (*InviteLink, error) {
+	if maxUses != nil && *maxUses <= 0 {
+		return nil, ErrorInvalidInviteLinkMaxUses
+	}
+
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, ErrorInvalidInviteLinkExpiry
+	}
+
+	return &InviteLink{
+		ID:             uuid.New(),
+		ConversationID: conversationID,
+		CreatorID:      creatorID,
+		MaxUses:        maxUses,
+		ExpiresAt:      expiresAt,
+		CreatedAt:      now,
+	}, nil
+}
+
+func (link *InviteLink) Validate(conversationID uuid.UUID, now time.Time) error {
+	if link.ConversationID != conversationID {
+		return ErrorInvalidInviteToken
+	}
+
+	if link.RevokedAt != nil {
+		return ErrorInviteLinkRevoked
+	}
+
+	if link.ExpiresAt != nil && !link.ExpiresAt.After(now) {
+		return ErrorInviteLinkExpired
+	}
+
+	if link.MaxUses != nil && link.UseCount >= *link.MaxUses {
+		return ErrorInviteLinkExhausted
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInviteLink(t *testing.T) {
+	now := time.Now()
+	conversationID := uuid.New()
+	creatorID := uuid.New()
+	maxUses := 5
+	expiresAt := now.Add(time.Hour)
+
+	link, err := NewInviteLink(conversationID, creatorID, &maxUses, &expiresAt, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, conversationID, link.ConversationID)
+	assert.Equal(t, creatorID, link.CreatorID)
+	assert.Equal(t, 0, link.UseCount)
+	assert.NotEqual(t, uuid.Nil, link.ID)
+
+	zero := 0
+	_, err = NewInviteLink(conversationID, creatorID, &zero, nil, now)
+	assert.ErrorIs(t, err, ErrorInvalidInviteLinkMaxUses)
+
+	past := now.Add(-time.Minute)
+	_, err = NewInviteLink(conversationID, creatorID, nil, &past, now)
+	assert.ErrorIs(t, err, ErrorInvalidInviteLinkExpiry)
+}
+
+func TestInviteLink_Validate(t *testing.T) {
+	now := time.Now()
+	conversationID := uuid.New()
+	maxUses := 2
+	expiresAt := now.Add(time.Hour)
+
+	newLink := func() *InviteLink {
+		link, err := NewInviteLink(conversationID, uuid.New(), &maxUses, &expiresAt, now)
+		assert.NoError(t, err)
+		return link
+	}
+
+	assert.NoError(t, newLink().Validate(conversationID, now))
+	assert.ErrorIs(t, newLink().Validate(uuid.New(), now), ErrorInvalidInviteToken)
+	assert.ErrorIs(t, newLink().Validate(conversationID, expiresAt), ErrorInviteLinkExpired)
+
+	exhausted := newLink()
+	exhausted.UseCount = maxUses
+	assert.ErrorIs(t, exhausted.Validate(conversationID, now), ErrorInviteLinkExhausted)
+
+	revoked := newLink()
+	revoked.RevokedAt = &now
+	assert.ErrorIs(t, revoked.Validate(conversationID, now), ErrorInviteLinkRevoked)
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorJoinRequestNotFound       = errors.New("join request not found")
+	ErrorJoinRequestPending        = errors.New("join request is already pending")
+	ErrorJoinRequestAlreadyDecided = errors.New("join request has already been decided")
+	ErrorJoinRequestNotNeeded      = errors.New("conversation is open to join")
+	ErrorUnknownJoinRequestStatus  = errors.New("unknown join request status")
+)
+
+type JoinRequestStatus struct {
+	slug string
+}
+
+func (s JoinRequestStatus) String() string {
+	return s.slug
+}
+
+var (
+	JoinRequestStatusPending  = JoinRequestStatus{"pending"}
+	JoinRequestStatusApproved = JoinRequestStatus{"approved"}
+	JoinRequestStatusRejected = JoinRequestStatus{"rejected"}
+)
+
+func NewJoinRequestStatus(slug string) (JoinRequestStatus, error) {
+	switch slug {
+	case JoinRequestStatusPending.slug:
+		return JoinRequestStatusPending, nil
+	case JoinRequestStatusApproved.slug:
+		return JoinRequestStatusApproved, nil
+	case JoinRequestStatusRejected.slug:
+		return JoinRequestStatusRejected, nil
+	default:
+		return JoinRequestStatus{}, ErrorUnknownJoinRequestStatus
+	}
+}
+
+type JoinRequest struct {
+	ID             uuid.UUID
+	ConversationID uuid.UUID
+	UserID         uuid.UUID
+	Status         JoinRequestStatus
+	DecidedBy      *uuid.UUID
+	CreatedAt      time.Time
+}
+
+func NewJoinRequest(conversationID uuid.UUID, userID uuid.UUID, visibility ConversationVisibility) (*JoinRequest, error) {
+	if visibility == ConversationVisibilityPublic {
+		return nil, ErrorJoinRequestNotNeeded
+	}
+
+	return &JoinRequest{
+		ID:             uuid.New(),
+		ConversationID: conversationID,
+		UserID:         userID,
+		Status:         JoinRequestStatusPending,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+func (request *JoinRequest) Approve(approver *Participant) (*Participant, error) {
+	if err := request.decide(approver, JoinRequestStatusApproved); err != nil {
+		return nil, err
+	}
+
+	return NewParticipant(uuid.New(), request.ConversationID, request.UserID), nil
+}
+
+func (request *JoinRequest) Reject(approver *Participant) error {
+	return request.decide(approver, JoinRequestStatusRejected)
+}
+
+func (request *JoinRequest) decide(approver *Participant, status JoinRequestStatus) error {
+	if approver.ConversationID != request.ConversationID {
+		return ErrorJoinRequestNotFound
+	}
+
+	if err := approver.Authorize(PermissionManageJoinRequests); err != nil {
+		return err
+	}
+
+	if request.Status != JoinRequestStatusPending {
+		return ErrorJoinRequestAlreadyDecided
+	}
+
+	request.Status = status
+	request.DecidedBy = &approver.UserID
+
+	return nil
+}
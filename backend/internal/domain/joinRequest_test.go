@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJoinRequest(t *testing.T) {
+	conversationID := uuid.New()
+	userID := uuid.New()
+
+	request, err := NewJoinRequest(conversationID, userID, ConversationVisibilityPrivate)
+
+	assert.NoError(t, err)
+	assert.Equal(t, conversationID, request.ConversationID)
+	assert.Equal(t, userID, request.UserID)
+	assert.Equal(t, JoinRequestStatusPending, request.Status)
+
+	_, err = NewJoinRequest(conversationID, userID, ConversationVisibilityPublic)
+	assert.ErrorIs(t, err, ErrorJoinRequestNotNeeded)
+}
+
+func TestJoinRequest_Decide(t *testing.T) {
+	conversationID := uuid.New()
+	newWithRole := func(role ParticipantRole) *Participant {
+		participant := NewParticipant(uuid.New(), conversationID, uuid.New())
+		participant.Role = role
+		return participant
+	}
+	newRequest := func() *JoinRequest {
+		request, err := NewJoinRequest(conversationID, uuid.New(), ConversationVisibilityPrivate)
+		assert.NoError(t, err)
+		return request
+	}
+
+	t.Run("admin approves", func(t *testing.T) {
+		request := newRequest()
+		admin := newWithRole(ParticipantRoleAdmin)
+
+		participant, err := request.Approve(admin)
+
+		assert.NoError(t, err)
+		assert.Equal(t, JoinRequestStatusApproved, request.Status)
+		assert.Equal(t, admin.UserID, *request.DecidedBy)
+		assert.Equal(t, request.UserID, participant.UserID)
+		assert.Equal(t, ParticipantRoleMember, participant.Role)
+	})
+
+	t.Run("owner rejects", func(t *testing.T) {
+		request := newRequest()
+
+		assert.NoError(t, request.Reject(newWithRole(ParticipantRoleOwner)))
+		assert.Equal(t, JoinRequestStatusRejected, request.Status)
+	})
+
+	t.Run("moderator cannot decide", func(t *testing.T) {
+		_, err := newRequest().Approve(newWithRole(ParticipantRoleModerator))
+
+		assert.ErrorIs(t, err, ErrorInsufficientPermissions)
+	})
+
+	t.Run("cannot decide twice", func(t *testing.T) {
+		request := newRequest()
+		assert.NoError(t, request.Reject(newWithRole(ParticipantRoleAdmin)))
+
+		_, err := request.Approve(newWithRole(ParticipantRoleAdmin))
+
+		assert.ErrorIs(t, err, ErrorJoinRequestAlreadyDecided)
+	})
+
+	t.Run("approver from another conversation", func(t *testing.T) {
+		_, err := newRequest().Approve(NewParticipant(uuid.New(), uuid.New(), uuid.New()))
+
+		assert.ErrorIs(t, err, ErrorJoinRequestNotFound)
+	})
+}
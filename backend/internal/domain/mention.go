@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_])@([\p{L}\p{N}_.-]+)`)
+
+// ParseMentions returns the lowercased, deduplicated names mentioned in text.
+// Usernames are matched case-insensitively, see ValidateUsername.
+func ParseMentions(text string) []string {
+	matches := mentionPattern.FindAllStringSubmatch(text, -1)
+
+	seen := make(map[string]struct{}, len(matches))
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		name := strings.ToLower(strings.TrimRight(match[1], ".-"))
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func (message *Message) MentionedNames() []string {
+	if message.Type != MessageTypeUser {
+		return nil
+	}
+
+	return ParseMentions(message.Content.String())
+}
+
+func (message *Message) Mention(userIDs []uuid.UUID) {
+	seen := make(map[uuid.UUID]struct{}, len(userIDs))
+	mentions := make([]uuid.UUID, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if userID == message.UserID {
+			continue
+		}
+		if _, ok := seen[userID]; ok {
+			continue
+		}
+		seen[userID] = struct{}{}
+		mentions = append(mentions, userID)
+	}
+
+	message.Mentions = mentions
+}
+
+// MentionedSince returns the mentioned users that are not in previous, so an
+// edit only notifies users who were not mentioned before.
+func (message *Message) MentionedSince(previous []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(previous))
+	for _, userID := range previous {
+		seen[userID] = struct{}{}
+	}
+
+	added := make([]uuid.UUID, 0, len(message.Mentions))
+	for _, userID := range message.Mentions {
+		if _, ok := seen[userID]; ok {
+			continue
+		}
+		added = append(added, userID)
+	}
+
+	return added
+}
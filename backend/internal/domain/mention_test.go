@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"hi @alice and @bob", []string{"alice", "bob"}},
+		{"@alice, thanks @alice.", []string{"alice"}},
+		{"mail me at bob@example.com", []string{}},
+		{"ping @john.doe-2!", []string{"john.doe-2"}},
+		{"nobody here", []string{}},
+		{"hey @Alice and @alice", []string{"alice"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseMentions(tt.text))
+		})
+	}
+}
+
+func TestMessage_Mention(t *testing.T) {
+	authorID := uuid.New()
+	mentionedID := uuid.New()
+	message, err := NewMessage(uuid.New(), authorID, MessageTypeUser, "hey @someone")
+	assert.NoError(t, err)
+
+	message.Mention([]uuid.UUID{mentionedID, authorID, mentionedID})
+
+	assert.Equal(t, []uuid.UUID{mentionedID}, message.Mentions)
+}
+
+func TestMessage_MentionedSince(t *testing.T) {
+	keptID := uuid.New()
+	addedID := uuid.New()
+	message, err := NewMessage(uuid.New(), uuid.New(), MessageTypeUser, "hey @someone")
+	assert.NoError(t, err)
+
+	message.Mention([]uuid.UUID{keptID, addedID})
+
+	assert.Equal(t, []uuid.UUID{addedID}, message.MentionedSince([]uuid.UUID{keptID, uuid.New()}))
+}
+
+func TestMessage_MentionedNames_IgnoresSystemMessages(t *testing.T) {
+	message, err := NewMessage(uuid.New(), uuid.New(), MessageTypeSystem, "renamed to @team")
+	assert.NoError(t, err)
+
+	assert.Empty(t, message.MentionedNames())
+}
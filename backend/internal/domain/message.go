@@ -7,6 +7,19 @@ import (
 	"github.com/microcosm-cc/bluemonday"
 )
 
+var (
+	ErrorUserNotAuthor         = errors.New("user is not author")
+	ErrorMessageNotEditable    = errors.New("message cannot be edited")
+	ErrorMessageNotDeletable   = errors.New("message cannot be deleted")
+	ErrorUnknownDeletionScope  = errors.New("unknown deletion scope")
+	ErrorMessageNotRepliable   = errors.New("message cannot be replied to")
+	ErrorMessageNotForwardable = errors.New("message cannot be forwarded")
+	ErrorMessageNotFound       = errors.New("message not found")
+	ErrorMessageAlreadyExists  = errors.New("message already exists")
+	ErrorTextEmpty             = errors.New("text is empty")
+	ErrorTextTooLong           = errors.New("text is too long")
+)
+
 type MessageType struct {
 	slug string
 }
@@ -20,6 +33,30 @@ var (
 	MessageTypeSystem = MessageType{"system"}
 )
 
+type MessageDeletionScope struct {
+	slug string
+}
+
+func (r MessageDeletionScope) String() string {
+	return r.slug
+}
+
+var (
+	MessageDeletionScopeSelf     = MessageDeletionScope{"self"}
+	MessageDeletionScopeEveryone = MessageDeletionScope{"everyone"}
+)
+
+func NewMessageDeletionScope(slug string) (MessageDeletionScope, error) {
+	switch slug {
+	case MessageDeletionScopeSelf.slug:
+		return MessageDeletionScopeSelf, nil
+	case MessageDeletionScopeEveryone.slug:
+		return MessageDeletionScopeEveryone, nil
+	default:
+		return MessageDeletionScope{}, ErrorUnknownDeletionScope
+	}
+}
+
 type messageContent interface {
 	String() string
 }
@@ -30,6 +67,9 @@ type Message struct {
 	UserID         uuid.UUID
 	Content        messageContent
 	Type           MessageType
+	ParentID       *uuid.UUID
+	Mentions       []uuid.UUID
+	ForwardedFrom  *ForwardedFrom
 }
 
 func NewMessage(conversationID uuid.UUID, userID uuid.UUID, messageType MessageType, content string) (*Message, error) {
@@ -48,6 +88,105 @@ func NewMessage(conversationID uuid.UUID, userID uuid.UUID, messageType MessageT
 	return &message, nil
 }
 
+func NewAttachmentMessage(attachment *Attachment, caption string) (*Message, error) {
+	id := uuid.New()
+	attachment.MessageID = id
+
+	content, err := NewAttachmentMessageContent(*attachment, caption)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		ID:             id,
+		ConversationID: attachment.ConversationID,
+		UserID:         attachment.UserID,
+		Type:           MessageTypeUser,
+		Content:        content,
+	}, nil
+}
+
+func NewReplyMessage(parent *Message, userID uuid.UUID, content string) (*Message, error) {
+	if parent.Type != MessageTypeUser {
+		return nil, ErrorMessageNotRepliable
+	}
+
+	message, err := NewMessage(parent.ConversationID, userID, MessageTypeUser, content)
+	if err != nil {
+		return nil, err
+	}
+
+	threadID := parent.ID
+	if parent.ParentID != nil {
+		threadID = *parent.ParentID
+	}
+	message.ParentID = &threadID
+
+	return message, nil
+}
+
+func (message *Message) Edit(editorID uuid.UUID, content string) error {
+	if message.Type != MessageTypeUser || message.ForwardedFrom != nil || message.Poll() != nil {
+		return ErrorMessageNotEditable
+	}
+
+	if message.UserID != editorID {
+		return ErrorUserNotAuthor
+	}
+
+	if attachment := message.Attachment(); attachment != nil {
+		caption, err := NewAttachmentMessageContent(*attachment, content)
+		if err != nil {
+			return err
+		}
+
+		message.Content = caption
+
+		return nil
+	}
+
+	text, err := NewTextMessageContent(content)
+	if err != nil {
+		return err
+	}
+
+	message.Content = text
+
+	return nil
+}
+
+func (message *Message) Attachment() *Attachment {
+	content, ok := message.Content.(attachmentMessageContent)
+	if !ok {
+		return nil
+	}
+
+	attachment := content.Attachment()
+	return &attachment
+}
+
+func (message *Message) Poll() *Poll {
+	content, ok := message.Content.(pollMessageContent)
+	if !ok {
+		return nil
+	}
+
+	poll := content.Poll()
+	return &poll
+}
+
+func (message *Message) Delete(deleterID uuid.UUID, canDeleteOthers bool) error {
+	if message.Type != MessageTypeUser {
+		return ErrorMessageNotDeletable
+	}
+
+	if message.UserID != deleterID && !canDeleteOthers {
+		return ErrorUserNotAuthor
+	}
+
+	return nil
+}
+
 type textMessageContent struct {
 	text string
 }
@@ -56,11 +195,11 @@ var sanitizer = bluemonday.UGCPolicy()
 
 func NewTextMessageContent(text string) (textMessageContent, error) {
 	if text == "" {
-		return textMessageContent{}, errors.New("text is empty")
+		return textMessageContent{}, ErrorTextEmpty
 	}
 
 	if len(text) > 1000 {
-		return textMessageContent{}, errors.New("text is too long")
+		return textMessageContent{}, ErrorTextTooLong
 	}
 
 	sanitizedText := sanitizer.Sanitize(text)
@@ -70,6 +209,14 @@ func NewTextMessageContent(text string) (textMessageContent, error) {
 	}, nil
 }
 
+// RestoreTextMessageContent wraps text that was sanitized before it was stored.
+// Validating it again would reject valid messages that grew when escaped.
+func RestoreTextMessageContent(text string) textMessageContent {
+	return textMessageContent{
+		text: text,
+	}
+}
+
 func (m textMessageContent) String() string {
 	return m.text
 }
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrorInvalidMessageRetention = errors.New("invalid message retention")
+
+const (
+	MinMessageRetention = time.Minute
+	MaxMessageRetention = 365 * 24 * time.Hour
+)
+
+type MessageRetention struct {
+	ttl time.Duration
+}
+
+func NewMessageRetention(ttl time.Duration) (MessageRetention, error) {
+	if ttl == 0 {
+		return MessageRetention{}, nil
+	}
+
+	if ttl < MinMessageRetention || ttl > MaxMessageRetention || ttl%time.Second != 0 {
+		return MessageRetention{}, ErrorInvalidMessageRetention
+	}
+
+	return MessageRetention{ttl: ttl}, nil
+}
+
+func (r MessageRetention) Enabled() bool {
+	return r.ttl > 0
+}
+
+func (r MessageRetention) TTL() time.Duration {
+	return r.ttl
+}
+
+func (r MessageRetention) String() string {
+	s := r.ttl.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+
+	return s
+}
+
+type ExpiredMessage struct {
+	ID             uuid.UUID
+	ConversationID uuid.UUID
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMessageRetention(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		enabled bool
+		err     error
+	}{
+		{"disabled", 0, false, nil},
+		{"one hour", time.Hour, true, nil},
+		{"too short", time.Second, false, ErrorInvalidMessageRetention},
+		{"too long", 2 * MaxMessageRetention, false, ErrorInvalidMessageRetention},
+		{"negative", -time.Hour, false, ErrorInvalidMessageRetention},
+		{"sub-second precision", time.Hour + time.Millisecond, false, ErrorInvalidMessageRetention},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retention, err := NewMessageRetention(tt.ttl)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.enabled, retention.Enabled())
+		})
+	}
+}
+
+func TestMessageRetention_String(t *testing.T) {
+	tests := []struct {
+		ttl  time.Duration
+		want string
+	}{
+		{time.Minute, "1m"},
+		{90 * time.Second, "1m30s"},
+		{24 * time.Hour, "24h"},
+		{time.Hour + 10*time.Minute, "1h10m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			retention, err := NewMessageRetention(tt.ttl)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, retention.String())
+		})
+	}
+}
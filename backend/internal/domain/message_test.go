@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreTextMessageContent(t *testing.T) {
+	content, err := NewTextMessageContent(strings.Repeat("x & y ", 150))
+	assert.NoError(t, err)
+	assert.Greater(t, len(content.String()), 1000)
+
+	restored := RestoreTextMessageContent(content.String())
+
+	assert.Equal(t, content.String(), restored.String())
+}
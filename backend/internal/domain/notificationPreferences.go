@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrorUnknownNotificationLevel = errors.New("unknown notification level")
+
+type NotificationLevel struct {
+	slug string
+}
+
+func (l NotificationLevel) String() string {
+	return l.slug
+}
+
+var (
+	NotificationLevelAll      = NotificationLevel{"all"}
+	NotificationLevelMentions = NotificationLevel{"mentions"}
+)
+
+func NewNotificationLevel(slug string) (NotificationLevel, error) {
+	switch slug {
+	case NotificationLevelAll.slug:
+		return NotificationLevelAll, nil
+	case NotificationLevelMentions.slug:
+		return NotificationLevelMentions, nil
+	default:
+		return NotificationLevel{}, ErrorUnknownNotificationLevel
+	}
+}
+
+type NotificationPreferences struct {
+	Level      NotificationLevel
+	MutedUntil *time.Time
+}
+
+func NewNotificationPreferences(level string, mutedUntil *time.Time) (NotificationPreferences, error) {
+	notificationLevel, err := NewNotificationLevel(level)
+	if err != nil {
+		return NotificationPreferences{}, err
+	}
+
+	return NotificationPreferences{
+		Level:      notificationLevel,
+		MutedUntil: mutedUntil,
+	}, nil
+}
+
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{Level: NotificationLevelAll}
+}
+
+func (p NotificationPreferences) IsMuted(now time.Time) bool {
+	return p.MutedUntil != nil && p.MutedUntil.After(now)
+}
+
+func (p NotificationPreferences) ShouldAlert(mentioned bool, now time.Time) bool {
+	if p.IsMuted(now) {
+		return false
+	}
+
+	return p.Level == NotificationLevelAll || mentioned
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNotificationPreferences(t *testing.T) {
+	preferences, err := NewNotificationPreferences("mentions", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, NotificationLevelMentions, preferences.Level)
+
+	_, err = NewNotificationPreferences("sometimes", nil)
+	assert.ErrorIs(t, err, ErrorUnknownNotificationLevel)
+}
+
+func TestNotificationPreferences_ShouldAlert(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		preferences NotificationPreferences
+		mentioned   bool
+		expected    bool
+	}{
+		{"all", NotificationPreferences{Level: NotificationLevelAll}, false, true},
+		{"mentions without mention", NotificationPreferences{Level: NotificationLevelMentions}, false, false},
+		{"mentions with mention", NotificationPreferences{Level: NotificationLevelMentions}, true, true},
+		{"muted", NotificationPreferences{Level: NotificationLevelAll, MutedUntil: &future}, true, false},
+		{"mute expired", NotificationPreferences{Level: NotificationLevelAll, MutedUntil: &past}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.preferences.ShouldAlert(tt.mentioned, now))
+		})
+	}
+}
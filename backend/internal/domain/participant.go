@@ -1,19 +1,253 @@
 package domain
 
 import (
+	"errors"
+
 	"github.com/google/uuid"
 )
 
+var (
+	ErrorUnknownParticipantRole  = errors.New("unknown participant role")
+	ErrorInsufficientPermissions = errors.New("insufficient permissions")
+	ErrorCannotChangeOwnRole     = errors.New("cannot change your own role")
+	ErrorCannotAssignRole        = errors.New("cannot assign this role")
+)
+
+type ParticipantRole struct {
+	slug string
+}
+
+func (r ParticipantRole) String() string {
+	return r.slug
+}
+
+var (
+	ParticipantRoleOwner     = ParticipantRole{"owner"}
+	ParticipantRoleAdmin     = ParticipantRole{"admin"}
+	ParticipantRoleModerator = ParticipantRole{"moderator"}
+	ParticipantRoleMember    = ParticipantRole{"member"}
+)
+
+var ParticipantRoles = []ParticipantRole{
+	ParticipantRoleOwner,
+	ParticipantRoleAdmin,
+	ParticipantRoleModerator,
+	ParticipantRoleMember,
+}
+
+func NewParticipantRole(slug string) (ParticipantRole, error) {
+	switch slug {
+	case ParticipantRoleOwner.slug:
+		return ParticipantRoleOwner, nil
+	case ParticipantRoleAdmin.slug:
+		return ParticipantRoleAdmin, nil
+	case ParticipantRoleModerator.slug:
+		return ParticipantRoleModerator, nil
+	case ParticipantRoleMember.slug:
+		return ParticipantRoleMember, nil
+	default:
+		return ParticipantRole{}, ErrorUnknownParticipantRole
+	}
+}
+
+type Permission struct {
+	slug string
+}
+
+func (p Permission) String() string {
+	return p.slug
+}
+
+var (
+	PermissionInvite             = Permission{"invite"}
+	PermissionManageInviteLinks  = Permission{"manage_invite_links"}
+	PermissionManageJoinRequests = Permission{"manage_join_requests"}
+	PermissionKick               = Permission{"kick"}
+	PermissionRename             = Permission{"rename"}
+	PermissionChangeVisibility   = Permission{"change_visibility"}
+	PermissionPinMessages        = Permission{"pin_messages"}
+	PermissionDeleteMessages     = Permission{"delete_messages"}
+	PermissionManageRoles        = Permission{"manage_roles"}
+	PermissionSetRetention       = Permission{"set_retention"}
+	PermissionDeleteConversation = Permission{"delete_conversation"}
+)
+
+var Permissions = []Permission{
+	PermissionInvite,
+	PermissionManageInviteLinks,
+	PermissionManageJoinRequests,
+	PermissionKick,
+	PermissionRename,
+	PermissionChangeVisibility,
+	PermissionPinMessages,
+	PermissionDeleteMessages,
+	PermissionManageRoles,
+	PermissionSetRetention,
+	PermissionDeleteConversation,
+}
+
+var roleRanks = map[ParticipantRole]int{
+	ParticipantRoleMember:    0,
+	ParticipantRoleModerator: 1,
+	ParticipantRoleAdmin:     2,
+	ParticipantRoleOwner:     3,
+}
+
+var rolePermissions = map[ParticipantRole]map[Permission]bool{
+	ParticipantRoleOwner: {
+		PermissionInvite:             true,
+		PermissionManageInviteLinks:  true,
+		PermissionManageJoinRequests: true,
+		PermissionKick:               true,
+		PermissionRename:             true,
+		PermissionChangeVisibility:   true,
+		PermissionPinMessages:        true,
+		PermissionDeleteMessages:     true,
+		PermissionManageRoles:        true,
+		PermissionSetRetention:       true,
+		PermissionDeleteConversation: true,
+	},
+	ParticipantRoleAdmin: {
+		PermissionInvite:             true,
+		PermissionManageInviteLinks:  true,
+		PermissionManageJoinRequests: true,
+		PermissionKick:               true,
+		PermissionRename:             true,
+		PermissionChangeVisibility:   true,
+		PermissionPinMessages:        true,
+		PermissionDeleteMessages:     true,
+		PermissionManageRoles:        true,
+	},
+	ParticipantRoleModerator: {
+		PermissionInvite:         true,
+		PermissionKick:           true,
+		PermissionPinMessages:    true,
+		PermissionDeleteMessages: true,
+	},
+	ParticipantRoleMember: {
+		PermissionInvite: true,
+	},
+}
+
+func (r ParticipantRole) Can(permission Permission) bool {
+	return rolePermissions[r][permission]
+}
+
+func (r ParticipantRole) Outranks(other ParticipantRole) bool {
+	return roleRanks[r] > roleRanks[other]
+}
+
+func (r ParticipantRole) Permissions() []string {
+	permissions := make([]string, 0, len(Permissions))
+	for _, permission := range Permissions {
+		if r.Can(permission) {
+			permissions = append(permissions, permission.String())
+		}
+	}
+	return permissions
+}
+
+func (p Permission) Roles() []string {
+	roles := make([]string, 0, len(ParticipantRoles))
+	for _, role := range ParticipantRoles {
+		if role.Can(p) {
+			roles = append(roles, role.String())
+		}
+	}
+	return roles
+}
+
 type Participant struct {
-	ID             uuid.UUID
-	ConversationID uuid.UUID
-	UserID         uuid.UUID
+	ID                      uuid.UUID
+	ConversationID          uuid.UUID
+	UserID                  uuid.UUID
+	Role                    ParticipantRole
+	NotificationPreferences NotificationPreferences
 }
 
 func NewParticipant(participantID uuid.UUID, conversationID uuid.UUID, userID uuid.UUID) *Participant {
 	return &Participant{
-		ID:             participantID,
-		ConversationID: conversationID,
-		UserID:         userID,
+		ID:                      participantID,
+		ConversationID:          conversationID,
+		UserID:                  userID,
+		Role:                    ParticipantRoleMember,
+		NotificationPreferences: DefaultNotificationPreferences(),
+	}
+}
+
+func (participant *Participant) Authorize(permission Permission) error {
+	if !participant.Role.Can(permission) {
+		return ErrorInsufficientPermissions
+	}
+
+	return nil
+}
+
+func (participant *Participant) Moderate(target *Participant, permission Permission) error {
+	if participant.ConversationID != target.ConversationID {
+		return ErrorUserNotInConversation
+	}
+
+	if err := participant.Authorize(permission); err != nil {
+		return err
+	}
+
+	if !participant.Role.Outranks(target.Role) {
+		return ErrorInsufficientPermissions
 	}
+
+	return nil
+}
+
+func (participant *Participant) Promote(target *Participant, role ParticipantRole) error {
+	if !role.Outranks(target.Role) {
+		return ErrorCannotAssignRole
+	}
+
+	return participant.changeRole(target, role)
+}
+
+func (participant *Participant) Demote(target *Participant, role ParticipantRole) error {
+	if !target.Role.Outranks(role) {
+		return ErrorCannotAssignRole
+	}
+
+	return participant.changeRole(target, role)
+}
+
+func (participant *Participant) TransferOwnership(successor *Participant) error {
+	if participant.ConversationID != successor.ConversationID {
+		return ErrorUserNotInConversation
+	}
+
+	if participant.Role != ParticipantRoleOwner {
+		return ErrorUserNotOwner
+	}
+
+	if participant.UserID == successor.UserID {
+		return ErrorCannotTransferToSelf
+	}
+
+	participant.Role = ParticipantRoleAdmin
+	successor.Role = ParticipantRoleOwner
+
+	return nil
+}
+
+func (participant *Participant) changeRole(target *Participant, role ParticipantRole) error {
+	if participant.UserID == target.UserID {
+		return ErrorCannotChangeOwnRole
+	}
+
+	if err := participant.Moderate(target, PermissionManageRoles); err != nil {
+		return err
+	}
+
+	if role == ParticipantRoleOwner || !participant.Role.Outranks(role) {
+		return ErrorCannotAssignRole
+	}
+
+	target.Role = role
+
+	return nil
 }
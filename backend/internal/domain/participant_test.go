@@ -18,3 +18,133 @@ func TestNewParticipant(t *testing.T) {
 	assert.Equal(t, userID, participant.UserID)
 	assert.Equal(t, participantID, participant.ID)
 }
+
+func TestNewParticipant_DefaultsToMember(t *testing.T) {
+	participant := NewParticipant(uuid.New(), uuid.New(), uuid.New())
+
+	assert.Equal(t, ParticipantRoleMember, participant.Role)
+}
+
+func TestParticipantRole_Permissions(t *testing.T) {
+	tests := []struct {
+		role    ParticipantRole
+		allowed []Permission
+		denied  []Permission
+	}{
+		{ParticipantRoleOwner, Permissions, nil},
+		{ParticipantRoleAdmin, []Permission{PermissionManageInviteLinks, PermissionManageJoinRequests, PermissionKick, PermissionRename, PermissionChangeVisibility, PermissionPinMessages, PermissionManageRoles}, []Permission{PermissionSetRetention, PermissionDeleteConversation}},
+		{ParticipantRoleModerator, []Permission{PermissionKick, PermissionPinMessages, PermissionDeleteMessages}, []Permission{PermissionManageInviteLinks, PermissionManageJoinRequests, PermissionRename, PermissionChangeVisibility, PermissionManageRoles}},
+		{ParticipantRoleMember, []Permission{PermissionInvite}, []Permission{PermissionKick, PermissionPinMessages, PermissionDeleteMessages}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role.String(), func(t *testing.T) {
+			for _, permission := range tt.allowed {
+				assert.True(t, tt.role.Can(permission), permission.String())
+			}
+			for _, permission := range tt.denied {
+				assert.False(t, tt.role.Can(permission), permission.String())
+			}
+		})
+	}
+}
+
+func TestPermission_Roles(t *testing.T) {
+	assert.Equal(t, []string{"owner", "admin"}, PermissionManageJoinRequests.Roles())
+	assert.Equal(t, []string{"owner"}, PermissionDeleteConversation.Roles())
+}
+
+func TestParticipant_Moderate(t *testing.T) {
+	conversationID := uuid.New()
+	newWithRole := func(role ParticipantRole) *Participant {
+		participant := NewParticipant(uuid.New(), conversationID, uuid.New())
+		participant.Role = role
+		return participant
+	}
+
+	assert.NoError(t, newWithRole(ParticipantRoleModerator).Moderate(newWithRole(ParticipantRoleMember), PermissionKick))
+	assert.ErrorIs(t, newWithRole(ParticipantRoleModerator).Moderate(newWithRole(ParticipantRoleModerator), PermissionKick), ErrorInsufficientPermissions)
+	assert.ErrorIs(t, newWithRole(ParticipantRoleMember).Moderate(newWithRole(ParticipantRoleMember), PermissionKick), ErrorInsufficientPermissions)
+	assert.ErrorIs(t, newWithRole(ParticipantRoleOwner).Moderate(NewParticipant(uuid.New(), uuid.New(), uuid.New()), PermissionKick), ErrorUserNotInConversation)
+}
+
+func TestParticipant_ChangeRole(t *testing.T) {
+	conversationID := uuid.New()
+	newWithRole := func(role ParticipantRole) *Participant {
+		participant := NewParticipant(uuid.New(), conversationID, uuid.New())
+		participant.Role = role
+		return participant
+	}
+
+	t.Run("owner promotes member to admin", func(t *testing.T) {
+		target := newWithRole(ParticipantRoleMember)
+
+		assert.NoError(t, newWithRole(ParticipantRoleOwner).Promote(target, ParticipantRoleAdmin))
+		assert.Equal(t, ParticipantRoleAdmin, target.Role)
+	})
+
+	t.Run("admin promotes member to moderator", func(t *testing.T) {
+		target := newWithRole(ParticipantRoleMember)
+
+		assert.NoError(t, newWithRole(ParticipantRoleAdmin).Promote(target, ParticipantRoleModerator))
+		assert.Equal(t, ParticipantRoleModerator, target.Role)
+	})
+
+	t.Run("admin cannot grant admin", func(t *testing.T) {
+		assert.ErrorIs(t, newWithRole(ParticipantRoleAdmin).Promote(newWithRole(ParticipantRoleMember), ParticipantRoleAdmin), ErrorCannotAssignRole)
+	})
+
+	t.Run("nobody can grant owner", func(t *testing.T) {
+		assert.ErrorIs(t, newWithRole(ParticipantRoleOwner).Promote(newWithRole(ParticipantRoleAdmin), ParticipantRoleOwner), ErrorCannotAssignRole)
+	})
+
+	t.Run("admin cannot demote admin", func(t *testing.T) {
+		assert.ErrorIs(t, newWithRole(ParticipantRoleAdmin).Demote(newWithRole(ParticipantRoleAdmin), ParticipantRoleMember), ErrorInsufficientPermissions)
+	})
+
+	t.Run("moderator cannot manage roles", func(t *testing.T) {
+		assert.ErrorIs(t, newWithRole(ParticipantRoleModerator).Promote(newWithRole(ParticipantRoleMember), ParticipantRoleModerator), ErrorInsufficientPermissions)
+	})
+
+	t.Run("demote must lower the role", func(t *testing.T) {
+		assert.ErrorIs(t, newWithRole(ParticipantRoleOwner).Demote(newWithRole(ParticipantRoleModerator), ParticipantRoleAdmin), ErrorCannotAssignRole)
+	})
+
+	t.Run("cannot change own role", func(t *testing.T) {
+		owner := newWithRole(ParticipantRoleOwner)
+
+		assert.ErrorIs(t, owner.Demote(owner, ParticipantRoleAdmin), ErrorCannotChangeOwnRole)
+	})
+}
+
+func TestParticipant_TransferOwnership(t *testing.T) {
+	conversationID := uuid.New()
+	newWithRole := func(role ParticipantRole) *Participant {
+		participant := NewParticipant(uuid.New(), conversationID, uuid.New())
+		participant.Role = role
+		return participant
+	}
+
+	t.Run("owner hands over to member", func(t *testing.T) {
+		owner := newWithRole(ParticipantRoleOwner)
+		successor := newWithRole(ParticipantRoleMember)
+
+		assert.NoError(t, owner.TransferOwnership(successor))
+		assert.Equal(t, ParticipantRoleAdmin, owner.Role)
+		assert.Equal(t, ParticipantRoleOwner, successor.Role)
+	})
+
+	t.Run("only owner can transfer", func(t *testing.T) {
+		assert.ErrorIs(t, newWithRole(ParticipantRoleAdmin).TransferOwnership(newWithRole(ParticipantRoleMember)), ErrorUserNotOwner)
+	})
+
+	t.Run("cannot transfer to self", func(t *testing.T) {
+		owner := newWithRole(ParticipantRoleOwner)
+
+		assert.ErrorIs(t, owner.TransferOwnership(owner), ErrorCannotTransferToSelf)
+	})
+
+	t.Run("successor must be in the conversation", func(t *testing.T) {
+		assert.ErrorIs(t, newWithRole(ParticipantRoleOwner).TransferOwnership(NewParticipant(uuid.New(), uuid.New(), uuid.New())), ErrorUserNotInConversation)
+	})
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorMessageNotPinnable   = errors.New("message cannot be pinned")
+	ErrorMessageAlreadyPinned = errors.New("message is already pinned")
+	ErrorMessageNotPinned     = errors.New("message is not pinned")
+	ErrorPinLimitReached      = errors.New("pinned messages limit reached")
+)
+
+type Pin struct {
+	MessageID      uuid.UUID
+	ConversationID uuid.UUID
+	PinnedBy       uuid.UUID
+	CreatedAt      time.Time
+}
+
+// NewPin checks who may pin what. The per-conversation limit depends on
+// concurrent pins, so the repository enforces it when storing.
+func NewPin(message *Message, pinner *Participant) (*Pin, error) {
+	if err := authorizePin(message, pinner); err != nil {
+		return nil, err
+	}
+
+	if message.Type != MessageTypeUser {
+		return nil, ErrorMessageNotPinnable
+	}
+
+	return &Pin{
+		MessageID:      message.ID,
+		ConversationID: message.ConversationID,
+		PinnedBy:       pinner.UserID,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+func AuthorizeUnpin(message *Message, unpinner *Participant) error {
+	return authorizePin(message, unpinner)
+}
+
+func authorizePin(message *Message, participant *Participant) error {
+	if message.ConversationID != participant.ConversationID {
+		return ErrorUserNotInConversation
+	}
+
+	return participant.Authorize(PermissionPinMessages)
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPin(t *testing.T) {
+	conversationID := uuid.New()
+	newWithRole := func(role ParticipantRole) *Participant {
+		participant := NewParticipant(uuid.New(), conversationID, uuid.New())
+		participant.Role = role
+		return participant
+	}
+	newMessage := func(messageType MessageType) *Message {
+		message, err := NewMessage(conversationID, uuid.New(), messageType, "hello")
+		assert.NoError(t, err)
+		return message
+	}
+
+	t.Run("moderator pins", func(t *testing.T) {
+		message := newMessage(MessageTypeUser)
+		moderator := newWithRole(ParticipantRoleModerator)
+
+		pin, err := NewPin(message, moderator)
+
+		assert.NoError(t, err)
+		assert.Equal(t, message.ID, pin.MessageID)
+		assert.Equal(t, moderator.UserID, pin.PinnedBy)
+	})
+
+	t.Run("member cannot pin", func(t *testing.T) {
+		_, err := NewPin(newMessage(MessageTypeUser), newWithRole(ParticipantRoleMember))
+
+		assert.ErrorIs(t, err, ErrorInsufficientPermissions)
+	})
+
+	t.Run("system messages cannot be pinned", func(t *testing.T) {
+		_, err := NewPin(newMessage(MessageTypeSystem), newWithRole(ParticipantRoleOwner))
+
+		assert.ErrorIs(t, err, ErrorMessageNotPinnable)
+	})
+
+	t.Run("message from another conversation", func(t *testing.T) {
+		message, err := NewMessage(uuid.New(), uuid.New(), MessageTypeUser, "hello")
+		assert.NoError(t, err)
+
+		_, err = NewPin(message, newWithRole(ParticipantRoleOwner))
+
+		assert.ErrorIs(t, err, ErrorUserNotInConversation)
+	})
+}
@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorPollQuestionEmpty     = errors.New("poll question is empty")
+	ErrorPollQuestionTooLong   = errors.New("poll question is too long")
+	ErrorPollOptionsCount      = errors.New("poll must have between 2 and 10 options")
+	ErrorPollOptionInvalid     = errors.New("poll option is invalid")
+	ErrorPollOptionDuplicate   = errors.New("poll option is duplicated")
+	ErrorPollNotFound          = errors.New("poll not found")
+	ErrorPollClosed            = errors.New("poll is closed")
+	ErrorPollInvalidChoice     = errors.New("poll choice is invalid")
+	ErrorPollMultipleChoiceOff = errors.New("poll allows a single choice")
+)
+
+const (
+	MaxPollQuestionLength = 300
+	MaxPollOptionLength   = 100
+	MinPollOptions        = 2
+	MaxPollOptions        = 10
+)
+
+type PollOption struct {
+	ID   uuid.UUID
+	Text string
+}
+
+type Poll struct {
+	ID             uuid.UUID
+	MessageID      uuid.UUID
+	ConversationID uuid.UUID
+	UserID         uuid.UUID
+	Question       string
+	Options        []PollOption
+	MultipleChoice bool
+	Anonymous      bool
+	ClosedAt       *time.Time
+}
+
+func NewPoll(conversationID uuid.UUID, userID uuid.UUID, question string, options []string, multipleChoice bool, anonymous bool) (*Poll, error) {
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return nil, ErrorPollQuestionEmpty
+	}
+	if len(question) > MaxPollQuestionLength {
+		return nil, ErrorPollQuestionTooLong
+	}
+
+	if len(options) < MinPollOptions || len(options) > MaxPollOptions {
+		return nil, ErrorPollOptionsCount
+	}
+
+	pollOptions := make([]PollOption, 0, len(options))
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		text := sanitizer.Sanitize(strings.TrimSpace(option))
+		if text == "" || len(text) > MaxPollOptionLength {
+			return nil, ErrorPollOptionInvalid
+		}
+
+		if seen[text] {
+			return nil, ErrorPollOptionDuplicate
+		}
+		seen[text] = true
+
+		pollOptions = append(pollOptions, PollOption{ID: uuid.New(), Text: text})
+	}
+
+	return &Poll{
+		ID:             uuid.New(),
+		ConversationID: conversationID,
+		UserID:         userID,
+		Question:       sanitizer.Sanitize(question),
+		Options:        pollOptions,
+		MultipleChoice: multipleChoice,
+		Anonymous:      anonymous,
+	}, nil
+}
+
+func NewPollMessage(poll *Poll) *Message {
+	id := uuid.New()
+	poll.MessageID = id
+
+	return &Message{
+		ID:             id,
+		ConversationID: poll.ConversationID,
+		UserID:         poll.UserID,
+		Type:           MessageTypeUser,
+		Content:        NewPollMessageContent(*poll),
+	}
+}
+
+func (poll *Poll) IsClosed() bool {
+	return poll.ClosedAt != nil
+}
+
+func (poll *Poll) Vote(userID uuid.UUID, optionIDs []uuid.UUID) (*PollVote, error) {
+	if poll.IsClosed() {
+		return nil, ErrorPollClosed
+	}
+
+	if len(optionIDs) == 0 {
+		return nil, ErrorPollInvalidChoice
+	}
+
+	if len(optionIDs) > 1 && !poll.MultipleChoice {
+		return nil, ErrorPollMultipleChoiceOff
+	}
+
+	known := make(map[uuid.UUID]bool, len(poll.Options))
+	for _, option := range poll.Options {
+		known[option.ID] = true
+	}
+
+	chosen := make([]uuid.UUID, 0, len(optionIDs))
+	seen := make(map[uuid.UUID]bool, len(optionIDs))
+	for _, optionID := range optionIDs {
+		if !known[optionID] {
+			return nil, ErrorPollInvalidChoice
+		}
+
+		if seen[optionID] {
+			continue
+		}
+		seen[optionID] = true
+
+		chosen = append(chosen, optionID)
+	}
+
+	return &PollVote{
+		PollID:    poll.ID,
+		UserID:    userID,
+		OptionIDs: chosen,
+	}, nil
+}
+
+func (poll *Poll) Close(userID uuid.UUID, now time.Time) error {
+	if poll.UserID != userID {
+		return ErrorUserNotAuthor
+	}
+
+	if poll.IsClosed() {
+		return ErrorPollClosed
+	}
+
+	poll.ClosedAt = &now
+
+	return nil
+}
+
+type PollVote struct {
+	PollID    uuid.UUID
+	UserID    uuid.UUID
+	OptionIDs []uuid.UUID
+}
+
+type pollMessageContent struct {
+	poll Poll
+}
+
+func NewPollMessageContent(poll Poll) pollMessageContent {
+	return pollMessageContent{poll: poll}
+}
+
+func (m pollMessageContent) String() string {
+	return m.poll.Question
+}
+
+func (m pollMessageContent) Poll() Poll {
+	return m.poll
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPoll(t *testing.T) {
+	conversationID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name     string
+		question string
+		options  []string
+		err      error
+	}{
+		{"valid", "Lunch?", []string{"Pizza", "Sushi"}, nil},
+		{"empty question", "  ", []string{"Pizza", "Sushi"}, ErrorPollQuestionEmpty},
+		{"question too long", strings.Repeat("a", MaxPollQuestionLength+1), []string{"Pizza", "Sushi"}, ErrorPollQuestionTooLong},
+		{"single option", "Lunch?", []string{"Pizza"}, ErrorPollOptionsCount},
+		{"too many options", "Lunch?", strings.Split("a,b,c,d,e,f,g,h,i,j,k", ","), ErrorPollOptionsCount},
+		{"empty option", "Lunch?", []string{"Pizza", " "}, ErrorPollOptionInvalid},
+		{"duplicate option", "Lunch?", []string{"Pizza", "Pizza "}, ErrorPollOptionDuplicate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll, err := NewPoll(conversationID, userID, tt.question, tt.options, false, false)
+
+			assert.ErrorIs(t, err, tt.err)
+			if tt.err == nil {
+				assert.Len(t, poll.Options, len(tt.options))
+			}
+		})
+	}
+}
+
+func TestNewPollMessage(t *testing.T) {
+	poll, _ := NewPoll(uuid.New(), uuid.New(), "Lunch?", []string{"Pizza", "Sushi"}, false, false)
+
+	message := NewPollMessage(poll)
+
+	assert.Equal(t, message.ID, poll.MessageID)
+	assert.Equal(t, "Lunch?", message.Content.String())
+	assert.Equal(t, poll.ID, message.Poll().ID)
+	assert.ErrorIs(t, message.Edit(poll.UserID, "Dinner?"), ErrorMessageNotEditable)
+
+	_, err := NewForwardedMessage(message, uuid.New(), uuid.New())
+	assert.ErrorIs(t, err, ErrorMessageNotForwardable)
+}
+
+func TestPoll_Vote(t *testing.T) {
+	voterID := uuid.New()
+
+	t.Run("single choice", func(t *testing.T) {
+		poll, _ := NewPoll(uuid.New(), uuid.New(), "Lunch?", []string{"Pizza", "Sushi"}, false, false)
+
+		vote, err := poll.Vote(voterID, []uuid.UUID{poll.Options[0].ID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, poll.ID, vote.PollID)
+		assert.Equal(t, []uuid.UUID{poll.Options[0].ID}, vote.OptionIDs)
+
+		_, err = poll.Vote(voterID, []uuid.UUID{poll.Options[0].ID, poll.Options[1].ID})
+		assert.ErrorIs(t, err, ErrorPollMultipleChoiceOff)
+	})
+
+	t.Run("multiple choice deduplicates options", func(t *testing.T) {
+		poll, _ := NewPoll(uuid.New(), uuid.New(), "Lunch?", []string{"Pizza", "Sushi"}, true, false)
+
+		vote, err := poll.Vote(voterID, []uuid.UUID{poll.Options[0].ID, poll.Options[1].ID, poll.Options[0].ID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{poll.Options[0].ID, poll.Options[1].ID}, vote.OptionIDs)
+	})
+
+	t.Run("unknown option", func(t *testing.T) {
+		poll, _ := NewPoll(uuid.New(), uuid.New(), "Lunch?", []string{"Pizza", "Sushi"}, true, false)
+
+		_, err := poll.Vote(voterID, []uuid.UUID{uuid.New()})
+
+		assert.ErrorIs(t, err, ErrorPollInvalidChoice)
+	})
+
+	t.Run("no options", func(t *testing.T) {
+		poll, _ := NewPoll(uuid.New(), uuid.New(), "Lunch?", []string{"Pizza", "Sushi"}, true, false)
+
+		_, err := poll.Vote(voterID, nil)
+
+		assert.ErrorIs(t, err, ErrorPollInvalidChoice)
+	})
+
+	t.Run("closed poll", func(t *testing.T) {
+		poll, _ := NewPoll(uuid.New(), uuid.New(), "Lunch?", []string{"Pizza", "Sushi"}, false, false)
+		_ = poll.Close(poll.UserID, time.Now())
+
+		_, err := poll.Vote(voterID, []uuid.UUID{poll.Options[0].ID})
+
+		assert.ErrorIs(t, err, ErrorPollClosed)
+	})
+}
+
+func TestPoll_Close(t *testing.T) {
+	poll, _ := NewPoll(uuid.New(), uuid.New(), "Lunch?", []string{"Pizza", "Sushi"}, false, false)
+
+	assert.ErrorIs(t, poll.Close(uuid.New(), time.Now()), ErrorUserNotAuthor)
+	assert.NoError(t, poll.Close(poll.UserID, time.Now()))
+	assert.True(t, poll.IsClosed())
+	assert.ErrorIs(t, poll.Close(poll.UserID, time.Now()), ErrorPollClosed)
+}
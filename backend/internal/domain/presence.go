@@ -0,0 +1,62 @@
+package domain
+
+import "errors"
+
+var (
+	ErrorUnknownPresenceStatus       = errors.New("unknown presence status")
+	ErrorPresenceStatusNotReportable = errors.New("presence status cannot be reported")
+)
+
+type PresenceStatus struct {
+	slug string
+}
+
+func (r PresenceStatus) String() string {
+	return r.slug
+}
+
+var (
+	PresenceStatusOnline  = PresenceStatus{"online"}
+	PresenceStatusAway    = PresenceStatus{"away"}
+	PresenceStatusOffline = PresenceStatus{"offline"}
+)
+
+func NewPresenceStatus(slug string) (PresenceStatus, error) {
+	switch slug {
+	case PresenceStatusOnline.slug:
+		return PresenceStatusOnline, nil
+	case PresenceStatusAway.slug:
+		return PresenceStatusAway, nil
+	case PresenceStatusOffline.slug:
+		return PresenceStatusOffline, nil
+	default:
+		return PresenceStatus{}, ErrorUnknownPresenceStatus
+	}
+}
+
+func NewReportedPresenceStatus(slug string) (PresenceStatus, error) {
+	status, err := NewPresenceStatus(slug)
+	if err != nil {
+		return PresenceStatus{}, err
+	}
+
+	if status == PresenceStatusOffline {
+		return PresenceStatus{}, ErrorPresenceStatusNotReportable
+	}
+
+	return status, nil
+}
+
+func AggregatePresence(statuses []PresenceStatus) PresenceStatus {
+	result := PresenceStatusOffline
+	for _, status := range statuses {
+		switch status {
+		case PresenceStatusOnline:
+			return PresenceStatusOnline
+		case PresenceStatusAway:
+			result = PresenceStatusAway
+		}
+	}
+
+	return result
+}
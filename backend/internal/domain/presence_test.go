@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPresenceStatus(t *testing.T) {
+	for _, status := range []PresenceStatus{PresenceStatusOnline, PresenceStatusAway, PresenceStatusOffline} {
+		parsed, err := NewPresenceStatus(status.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, status, parsed)
+	}
+
+	_, err := NewPresenceStatus("busy")
+	assert.ErrorIs(t, err, ErrorUnknownPresenceStatus)
+}
+
+func TestNewReportedPresenceStatus(t *testing.T) {
+	status, err := NewReportedPresenceStatus("away")
+	assert.NoError(t, err)
+	assert.Equal(t, PresenceStatusAway, status)
+
+	_, err = NewReportedPresenceStatus("offline")
+	assert.ErrorIs(t, err, ErrorPresenceStatusNotReportable)
+}
+
+func TestAggregatePresence(t *testing.T) {
+	assert.Equal(t, PresenceStatusOffline, AggregatePresence(nil))
+	assert.Equal(t, PresenceStatusAway, AggregatePresence([]PresenceStatus{PresenceStatusOffline, PresenceStatusAway}))
+	assert.Equal(t, PresenceStatusOnline, AggregatePresence([]PresenceStatus{PresenceStatusAway, PresenceStatusOnline}))
+}
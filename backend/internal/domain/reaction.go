@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+var ErrorInvalidEmoji = errors.New("invalid emoji")
+
+const zeroWidthJoiner = '\u200d'
+
+type Reaction struct {
+	MessageID uuid.UUID
+	UserID    uuid.UUID
+	Emoji     string
+}
+
+func NewReaction(messageID uuid.UUID, userID uuid.UUID, emoji string) (*Reaction, error) {
+	if err := ValidateEmoji(emoji); err != nil {
+		return nil, err
+	}
+
+	return &Reaction{
+		MessageID: messageID,
+		UserID:    userID,
+		Emoji:     emoji,
+	}, nil
+}
+
+func ValidateEmoji(emoji string) error {
+	if emoji == "" || len(emoji) > 32 || !utf8.ValidString(emoji) {
+		return ErrorInvalidEmoji
+	}
+
+	hasSymbol := false
+	for _, r := range emoji {
+		switch {
+		case unicode.Is(unicode.So, r), unicode.Is(unicode.Me, r):
+			hasSymbol = true
+		case unicode.Is(unicode.Sk, r), unicode.Is(unicode.Mn, r):
+		case r == zeroWidthJoiner, r >= 0xE0020 && r <= 0xE007F:
+		case r == '#' || r == '*' || (r >= '0' && r <= '9'):
+		default:
+			return ErrorInvalidEmoji
+		}
+	}
+
+	if !hasSymbol {
+		return ErrorInvalidEmoji
+	}
+
+	return nil
+}
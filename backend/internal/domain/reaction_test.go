@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReaction(t *testing.T) {
+	messageID := uuid.New()
+	userID := uuid.New()
+
+	reaction, err := NewReaction(messageID, userID, "👍")
+
+	assert.NoError(t, err)
+	assert.Equal(t, messageID, reaction.MessageID)
+	assert.Equal(t, userID, reaction.UserID)
+	assert.Equal(t, "👍", reaction.Emoji)
+}
+
+func TestValidateEmoji(t *testing.T) {
+	valid := []string{"👍", "❤️", "👍🏽", "👨‍👩‍👧", "🇺🇦", "1️⃣"}
+	for _, emoji := range valid {
+		assert.NoError(t, ValidateEmoji(emoji), emoji)
+	}
+
+	invalid := []string{"", "a", "1", "lol", "12", "👍 ", "<b>", string([]byte{0xff})}
+	for _, emoji := range invalid {
+		assert.ErrorIs(t, ValidateEmoji(emoji), ErrorInvalidEmoji, emoji)
+	}
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorScheduledTimeInPast      = errors.New("scheduled time must be in the future")
+	ErrorScheduledMessageNotFound = errors.New("scheduled message not found")
+)
+
+type ScheduledMessage struct {
+	ID             uuid.UUID
+	ConversationID uuid.UUID
+	UserID         uuid.UUID
+	Content        messageContent
+	ScheduledAt    time.Time
+	Attempts       int
+}
+
+func NewScheduledMessage(conversationID uuid.UUID, userID uuid.UUID, content string, scheduledAt time.Time, now time.Time) (*ScheduledMessage, error) {
+	if !scheduledAt.After(now) {
+		return nil, ErrorScheduledTimeInPast
+	}
+
+	text, err := NewTextMessageContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScheduledMessage{
+		ID:             uuid.New(),
+		ConversationID: conversationID,
+		UserID:         userID,
+		Content:        text,
+		ScheduledAt:    scheduledAt,
+	}, nil
+}
+
+func (m *ScheduledMessage) Edit(editorID uuid.UUID, content string, scheduledAt time.Time, now time.Time) error {
+	if m.UserID != editorID {
+		return ErrorUserNotAuthor
+	}
+
+	if !scheduledAt.After(now) {
+		return ErrorScheduledTimeInPast
+	}
+
+	text, err := NewTextMessageContent(content)
+	if err != nil {
+		return err
+	}
+
+	m.Content = text
+	m.ScheduledAt = scheduledAt
+
+	return nil
+}
+
+func (m *ScheduledMessage) Cancel(userID uuid.UUID) error {
+	if m.UserID != userID {
+		return ErrorUserNotAuthor
+	}
+
+	return nil
+}
+
+// Message builds the message to send. It reuses the scheduled ID, so sending
+// the same scheduled message twice collides on the message's primary key.
+func (m *ScheduledMessage) Message() *Message {
+	return &Message{
+		ID:             m.ID,
+		ConversationID: m.ConversationID,
+		UserID:         m.UserID,
+		Type:           MessageTypeUser,
+		Content:        m.Content,
+	}
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScheduledMessage(t *testing.T) {
+	now := time.Now()
+	conversationID := uuid.New()
+	userID := uuid.New()
+
+	message, err := NewScheduledMessage(conversationID, userID, "later", now.Add(time.Hour), now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "later", message.Content.String())
+
+	_, err = NewScheduledMessage(conversationID, userID, "too late", now.Add(-time.Minute), now)
+	assert.ErrorIs(t, err, ErrorScheduledTimeInPast)
+}
+
+func TestScheduledMessage_Edit(t *testing.T) {
+	now := time.Now()
+	userID := uuid.New()
+	message, _ := NewScheduledMessage(uuid.New(), userID, "later", now.Add(time.Hour), now)
+
+	t.Run("author edits", func(t *testing.T) {
+		err := message.Edit(userID, "even later", now.Add(2*time.Hour), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "even later", message.Content.String())
+		assert.Equal(t, now.Add(2*time.Hour), message.ScheduledAt)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		err := message.Edit(uuid.New(), "mine now", now.Add(time.Hour), now)
+
+		assert.ErrorIs(t, err, ErrorUserNotAuthor)
+	})
+
+	t.Run("past time", func(t *testing.T) {
+		err := message.Edit(userID, "now", now, now)
+
+		assert.ErrorIs(t, err, ErrorScheduledTimeInPast)
+	})
+}
+
+func TestScheduledMessage_Message(t *testing.T) {
+	now := time.Now()
+	scheduled, _ := NewScheduledMessage(uuid.New(), uuid.New(), "later", now.Add(time.Hour), now)
+
+	message := scheduled.Message()
+
+	assert.Equal(t, scheduled.ID, message.ID)
+	assert.Equal(t, scheduled.ConversationID, message.ConversationID)
+	assert.Equal(t, scheduled.UserID, message.UserID)
+	assert.Equal(t, MessageTypeUser, message.Type)
+	assert.Equal(t, "later", message.Content.String())
+}
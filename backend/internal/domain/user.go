@@ -2,12 +2,17 @@ package domain
 
 import (
 	"errors"
+	"regexp"
 	"unicode"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// usernamePattern only accepts names that mentionPattern can match in full:
+// letters, digits, underscores, dots and dashes, not ending with a dot or dash.
+var usernamePattern = regexp.MustCompile(`^[\p{L}\p{N}_.-]*[\p{L}\p{N}_]$`)
+
 func ValidateUsername(username string) error {
 	if username == "" {
 		return errors.New("username is empty")
@@ -17,6 +22,10 @@ func ValidateUsername(username string) error {
 		return errors.New("username too long")
 	}
 
+	if !usernamePattern.MatchString(username) {
+		return errors.New("username contains invalid characters")
+	}
+
 	return nil
 }
 
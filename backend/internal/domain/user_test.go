@@ -47,6 +47,15 @@ func TestValidateUsernameErrors(t *testing.T) {
 		}, {
 			name:        longName,
 			expectedErr: errors.New("username too long"),
+		}, {
+			name:        "John Doe",
+			expectedErr: errors.New("username contains invalid characters"),
+		}, {
+			name:        "john.",
+			expectedErr: errors.New("username contains invalid characters"),
+		}, {
+			name:        "john!",
+			expectedErr: errors.New("username contains invalid characters"),
 		},
 	}
 
@@ -0,0 +1,9 @@
+package blob
+
+import "errors"
+
+var (
+	ErrBlobNotFound     = errors.New("blob not found")
+	ErrInvalidBlobKey   = errors.New("invalid blob key")
+	ErrBlobSizeMismatch = errors.New("blob size mismatch")
+)
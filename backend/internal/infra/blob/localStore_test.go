@@ -0,0 +1,54 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+
+	t.Run("put, get and delete", func(t *testing.T) {
+		key := "attachments/conversation/file"
+
+		err := store.Put(ctx, key, strings.NewReader("hello"), 5, "text/plain; charset=utf-8")
+		assert.NoError(t, err)
+
+		content, err := store.Get(ctx, key)
+		assert.NoError(t, err)
+		data, _ := io.ReadAll(content)
+		_ = content.Close()
+		assert.Equal(t, "hello", string(data))
+
+		assert.NoError(t, store.Delete(ctx, key))
+		_, err = store.Get(ctx, key)
+		assert.ErrorIs(t, err, ErrBlobNotFound)
+		assert.NoError(t, store.Delete(ctx, key))
+	})
+
+	t.Run("size mismatch is not stored", func(t *testing.T) {
+		key := "attachments/conversation/short"
+
+		err := store.Put(ctx, key, strings.NewReader("hello"), 10, "text/plain; charset=utf-8")
+		assert.ErrorIs(t, err, ErrBlobSizeMismatch)
+
+		err = store.Put(ctx, key, strings.NewReader("hello world"), 5, "text/plain; charset=utf-8")
+		assert.ErrorIs(t, err, ErrBlobSizeMismatch)
+
+		_, err = store.Get(ctx, key)
+		assert.ErrorIs(t, err, ErrBlobNotFound)
+	})
+
+	t.Run("rejects keys escaping the root", func(t *testing.T) {
+		for _, key := range []string{"", "../secret", "/etc/passwd", "a/../../b", "a\\b", "a//b"} {
+			err := store.Put(ctx, key, strings.NewReader("x"), 1, "text/plain; charset=utf-8")
+			assert.ErrorIs(t, err, ErrInvalidBlobKey, key)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3Store(t *testing.T) {
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT is not set, run against a local MinIO to enable")
+	}
+
+	ctx := context.Background()
+	store, err := NewS3Store(ctx, S3Config{
+		Endpoint:  endpoint,
+		AccessKey: os.Getenv("S3_TEST_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_TEST_SECRET_KEY"),
+		Bucket:    "go-chat-test",
+	})
+	assert.NoError(t, err)
+
+	key := "attachments/" + uuid.NewString()
+
+	err = store.Put(ctx, key, strings.NewReader("hello"), 5, "text/plain; charset=utf-8")
+	assert.NoError(t, err)
+
+	content, err := store.Get(ctx, key)
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(content)
+	_ = content.Close()
+	assert.Equal(t, "hello", string(data))
+
+	assert.NoError(t, store.Delete(ctx, key))
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, ErrBlobNotFound)
+}
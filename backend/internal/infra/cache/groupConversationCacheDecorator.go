@@ -85,6 +85,26 @@ func (d *GroupConversationCacheDecorator) Rename(ctx context.Context, id uuid.UU
 	return nil
 }
 
+func (d *GroupConversationCacheDecorator) SetVisibility(ctx context.Context, id uuid.UUID, visibility domain.ConversationVisibility) error {
+	if err := d.repo.SetVisibility(ctx, id, visibility); err != nil {
+		return fmt.Errorf("repo set visibility error: %w", err)
+	}
+
+	d.invalidateConversationCache(ctx, id.String())
+
+	return nil
+}
+
+func (d *GroupConversationCacheDecorator) SetMessageRetention(ctx context.Context, id uuid.UUID, retention domain.MessageRetention) error {
+	if err := d.repo.SetMessageRetention(ctx, id, retention); err != nil {
+		return fmt.Errorf("repo set message retention error: %w", err)
+	}
+
+	d.invalidateConversationCache(ctx, id.String())
+
+	return nil
+}
+
 func (d *GroupConversationCacheDecorator) Delete(ctx context.Context, id uuid.UUID) error {
 	if err := d.repo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("repo delete error: %w", err)
@@ -95,8 +115,38 @@ func (d *GroupConversationCacheDecorator) Delete(ctx context.Context, id uuid.UU
 	return nil
 }
 
+func (d *GroupConversationCacheDecorator) TransferOwnership(ctx context.Context, previousOwner *domain.Participant, newOwner *domain.Participant) error {
+	if err := d.repo.TransferOwnership(ctx, previousOwner, newOwner); err != nil {
+		return fmt.Errorf("repo transfer ownership error: %w", err)
+	}
+
+	d.invalidateConversationCache(ctx, newOwner.ConversationID.String())
+	d.invalidateParticipantCache(ctx, previousOwner, newOwner)
+
+	return nil
+}
+
+func (d *GroupConversationCacheDecorator) TransferOwnershipAndLeave(ctx context.Context, previousOwner *domain.Participant, newOwner *domain.Participant) error {
+	if err := d.repo.TransferOwnershipAndLeave(ctx, previousOwner, newOwner); err != nil {
+		return fmt.Errorf("repo transfer ownership and leave error: %w", err)
+	}
+
+	d.invalidateConversationCache(ctx, newOwner.ConversationID.String())
+	d.invalidateParticipantCache(ctx, previousOwner, newOwner)
+
+	return nil
+}
+
 func (d *GroupConversationCacheDecorator) invalidateConversationCache(ctx context.Context, conversationID string) {
 	_ = d.cache.Delete(ctx, ConversationKey(conversationID))
 	_ = d.cache.Delete(ctx, ConvMetaKey(conversationID))
 	_ = d.cache.DeletePattern(ctx, ParticipantsKey(conversationID))
 }
+
+// invalidateParticipantCache drops the per-user entries the participant cache
+// decorator would have cleared had the rows changed through it.
+func (d *GroupConversationCacheDecorator) invalidateParticipantCache(ctx context.Context, participants ...*domain.Participant) {
+	for _, participant := range participants {
+		_ = d.cache.Delete(ctx, UserConvListKey(participant.UserID.String()))
+	}
+}
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockGroupConversationRepository only implements the ownership transfers, any
+// other call panics on the nil embedded repository.
+type MockGroupConversationRepository struct {
+	repository.GroupConversationRepository
+	mock.Mock
+}
+
+func (m *MockGroupConversationRepository) TransferOwnership(ctx context.Context, previousOwner *domain.Participant, newOwner *domain.Participant) error {
+	args := m.Called(ctx, previousOwner, newOwner)
+	return args.Error(0)
+}
+
+func (m *MockGroupConversationRepository) TransferOwnershipAndLeave(ctx context.Context, previousOwner *domain.Participant, newOwner *domain.Participant) error {
+	args := m.Called(ctx, previousOwner, newOwner)
+	return args.Error(0)
+}
+
+func TestGroupConversationCacheDecorator_TransferOwnership_InvalidatesParticipants(t *testing.T) {
+	conversationID := uuid.New()
+	previousOwner := &domain.Participant{ID: uuid.New(), ConversationID: conversationID, UserID: uuid.New()}
+	newOwner := &domain.Participant{ID: uuid.New(), ConversationID: conversationID, UserID: uuid.New()}
+
+	for _, method := range []string{"TransferOwnership", "TransferOwnershipAndLeave"} {
+		t.Run(method, func(t *testing.T) {
+			mockRepo := new(MockGroupConversationRepository)
+			mockCache := new(MockCacheClient)
+
+			mockRepo.On(method, mock.Anything, previousOwner, newOwner).Return(nil)
+			mockCache.On("Delete", mock.Anything, ConversationKey(conversationID.String())).Return(nil)
+			mockCache.On("Delete", mock.Anything, ConvMetaKey(conversationID.String())).Return(nil)
+			mockCache.On("DeletePattern", mock.Anything, ParticipantsKey(conversationID.String())).Return(nil)
+			mockCache.On("Delete", mock.Anything, UserConvListKey(previousOwner.UserID.String())).Return(nil)
+			mockCache.On("Delete", mock.Anything, UserConvListKey(newOwner.UserID.String())).Return(nil)
+
+			decorator := NewGroupConversationCacheDecorator(mockRepo, mockCache)
+
+			var err error
+			if method == "TransferOwnership" {
+				err = decorator.TransferOwnership(context.Background(), previousOwner, newOwner)
+			} else {
+				err = decorator.TransferOwnershipAndLeave(context.Background(), previousOwner, newOwner)
+			}
+
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+			mockCache.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGroupConversationCacheDecorator_TransferOwnership_KeepsCacheOnError(t *testing.T) {
+	conversationID := uuid.New()
+	previousOwner := &domain.Participant{ID: uuid.New(), ConversationID: conversationID, UserID: uuid.New()}
+	newOwner := &domain.Participant{ID: uuid.New(), ConversationID: conversationID, UserID: uuid.New()}
+
+	mockRepo := new(MockGroupConversationRepository)
+	mockCache := new(MockCacheClient)
+
+	mockRepo.On("TransferOwnership", mock.Anything, previousOwner, newOwner).Return(assert.AnError)
+
+	decorator := NewGroupConversationCacheDecorator(mockRepo, mockCache)
+	err := decorator.TransferOwnership(context.Background(), previousOwner, newOwner)
+
+	assert.ErrorIs(t, err, assert.AnError)
+	mockCache.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
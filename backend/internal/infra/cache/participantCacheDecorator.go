@@ -126,6 +126,42 @@ func (d *ParticipantCacheDecorator) Delete(ctx context.Context, participantID uu
 	return nil
 }
 
+func (d *ParticipantCacheDecorator) MarkRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, messageID uuid.UUID) (bool, error) {
+	advanced, err := d.repo.MarkRead(ctx, conversationID, userID, messageID)
+	if err != nil {
+		return false, fmt.Errorf("repo mark read error: %w", err)
+	}
+
+	return advanced, nil
+}
+
+func (d *ParticipantCacheDecorator) UpdateRole(ctx context.Context, participant *domain.Participant) error {
+	if err := d.repo.UpdateRole(ctx, participant); err != nil {
+		return fmt.Errorf("repo update role error: %w", err)
+	}
+
+	d.invalidateParticipantsCache(ctx, participant.ConversationID.String())
+
+	return nil
+}
+
+func (d *ParticipantCacheDecorator) UpdateNotificationPreferences(ctx context.Context, participant *domain.Participant) error {
+	if err := d.repo.UpdateNotificationPreferences(ctx, participant); err != nil {
+		return fmt.Errorf("repo update notification preferences error: %w", err)
+	}
+
+	return nil
+}
+
+func (d *ParticipantCacheDecorator) GetNotificationPreferencesByUserID(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]domain.NotificationPreferences, error) {
+	preferences, err := d.repo.GetNotificationPreferencesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("repo get notification preferences by user id error: %w", err)
+	}
+
+	return preferences, nil
+}
+
 func (d *ParticipantCacheDecorator) invalidateParticipantsCache(ctx context.Context, conversationID string) {
 	_ = d.cache.Delete(ctx, ParticipantsKey(conversationID))
 }
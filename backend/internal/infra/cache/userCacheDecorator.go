@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"GitHub/go-chat/backend/internal/domain"
 	"GitHub/go-chat/backend/internal/repository"
@@ -129,6 +130,14 @@ func (d *UserCacheDecorator) Update(ctx context.Context, user *domain.User) erro
 	return nil
 }
 
+func (d *UserCacheDecorator) UpdateLastSeen(ctx context.Context, id uuid.UUID, lastSeenAt time.Time) error {
+	if err := d.repo.UpdateLastSeen(ctx, id, lastSeenAt); err != nil {
+		return fmt.Errorf("repo update last seen error: %w", err)
+	}
+
+	return nil
+}
+
 func (d *UserCacheDecorator) invalidateUserCache(ctx context.Context, user *domain.User) {
 	_ = d.cache.Delete(ctx, UserKey(user.ID.String()))
 	_ = d.cache.Delete(ctx, UsernameKey(user.Name))
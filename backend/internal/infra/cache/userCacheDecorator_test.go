@@ -68,6 +68,11 @@ func (m *MockUserRepository) FindByUsername(ctx context.Context, username string
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) UpdateLastSeen(ctx context.Context, id uuid.UUID, lastSeenAt time.Time) error {
+	args := m.Called(ctx, id, lastSeenAt)
+	return args.Error(0)
+}
+
 func TestUserCacheDecorator_GetByID_CacheHit(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockCache := new(MockCacheClient)
@@ -0,0 +1,188 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+var (
+	ErrUnsupportedImage = errors.New("unsupported image type")
+	ErrImageTooLarge    = errors.New("image dimensions are too large")
+)
+
+const (
+	MaxImagePixels       = 50_000_000
+	DefaultThumbnailSize = 320
+	thumbnailJPEGQuality = 80
+	orientedJPEGQuality  = 90
+	blurhashSampleSize   = 32
+	blurhashComponentsX  = 4
+	blurhashComponentsY  = 3
+)
+
+type ProcessedImage struct {
+	Content              []byte
+	Width                int
+	Height               int
+	Blurhash             string
+	Thumbnail            []byte
+	ThumbnailContentType string
+	ThumbnailWidth       int
+	ThumbnailHeight      int
+}
+
+type imageProcessor struct {
+	thumbnailSize int
+}
+
+func NewImageProcessor(thumbnailSize int) *imageProcessor {
+	return &imageProcessor{
+		thumbnailSize: thumbnailSize,
+	}
+}
+
+func (p *imageProcessor) Process(content []byte, contentType string) (ProcessedImage, error) {
+	sanitized, orientation, err := stripMetadata(content, contentType)
+	if err != nil {
+		return ProcessedImage{}, fmt.Errorf("strip metadata error: %w", err)
+	}
+
+	config, err := decodeConfig(sanitized, contentType)
+	if err != nil {
+		return ProcessedImage{}, fmt.Errorf("decode image config error: %w", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxImagePixels {
+		return ProcessedImage{}, ErrImageTooLarge
+	}
+
+	img, err := decode(sanitized, contentType)
+	if err != nil {
+		return ProcessedImage{}, fmt.Errorf("decode image error: %w", err)
+	}
+
+	if orientation > 1 {
+		img = applyOrientation(img, orientation)
+
+		var oriented bytes.Buffer
+		if err := jpeg.Encode(&oriented, img, &jpeg.Options{Quality: orientedJPEGQuality}); err != nil {
+			return ProcessedImage{}, fmt.Errorf("encode oriented image error: %w", err)
+		}
+		sanitized = oriented.Bytes()
+	}
+
+	bounds := img.Bounds()
+	thumbnail := resizeToFit(img, p.thumbnailSize)
+
+	thumbnailContent, thumbnailContentType, err := encodeThumbnail(thumbnail, contentType)
+	if err != nil {
+		return ProcessedImage{}, fmt.Errorf("encode thumbnail error: %w", err)
+	}
+
+	return ProcessedImage{
+		Content:              sanitized,
+		Width:                bounds.Dx(),
+		Height:               bounds.Dy(),
+		Blurhash:             encodeBlurhash(resizeToFit(thumbnail, blurhashSampleSize), blurhashComponentsX, blurhashComponentsY),
+		Thumbnail:            thumbnailContent,
+		ThumbnailContentType: thumbnailContentType,
+		ThumbnailWidth:       thumbnail.Bounds().Dx(),
+		ThumbnailHeight:      thumbnail.Bounds().Dy(),
+	}, nil
+}
+
+func stripMetadata(content []byte, contentType string) ([]byte, int, error) {
+	switch contentType {
+	case "image/jpeg":
+		return stripJPEGMetadata(content)
+	case "image/png":
+		stripped, err := stripPNGMetadata(content)
+		return stripped, 1, err
+	case "image/webp":
+		stripped, err := stripWebPMetadata(content)
+		return stripped, 1, err
+	case "image/gif":
+		return content, 1, nil
+	default:
+		return nil, 0, ErrUnsupportedImage
+	}
+}
+
+func decodeConfig(content []byte, contentType string) (image.Config, error) {
+	reader := bytes.NewReader(content)
+
+	switch contentType {
+	case "image/jpeg":
+		return jpeg.DecodeConfig(reader)
+	case "image/png":
+		return png.DecodeConfig(reader)
+	case "image/webp":
+		return webp.DecodeConfig(reader)
+	case "image/gif":
+		return gif.DecodeConfig(reader)
+	default:
+		return image.Config{}, ErrUnsupportedImage
+	}
+}
+
+func decode(content []byte, contentType string) (image.Image, error) {
+	reader := bytes.NewReader(content)
+
+	switch contentType {
+	case "image/jpeg":
+		return jpeg.Decode(reader)
+	case "image/png":
+		return png.Decode(reader)
+	case "image/webp":
+		return webp.Decode(reader)
+	case "image/gif":
+		return gif.Decode(reader)
+	default:
+		return nil, ErrUnsupportedImage
+	}
+}
+
+func resizeToFit(img image.Image, maxSize int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width <= maxSize && height <= maxSize {
+		return img
+	}
+
+	if width >= height {
+		height = max(1, height*maxSize/width)
+		width = maxSize
+	} else {
+		width = max(1, width*maxSize/height)
+		height = maxSize
+	}
+
+	resized := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(resized, resized.Bounds(), img, bounds, draw.Src, nil)
+
+	return resized
+}
+
+func encodeThumbnail(img image.Image, contentType string) ([]byte, string, error) {
+	var buf bytes.Buffer
+
+	if contentType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/png", nil
+}
@@ -0,0 +1,191 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestImage(width int, height int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func encodeTestPNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func encodeTestJPEG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+func pngChunk(chunkType string, data []byte) []byte {
+	chunk := make([]byte, 8, 12+len(data))
+	binary.BigEndian.PutUint32(chunk[:4], uint32(len(data)))
+	copy(chunk[4:8], chunkType)
+	chunk = append(chunk, data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+func withPNGChunk(content []byte, chunk []byte) []byte {
+	const ihdrEnd = 8 + 12 + 13
+	result := append([]byte{}, content[:ihdrEnd]...)
+	result = append(result, chunk...)
+	return append(result, content[ihdrEnd:]...)
+}
+
+func exifSegment(orientation uint16) []byte {
+	tiff := []byte("II\x2a\x00\x08\x00\x00\x00\x01\x00")
+	tiff = binary.LittleEndian.AppendUint16(tiff, exifOrientationTag)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	payload := append(append([]byte{}, exifHeader...), tiff...)
+	segment := []byte{0xFF, jpegMarkerAPP1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+func withJPEGSegment(content []byte, segment []byte) []byte {
+	result := append([]byte{}, content[:2]...)
+	result = append(result, segment...)
+	return append(result, content[2:]...)
+}
+
+func TestStripPNGMetadata(t *testing.T) {
+	content := withPNGChunk(encodeTestPNG(t, newTestImage(4, 4)), pngChunk("tEXt", []byte("GPS\x0052.1,13.4")))
+
+	stripped, err := stripPNGMetadata(content)
+
+	assert.NoError(t, err)
+	assert.NotContains(t, string(stripped), "tEXt")
+	assert.NotContains(t, string(stripped), "52.1,13.4")
+	_, err = png.Decode(bytes.NewReader(stripped))
+	assert.NoError(t, err)
+
+	_, err = stripPNGMetadata([]byte("not a png"))
+	assert.ErrorIs(t, err, ErrMalformedImage)
+}
+
+func TestStripJPEGMetadata(t *testing.T) {
+	content := withJPEGSegment(encodeTestJPEG(t, newTestImage(4, 4)), exifSegment(6))
+
+	stripped, orientation, err := stripJPEGMetadata(content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, orientation)
+	assert.False(t, bytes.Contains(stripped, exifHeader))
+	_, err = jpeg.Decode(bytes.NewReader(stripped))
+	assert.NoError(t, err)
+
+	_, _, err = stripJPEGMetadata(content[:10])
+	assert.ErrorIs(t, err, ErrMalformedImage)
+}
+
+func TestStripWebPMetadata(t *testing.T) {
+	vp8x := append([]byte("VP8X\x0a\x00\x00\x00"), 0x08|0x04, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+	exif := append([]byte("EXIF\x05\x00\x00\x00"), 'G', 'P', 'S', '!', '!', 0)
+	image := []byte("VP8L\x02\x00\x00\x00\x2f\x00")
+
+	body := append(append(append([]byte("WEBP"), vp8x...), exif...), image...)
+	content := binary.LittleEndian.AppendUint32([]byte("RIFF"), uint32(len(body)))
+	content = append(content, body...)
+
+	stripped, err := stripWebPMetadata(content)
+
+	assert.NoError(t, err)
+	assert.NotContains(t, string(stripped), "EXIF")
+	assert.NotContains(t, string(stripped), "GPS")
+	assert.Equal(t, byte(0), stripped[20])
+	assert.Equal(t, uint32(len(stripped)-8), binary.LittleEndian.Uint32(stripped[4:8]))
+}
+
+func TestApplyOrientation(t *testing.T) {
+	img := newTestImage(4, 2)
+
+	rotated := applyOrientation(img, 6)
+
+	assert.Equal(t, 2, rotated.Bounds().Dx())
+	assert.Equal(t, 4, rotated.Bounds().Dy())
+	assert.Equal(t, img.At(0, 0), rotated.At(1, 0))
+	assert.Equal(t, img, applyOrientation(img, 1))
+}
+
+func TestEncodeBlurhash(t *testing.T) {
+	hash := encodeBlurhash(newTestImage(16, 12), 4, 3)
+
+	assert.Len(t, hash, 28)
+	assert.Equal(t, encodeBase83(3+2*9, 1), hash[:1])
+}
+
+func TestImageProcessor_Process(t *testing.T) {
+	processor := NewImageProcessor(100)
+
+	t.Run("png", func(t *testing.T) {
+		content := withPNGChunk(encodeTestPNG(t, newTestImage(400, 200)), pngChunk("tEXt", []byte("Author\x00someone")))
+
+		processed, err := processor.Process(content, "image/png")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 400, processed.Width)
+		assert.Equal(t, 200, processed.Height)
+		assert.Equal(t, 100, processed.ThumbnailWidth)
+		assert.Equal(t, 50, processed.ThumbnailHeight)
+		assert.Equal(t, "image/png", processed.ThumbnailContentType)
+		assert.NotContains(t, string(processed.Content), "someone")
+		assert.NotEmpty(t, processed.Blurhash)
+
+		thumbnail, err := png.Decode(bytes.NewReader(processed.Thumbnail))
+		assert.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 100, 50), thumbnail.Bounds())
+	})
+
+	t.Run("jpeg with orientation", func(t *testing.T) {
+		content := withJPEGSegment(encodeTestJPEG(t, newTestImage(200, 100)), exifSegment(6))
+
+		processed, err := processor.Process(content, "image/jpeg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 100, processed.Width)
+		assert.Equal(t, 200, processed.Height)
+		assert.Equal(t, "image/jpeg", processed.ThumbnailContentType)
+		assert.False(t, bytes.Contains(processed.Content, exifHeader))
+
+		config, err := jpeg.DecodeConfig(bytes.NewReader(processed.Content))
+		assert.NoError(t, err)
+		assert.Equal(t, 100, config.Width)
+		assert.Equal(t, 200, config.Height)
+	})
+
+	t.Run("rejects unsupported types", func(t *testing.T) {
+		_, err := processor.Process([]byte("%PDF-1.4"), "application/pdf")
+
+		assert.ErrorIs(t, err, ErrUnsupportedImage)
+	})
+
+	t.Run("rejects corrupt images", func(t *testing.T) {
+		content := encodeTestPNG(t, newTestImage(4, 4))
+
+		_, err := processor.Process(content[:len(content)-20], "image/png")
+
+		assert.Error(t, err)
+	})
+}
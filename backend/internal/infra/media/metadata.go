@@ -0,0 +1,228 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+)
+
+var ErrMalformedImage = errors.New("malformed image")
+
+const (
+	jpegMarkerSOI  = 0xD8
+	jpegMarkerEOI  = 0xD9
+	jpegMarkerSOS  = 0xDA
+	jpegMarkerAPP1 = 0xE1
+	jpegMarkerAPPD = 0xED
+	jpegMarkerCOM  = 0xFE
+
+	exifOrientationTag = 0x0112
+)
+
+var (
+	pngSignature        = []byte("\x89PNG\r\n\x1a\n")
+	exifHeader          = []byte("Exif\x00\x00")
+	pngMetadataChunks   = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true}
+	webpMetadataChunks  = map[string]bool{"EXIF": true, "XMP ": true}
+	webpVP8XMetadataBit = byte(0x08 | 0x04)
+)
+
+// Keeps JFIF, ICC and Adobe segments, decoders rely on them for color handling.
+func stripJPEGMetadata(content []byte) ([]byte, int, error) {
+	if len(content) < 4 || content[0] != 0xFF || content[1] != jpegMarkerSOI {
+		return nil, 0, ErrMalformedImage
+	}
+
+	orientation := 1
+	result := make([]byte, 0, len(content))
+	result = append(result, content[:2]...)
+
+	for pos := 2; pos < len(content); {
+		if content[pos] != 0xFF {
+			return nil, 0, ErrMalformedImage
+		}
+
+		for pos+1 < len(content) && content[pos+1] == 0xFF {
+			pos++
+		}
+		if pos+1 >= len(content) {
+			return nil, 0, ErrMalformedImage
+		}
+
+		marker := content[pos+1]
+		if marker == jpegMarkerEOI {
+			return append(result, content[pos:]...), orientation, nil
+		}
+
+		if pos+4 > len(content) {
+			return nil, 0, ErrMalformedImage
+		}
+		end := pos + 2 + int(binary.BigEndian.Uint16(content[pos+2:pos+4]))
+		if end > len(content) || end < pos+4 {
+			return nil, 0, ErrMalformedImage
+		}
+
+		if marker == jpegMarkerSOS {
+			return append(result, content[pos:]...), orientation, nil
+		}
+
+		switch marker {
+		case jpegMarkerAPP1:
+			if payload := content[pos+4 : end]; bytes.HasPrefix(payload, exifHeader) {
+				orientation = exifOrientation(payload[len(exifHeader):])
+			}
+		case jpegMarkerAPPD, jpegMarkerCOM:
+		default:
+			result = append(result, content[pos:end]...)
+		}
+
+		pos = end
+	}
+
+	return nil, 0, ErrMalformedImage
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[offset : offset+2]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+
+		if order.Uint16(tiff[entry:entry+2]) == exifOrientationTag {
+			orientation := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+
+	return 1
+}
+
+func stripPNGMetadata(content []byte) ([]byte, error) {
+	if !bytes.HasPrefix(content, pngSignature) {
+		return nil, ErrMalformedImage
+	}
+
+	result := make([]byte, 0, len(content))
+	result = append(result, pngSignature...)
+
+	for pos := len(pngSignature); pos < len(content); {
+		if pos+8 > len(content) {
+			return nil, ErrMalformedImage
+		}
+
+		length := int(binary.BigEndian.Uint32(content[pos : pos+4]))
+		end := pos + 12 + length
+		if length < 0 || end > len(content) || end < pos {
+			return nil, ErrMalformedImage
+		}
+
+		if !pngMetadataChunks[string(content[pos+4:pos+8])] {
+			result = append(result, content[pos:end]...)
+		}
+
+		pos = end
+	}
+
+	return result, nil
+}
+
+func stripWebPMetadata(content []byte) ([]byte, error) {
+	if len(content) < 12 || string(content[:4]) != "RIFF" || string(content[8:12]) != "WEBP" {
+		return nil, ErrMalformedImage
+	}
+
+	result := make([]byte, 12, len(content))
+	copy(result, content[:12])
+
+	for pos := 12; pos < len(content); {
+		if pos+8 > len(content) {
+			return nil, ErrMalformedImage
+		}
+
+		size := int(binary.LittleEndian.Uint32(content[pos+4 : pos+8]))
+		end := pos + 8 + size + size%2
+		if size < 0 || end > len(content) || end < pos {
+			return nil, ErrMalformedImage
+		}
+
+		fourCC := string(content[pos : pos+4])
+		if !webpMetadataChunks[fourCC] {
+			start := len(result)
+			result = append(result, content[pos:end]...)
+			if fourCC == "VP8X" && size > 0 {
+				result[start+8] &^= webpVP8XMetadataBit
+			}
+		}
+
+		pos = end
+	}
+
+	binary.LittleEndian.PutUint32(result[4:8], uint32(len(result)-8))
+
+	return result, nil
+}
+
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	outWidth, outHeight := width, height
+	if orientation >= 5 {
+		outWidth, outHeight = height, width
+	}
+
+	result := image.NewNRGBA(image.Rect(0, 0, outWidth, outHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = width-1-x, y
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dx, dy = x, height-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = height-1-y, x
+			case 7:
+				dx, dy = height-1-y, width-1-x
+			case 8:
+				dx, dy = y, width-1-x
+			}
+			result.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/infra/postgres/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type attachmentRepository struct {
+	*repository
+}
+
+func NewAttachmentRepository(pool *pgxpool.Pool) *attachmentRepository {
+	return &attachmentRepository{
+		repository: newRepository(pool, db.New(pool)),
+	}
+}
+
+func (r *attachmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Attachment, error) {
+	attachment, err := r.queries.GetAttachmentByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrorAttachmentNotFound
+		}
+		return nil, fmt.Errorf("get attachment error: %w", err)
+	}
+
+	return toAttachmentDomain(attachment), nil
+}
+
+func (r *attachmentRepository) UpdateProcessing(ctx context.Context, attachment *domain.Attachment) error {
+	if err := r.queries.UpdateAttachmentProcessing(ctx, toAttachmentProcessingParams(attachment)); err != nil {
+		return fmt.Errorf("update attachment processing error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *attachmentRepository) GetProcessingIDs(ctx context.Context, createdBefore time.Time, limit int) ([]uuid.UUID, error) {
+	rows, err := r.queries.GetProcessingAttachmentIDs(ctx, db.GetProcessingAttachmentIDsParams{
+		CreatedAt: pgtype.Timestamptz{Time: createdBefore, Valid: true},
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get processing attachments error: %w", err)
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, id := range rows {
+		ids[i] = pgtypeToUUID(id)
+	}
+
+	return ids, nil
+}
+
+func toAttachmentProcessingParams(attachment *domain.Attachment) db.UpdateAttachmentProcessingParams {
+	params := db.UpdateAttachmentProcessingParams{
+		ID:         uuidToPgtype(attachment.ID),
+		Status:     attachment.Status.String(),
+		Size:       attachment.Size,
+		Width:      intToPgtype(attachment.Width),
+		Height:     intToPgtype(attachment.Height),
+		Blurhash:   stringToPgtype(attachment.Blurhash),
+		StorageKey: attachment.StorageKey,
+	}
+
+	if attachment.Thumbnail != nil {
+		params.ThumbnailKey = stringToPgtype(attachment.Thumbnail.StorageKey)
+		params.ThumbnailContentType = stringToPgtype(attachment.Thumbnail.ContentType)
+		params.ThumbnailWidth = intToPgtype(attachment.Thumbnail.Width)
+		params.ThumbnailHeight = intToPgtype(attachment.Thumbnail.Height)
+	}
+
+	return params
+}
+
+func toAttachmentDomain(attachment db.Attachment) *domain.Attachment {
+	status, err := domain.NewAttachmentStatus(attachment.Status)
+	if err != nil {
+		status = domain.AttachmentStatusFailed
+	}
+
+	result := &domain.Attachment{
+		ID:             pgtypeToUUID(attachment.ID),
+		MessageID:      pgtypeToUUID(attachment.MessageID),
+		ConversationID: pgtypeToUUID(attachment.ConversationID),
+		UserID:         pgtypeToUUID(attachment.UserID),
+		FileName:       attachment.FileName,
+		ContentType:    attachment.ContentType,
+		Size:           attachment.Size,
+		StorageKey:     attachment.StorageKey,
+		Status:         status,
+		Width:          int(attachment.Width.Int32),
+		Height:         int(attachment.Height.Int32),
+		Blurhash:       attachment.Blurhash.String,
+	}
+
+	if attachment.ThumbnailKey.Valid {
+		result.Thumbnail = &domain.Thumbnail{
+			StorageKey:  attachment.ThumbnailKey.String,
+			ContentType: attachment.ThumbnailContentType.String,
+			Width:       int(attachment.ThumbnailWidth.Int32),
+			Height:      int(attachment.ThumbnailHeight.Int32),
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/infra/postgres/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type blockRepository struct {
+	*repository
+}
+
+func NewBlockRepository(pool *pgxpool.Pool) *blockRepository {
+	return &blockRepository{
+		repository: newRepository(pool, db.New(pool)),
+	}
+}
+
+func (r *blockRepository) Store(ctx context.Context, block *domain.Block) error {
+	if err := r.queries.BlockUser(ctx, db.BlockUserParams{
+		BlockerID: uuidToPgtype(block.BlockerID),
+		BlockedID: uuidToPgtype(block.BlockedID),
+	}); err != nil {
+		return fmt.Errorf("store block error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *blockRepository) Delete(ctx context.Context, blockerID uuid.UUID, blockedID uuid.UUID) error {
+	rowsAffected, err := r.queries.UnblockUser(ctx, db.UnblockUserParams{
+		BlockerID: uuidToPgtype(blockerID),
+		BlockedID: uuidToPgtype(blockedID),
+	})
+	if err != nil {
+		return fmt.Errorf("delete block error: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrorBlockNotFound
+	}
+
+	return nil
+}
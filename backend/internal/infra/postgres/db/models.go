@@ -8,12 +8,33 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Attachment struct {
+	ID                   pgtype.UUID        `json:"id"`
+	MessageID            pgtype.UUID        `json:"message_id"`
+	ConversationID       pgtype.UUID        `json:"conversation_id"`
+	UserID               pgtype.UUID        `json:"user_id"`
+	FileName             string             `json:"file_name"`
+	ContentType          string             `json:"content_type"`
+	Size                 int64              `json:"size"`
+	StorageKey           string             `json:"storage_key"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	Status               string             `json:"status"`
+	Width                pgtype.Int4        `json:"width"`
+	Height               pgtype.Int4        `json:"height"`
+	Blurhash             pgtype.Text        `json:"blurhash"`
+	ThumbnailKey         pgtype.Text        `json:"thumbnail_key"`
+	ThumbnailContentType pgtype.Text        `json:"thumbnail_content_type"`
+	ThumbnailWidth       pgtype.Int4        `json:"thumbnail_width"`
+	ThumbnailHeight      pgtype.Int4        `json:"thumbnail_height"`
+}
+
 type Conversation struct {
-	ID        pgtype.UUID        `json:"id"`
-	Type      int32              `json:"type"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+	ID                pgtype.UUID        `json:"id"`
+	Type              int32              `json:"type"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt         pgtype.Timestamptz `json:"deleted_at"`
+	MessageTtlSeconds pgtype.Int4        `json:"message_ttl_seconds"`
 }
 
 type GroupConversation struct {
@@ -25,6 +46,36 @@ type GroupConversation struct {
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
+	Visibility     string             `json:"visibility"`
+}
+
+type HiddenMessage struct {
+	MessageID pgtype.UUID        `json:"message_id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type InviteLink struct {
+	ID             pgtype.UUID        `json:"id"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	CreatorID      pgtype.UUID        `json:"creator_id"`
+	MaxUses        pgtype.Int4        `json:"max_uses"`
+	UseCount       int32              `json:"use_count"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt      pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type JoinRequest struct {
+	ID             pgtype.UUID        `json:"id"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	Status         string             `json:"status"`
+	DecidedBy      pgtype.UUID        `json:"decided_by"`
+	DecidedAt      pgtype.Timestamptz `json:"decided_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
 }
 
 type Message struct {
@@ -36,15 +87,98 @@ type Message struct {
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
+	EditedAt       pgtype.Timestamptz `json:"edited_at"`
+	ParentID       pgtype.UUID        `json:"parent_id"`
+	SearchVector   interface{}        `json:"search_vector"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+}
+
+type MessageForward struct {
+	MessageID              pgtype.UUID `json:"message_id"`
+	OriginalMessageID      pgtype.UUID `json:"original_message_id"`
+	OriginalUserID         pgtype.UUID `json:"original_user_id"`
+	OriginalConversationID pgtype.UUID `json:"original_conversation_id"`
+}
+
+type MessageMention struct {
+	MessageID pgtype.UUID        `json:"message_id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type MessageReaction struct {
+	MessageID pgtype.UUID        `json:"message_id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Emoji     string             `json:"emoji"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type MessageRevision struct {
+	ID        pgtype.UUID        `json:"id"`
+	MessageID pgtype.UUID        `json:"message_id"`
+	Content   string             `json:"content"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
 type Participant struct {
+	ID                pgtype.UUID        `json:"id"`
+	ConversationID    pgtype.UUID        `json:"conversation_id"`
+	UserID            pgtype.UUID        `json:"user_id"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt         pgtype.Timestamptz `json:"deleted_at"`
+	LastReadMessageID pgtype.UUID        `json:"last_read_message_id"`
+	LastReadAt        pgtype.Timestamptz `json:"last_read_at"`
+	Role              string             `json:"role"`
+	NotificationLevel string             `json:"notification_level"`
+	MutedUntil        pgtype.Timestamptz `json:"muted_until"`
+}
+
+type PinnedMessage struct {
+	MessageID      pgtype.UUID        `json:"message_id"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	PinnedBy       pgtype.UUID        `json:"pinned_by"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type Poll struct {
 	ID             pgtype.UUID        `json:"id"`
+	MessageID      pgtype.UUID        `json:"message_id"`
 	ConversationID pgtype.UUID        `json:"conversation_id"`
 	UserID         pgtype.UUID        `json:"user_id"`
+	Question       string             `json:"question"`
+	MultipleChoice bool               `json:"multiple_choice"`
+	Anonymous      bool               `json:"anonymous"`
+	ClosedAt       pgtype.Timestamptz `json:"closed_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type PollOption struct {
+	ID       pgtype.UUID `json:"id"`
+	PollID   pgtype.UUID `json:"poll_id"`
+	Position int32       `json:"position"`
+	Text     string      `json:"text"`
+}
+
+type PollVote struct {
+	PollID    pgtype.UUID        `json:"poll_id"`
+	OptionID  pgtype.UUID        `json:"option_id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type ScheduledMessage struct {
+	ID             pgtype.UUID        `json:"id"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	Content        string             `json:"content"`
+	ScheduledAt    pgtype.Timestamptz `json:"scheduled_at"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
+	Attempts       int32              `json:"attempts"`
+	NextAttemptAt  pgtype.Timestamptz `json:"next_attempt_at"`
+	FailedAt       pgtype.Timestamptz `json:"failed_at"`
+	LastError      pgtype.Text        `json:"last_error"`
 }
 
 type User struct {
@@ -56,4 +190,11 @@ type User struct {
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
+	LastSeenAt   pgtype.Timestamptz `json:"last_seen_at"`
+}
+
+type UserBlock struct {
+	BlockerID pgtype.UUID        `json:"blocker_id"`
+	BlockedID pgtype.UUID        `json:"blocked_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
@@ -11,10 +11,28 @@ import (
 )
 
 type Querier interface {
+	AddMessageReaction(ctx context.Context, arg AddMessageReactionParams) error
+	BlockUser(ctx context.Context, arg BlockUserParams) error
+	ClaimDueScheduledMessages(ctx context.Context, arg ClaimDueScheduledMessagesParams) ([]ScheduledMessage, error)
+	ClosePoll(ctx context.Context, arg ClosePollParams) (int64, error)
+	CountPinnedMessages(ctx context.Context, conversationID pgtype.UUID) (int64, error)
+	DecideJoinRequest(ctx context.Context, arg DecideJoinRequestParams) (int64, error)
 	DeleteConversation(ctx context.Context, id pgtype.UUID) error
+	DeleteExpiredMessages(ctx context.Context, limit int32) ([]DeleteExpiredMessagesRow, error)
+	DeleteMessage(ctx context.Context, id pgtype.UUID) (int64, error)
+	DeleteMessageMentions(ctx context.Context, messageID pgtype.UUID) error
 	DeleteParticipant(ctx context.Context, id pgtype.UUID) error
+	DeletePinnedMessage(ctx context.Context, arg DeletePinnedMessageParams) (int64, error)
+	DeletePollVotes(ctx context.Context, arg DeletePollVotesParams) error
+	DeleteScheduledMessage(ctx context.Context, id pgtype.UUID) (int64, error)
+	EditMessageAndReturn(ctx context.Context, arg EditMessageAndReturnParams) (EditMessageAndReturnRow, error)
 	FindParticipantByConversationAndUser(ctx context.Context, arg FindParticipantByConversationAndUserParams) (Participant, error)
 	FindUserByUsername(ctx context.Context, name string) (User, error)
+	GetActiveInviteLinks(ctx context.Context, conversationID pgtype.UUID) ([]GetActiveInviteLinksRow, error)
+	GetAttachmentByID(ctx context.Context, id pgtype.UUID) (Attachment, error)
+	GetAttachmentByMessageID(ctx context.Context, messageID pgtype.UUID) (Attachment, error)
+	GetAttachmentsByMessageIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]Attachment, error)
+	GetBlockedUsers(ctx context.Context, arg GetBlockedUsersParams) ([]GetBlockedUsersRow, error)
 	// Complex queries for read model
 	GetContacts(ctx context.Context, arg GetContactsParams) ([]GetContactsRow, error)
 	GetConversationFull(ctx context.Context, arg GetConversationFullParams) (GetConversationFullRow, error)
@@ -22,37 +40,95 @@ type Querier interface {
 	GetConversationMessagesRaw(ctx context.Context, arg GetConversationMessagesRawParams) ([]GetConversationMessagesRawRow, error)
 	GetDirectConversationBetweenUsers(ctx context.Context, arg GetDirectConversationBetweenUsersParams) (Conversation, error)
 	GetDirectConversationWithParticipants(ctx context.Context, id pgtype.UUID) (GetDirectConversationWithParticipantsRow, error)
+	GetForwardsByMessageIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]GetForwardsByMessageIDsRow, error)
 	GetGroupConversationWithOwner(ctx context.Context, conversationID pgtype.UUID) (GetGroupConversationWithOwnerRow, error)
+	GetGroupVisibility(ctx context.Context, conversationID pgtype.UUID) (string, error)
+	GetInviteLinkByID(ctx context.Context, id pgtype.UUID) (InviteLink, error)
+	GetJoinRequestByID(ctx context.Context, id pgtype.UUID) (GetJoinRequestByIDRow, error)
+	GetLongestStandingMember(ctx context.Context, arg GetLongestStandingMemberParams) (pgtype.UUID, error)
+	GetMemberIDsByRoles(ctx context.Context, arg GetMemberIDsByRolesParams) ([]pgtype.UUID, error)
+	GetMemberRole(ctx context.Context, arg GetMemberRoleParams) (string, error)
+	GetMentionsByMessageIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]GetMentionsByMessageIDsRow, error)
+	GetMentionsRaw(ctx context.Context, arg GetMentionsRawParams) ([]GetMentionsRawRow, error)
+	GetMessageByID(ctx context.Context, id pgtype.UUID) (GetMessageByIDRow, error)
+	GetMessageForward(ctx context.Context, messageID pgtype.UUID) (GetMessageForwardRow, error)
+	GetMessageRevisions(ctx context.Context, arg GetMessageRevisionsParams) ([]MessageRevision, error)
 	GetMessageWithUser(ctx context.Context, id pgtype.UUID) (GetMessageWithUserRow, error)
 	GetNotificationMessageRaw(ctx context.Context, id pgtype.UUID) (GetNotificationMessageRawRow, error)
+	GetNotificationPreferencesByUserID(ctx context.Context, userID pgtype.UUID) ([]GetNotificationPreferencesByUserIDRow, error)
+	GetParticipantIDsByNames(ctx context.Context, arg GetParticipantIDsByNamesParams) ([]pgtype.UUID, error)
 	GetParticipantsByConversationID(ctx context.Context, arg GetParticipantsByConversationIDParams) ([]GetParticipantsByConversationIDRow, error)
 	GetParticipantsIDsByConversationID(ctx context.Context, conversationID pgtype.UUID) ([]pgtype.UUID, error)
+	GetPendingJoinRequests(ctx context.Context, conversationID pgtype.UUID) ([]GetPendingJoinRequestsRow, error)
+	GetPinnedMessagesRaw(ctx context.Context, arg GetPinnedMessagesRawParams) ([]GetPinnedMessagesRawRow, error)
+	GetPollByMessageID(ctx context.Context, messageID pgtype.UUID) (Poll, error)
+	GetPollOptions(ctx context.Context, pollID pgtype.UUID) ([]PollOption, error)
+	GetPollTalliesByPollIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]GetPollTalliesByPollIDsRow, error)
+	GetPollsByMessageIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]GetPollsByMessageIDsRow, error)
 	GetPotentialInvitees(ctx context.Context, arg GetPotentialInviteesParams) ([]GetPotentialInviteesRow, error)
+	GetProcessingAttachmentIDs(ctx context.Context, arg GetProcessingAttachmentIDsParams) ([]pgtype.UUID, error)
+	GetPublicGroups(ctx context.Context, arg GetPublicGroupsParams) ([]GetPublicGroupsRow, error)
+	GetReactionsByMessageIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]GetReactionsByMessageIDsRow, error)
+	GetScheduledMessageByID(ctx context.Context, id pgtype.UUID) (ScheduledMessage, error)
+	GetScheduledMessages(ctx context.Context, arg GetScheduledMessagesParams) ([]ScheduledMessage, error)
+	GetThreadMessagesRaw(ctx context.Context, arg GetThreadMessagesRawParams) ([]GetThreadMessagesRawRow, error)
 	GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 	GetUserConversations(ctx context.Context, arg GetUserConversationsParams) ([]GetUserConversationsRow, error)
 	GetUsersByIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]GetUsersByIDsRow, error)
+	HasBlocked(ctx context.Context, arg HasBlockedParams) (bool, error)
+	HideMessageForUser(ctx context.Context, arg HideMessageForUserParams) error
 	InviteToConversationAtomic(ctx context.Context, arg InviteToConversationAtomicParams) (pgtype.UUID, error)
+	IsBlockedInDirectConversation(ctx context.Context, arg IsBlockedInDirectConversationParams) (bool, error)
 	IsMember(ctx context.Context, arg IsMemberParams) (bool, error)
 	IsMemberOwner(ctx context.Context, arg IsMemberOwnerParams) (bool, error)
+	IsMessageHidden(ctx context.Context, arg IsMessageHiddenParams) (bool, error)
 	LeaveConversationAtomic(ctx context.Context, arg LeaveConversationAtomicParams) (int64, error)
+	LockConversation(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error)
+	LockOpenPoll(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error)
+	MarkParticipantRead(ctx context.Context, arg MarkParticipantReadParams) (int64, error)
+	RecordScheduledMessageFailure(ctx context.Context, arg RecordScheduledMessageFailureParams) error
+	RemoveMessageReaction(ctx context.Context, arg RemoveMessageReactionParams) error
 	RenameConversationAndReturn(ctx context.Context, arg RenameConversationAndReturnParams) (int64, error)
 	RenameGroupConversation(ctx context.Context, arg RenameGroupConversationParams) error
+	RevokeInviteLink(ctx context.Context, arg RevokeInviteLinkParams) (int64, error)
+	SearchMessagesRaw(ctx context.Context, arg SearchMessagesRawParams) ([]SearchMessagesRawRow, error)
+	StoreAttachment(ctx context.Context, arg StoreAttachmentParams) error
 	// Conversation queries
 	StoreConversation(ctx context.Context, arg StoreConversationParams) error
 	// GroupConversation queries
 	StoreGroupConversation(ctx context.Context, arg StoreGroupConversationParams) error
+	StoreInviteLink(ctx context.Context, arg StoreInviteLinkParams) error
+	StoreJoinRequest(ctx context.Context, arg StoreJoinRequestParams) (int64, error)
 	// Message queries
 	StoreMessage(ctx context.Context, arg StoreMessageParams) error
 	StoreMessageAndReturn(ctx context.Context, arg StoreMessageAndReturnParams) (StoreMessageAndReturnRow, error)
+	StoreMessageForward(ctx context.Context, arg StoreMessageForwardParams) error
+	StoreMessageMentions(ctx context.Context, arg StoreMessageMentionsParams) error
+	StoreMessageRevision(ctx context.Context, arg StoreMessageRevisionParams) error
 	// Participant queries
 	StoreParticipant(ctx context.Context, arg StoreParticipantParams) error
 	StoreParticipantsBatch(ctx context.Context, arg StoreParticipantsBatchParams) error
+	StorePinnedMessage(ctx context.Context, arg StorePinnedMessageParams) (int64, error)
+	StorePoll(ctx context.Context, arg StorePollParams) error
+	StorePollOption(ctx context.Context, arg StorePollOptionParams) error
+	StorePollVote(ctx context.Context, arg StorePollVoteParams) error
+	StoreScheduledMessage(ctx context.Context, arg StoreScheduledMessageParams) error
 	// User queries
 	StoreUser(ctx context.Context, arg StoreUserParams) error
+	UnblockUser(ctx context.Context, arg UnblockUserParams) (int64, error)
+	UpdateAttachmentProcessing(ctx context.Context, arg UpdateAttachmentProcessingParams) error
 	UpdateConversation(ctx context.Context, arg UpdateConversationParams) error
+	UpdateConversationMessageTTL(ctx context.Context, arg UpdateConversationMessageTTLParams) (int64, error)
 	UpdateGroupConversation(ctx context.Context, arg UpdateGroupConversationParams) error
+	UpdateGroupConversationOwner(ctx context.Context, arg UpdateGroupConversationOwnerParams) (int64, error)
+	UpdateGroupConversationVisibility(ctx context.Context, arg UpdateGroupConversationVisibilityParams) (int64, error)
+	UpdateParticipantNotificationPreferences(ctx context.Context, arg UpdateParticipantNotificationPreferencesParams) (int64, error)
+	UpdateParticipantRole(ctx context.Context, arg UpdateParticipantRoleParams) (int64, error)
+	UpdateScheduledMessage(ctx context.Context, arg UpdateScheduledMessageParams) (int64, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) error
+	UpdateUserLastSeen(ctx context.Context, arg UpdateUserLastSeenParams) error
 	UpdateUserRefreshToken(ctx context.Context, arg UpdateUserRefreshTokenParams) error
+	UseInviteLink(ctx context.Context, id pgtype.UUID) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
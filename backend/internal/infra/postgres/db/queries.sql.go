@@ -11,6 +11,153 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const addMessageReaction = `-- name: AddMessageReaction :exec
+INSERT INTO message_reactions (message_id, user_id, emoji, created_at)
+VALUES ($1, $2, $3, NOW())
+ON CONFLICT DO NOTHING
+`
+
+type AddMessageReactionParams struct {
+	MessageID pgtype.UUID `json:"message_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+	Emoji     string      `json:"emoji"`
+}
+
+func (q *Queries) AddMessageReaction(ctx context.Context, arg AddMessageReactionParams) error {
+	_, err := q.db.Exec(ctx, addMessageReaction, arg.MessageID, arg.UserID, arg.Emoji)
+	return err
+}
+
+const blockUser = `-- name: BlockUser :exec
+INSERT INTO user_blocks (blocker_id, blocked_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type BlockUserParams struct {
+	BlockerID pgtype.UUID `json:"blocker_id"`
+	BlockedID pgtype.UUID `json:"blocked_id"`
+}
+
+func (q *Queries) BlockUser(ctx context.Context, arg BlockUserParams) error {
+	_, err := q.db.Exec(ctx, blockUser, arg.BlockerID, arg.BlockedID)
+	return err
+}
+
+const claimDueScheduledMessages = `-- name: ClaimDueScheduledMessages :many
+UPDATE scheduled_messages
+SET attempts = attempts + 1,
+    next_attempt_at = $1::timestamptz + make_interval(secs => LEAST($2::float8 * power(2, attempts), $3::float8))
+WHERE id IN (
+    SELECT id FROM scheduled_messages
+    WHERE next_attempt_at <= $1::timestamptz
+      AND failed_at IS NULL
+    ORDER BY next_attempt_at ASC
+    LIMIT $4
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, conversation_id, user_id, content, scheduled_at, created_at, updated_at, attempts, next_attempt_at, failed_at, last_error
+`
+
+type ClaimDueScheduledMessagesParams struct {
+	Now              pgtype.Timestamptz `json:"now"`
+	RetryBaseSeconds float64            `json:"retry_base_seconds"`
+	RetryMaxSeconds  float64            `json:"retry_max_seconds"`
+	ClaimLimit       int32              `json:"claim_limit"`
+}
+
+func (q *Queries) ClaimDueScheduledMessages(ctx context.Context, arg ClaimDueScheduledMessagesParams) ([]ScheduledMessage, error) {
+	rows, err := q.db.Query(ctx, claimDueScheduledMessages,
+		arg.Now,
+		arg.RetryBaseSeconds,
+		arg.RetryMaxSeconds,
+		arg.ClaimLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ScheduledMessage
+	for rows.Next() {
+		var i ScheduledMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ConversationID,
+			&i.UserID,
+			&i.Content,
+			&i.ScheduledAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.FailedAt,
+			&i.LastError,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const closePoll = `-- name: ClosePoll :execrows
+UPDATE polls
+SET closed_at = $2
+WHERE id = $1 AND closed_at IS NULL
+`
+
+type ClosePollParams struct {
+	ID       pgtype.UUID        `json:"id"`
+	ClosedAt pgtype.Timestamptz `json:"closed_at"`
+}
+
+func (q *Queries) ClosePoll(ctx context.Context, arg ClosePollParams) (int64, error) {
+	result, err := q.db.Exec(ctx, closePoll, arg.ID, arg.ClosedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const countPinnedMessages = `-- name: CountPinnedMessages :one
+SELECT COUNT(*)
+FROM pinned_messages pm
+JOIN messages m ON m.id = pm.message_id
+WHERE pm.conversation_id = $1
+  AND m.deleted_at IS NULL
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+`
+
+func (q *Queries) CountPinnedMessages(ctx context.Context, conversationID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countPinnedMessages, conversationID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const decideJoinRequest = `-- name: DecideJoinRequest :execrows
+UPDATE join_requests
+SET status = $2, decided_by = $3, decided_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'pending'
+`
+
+type DecideJoinRequestParams struct {
+	ID        pgtype.UUID `json:"id"`
+	Status    string      `json:"status"`
+	DecidedBy pgtype.UUID `json:"decided_by"`
+}
+
+func (q *Queries) DecideJoinRequest(ctx context.Context, arg DecideJoinRequestParams) (int64, error) {
+	result, err := q.db.Exec(ctx, decideJoinRequest, arg.ID, arg.Status, arg.DecidedBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteConversation = `-- name: DeleteConversation :exec
 UPDATE conversations
 SET deleted_at = NOW(), updated_at = NOW()
@@ -22,6 +169,68 @@ func (q *Queries) DeleteConversation(ctx context.Context, id pgtype.UUID) error
 	return err
 }
 
+const deleteExpiredMessages = `-- name: DeleteExpiredMessages :many
+UPDATE messages
+SET deleted_at = NOW(), updated_at = NOW()
+WHERE id IN (
+    SELECT id FROM messages
+    WHERE expires_at <= NOW() AND deleted_at IS NULL
+    ORDER BY expires_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, conversation_id
+`
+
+type DeleteExpiredMessagesRow struct {
+	ID             pgtype.UUID `json:"id"`
+	ConversationID pgtype.UUID `json:"conversation_id"`
+}
+
+func (q *Queries) DeleteExpiredMessages(ctx context.Context, limit int32) ([]DeleteExpiredMessagesRow, error) {
+	rows, err := q.db.Query(ctx, deleteExpiredMessages, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DeleteExpiredMessagesRow
+	for rows.Next() {
+		var i DeleteExpiredMessagesRow
+		if err := rows.Scan(&i.ID, &i.ConversationID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteMessage = `-- name: DeleteMessage :execrows
+UPDATE messages
+SET deleted_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) DeleteMessage(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteMessage, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteMessageMentions = `-- name: DeleteMessageMentions :exec
+DELETE FROM message_mentions
+WHERE message_id = $1
+`
+
+func (q *Queries) DeleteMessageMentions(ctx context.Context, messageID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteMessageMentions, messageID)
+	return err
+}
+
 const deleteParticipant = `-- name: DeleteParticipant :exec
 UPDATE participants
 SET deleted_at = NOW(), updated_at = NOW()
@@ -33,8 +242,111 @@ func (q *Queries) DeleteParticipant(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const deletePinnedMessage = `-- name: DeletePinnedMessage :execrows
+DELETE FROM pinned_messages
+WHERE conversation_id = $1 AND message_id = $2
+`
+
+type DeletePinnedMessageParams struct {
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	MessageID      pgtype.UUID `json:"message_id"`
+}
+
+func (q *Queries) DeletePinnedMessage(ctx context.Context, arg DeletePinnedMessageParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePinnedMessage, arg.ConversationID, arg.MessageID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deletePollVotes = `-- name: DeletePollVotes :exec
+DELETE FROM poll_votes
+WHERE poll_id = $1 AND user_id = $2
+`
+
+type DeletePollVotesParams struct {
+	PollID pgtype.UUID `json:"poll_id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) DeletePollVotes(ctx context.Context, arg DeletePollVotesParams) error {
+	_, err := q.db.Exec(ctx, deletePollVotes, arg.PollID, arg.UserID)
+	return err
+}
+
+const deleteScheduledMessage = `-- name: DeleteScheduledMessage :execrows
+DELETE FROM scheduled_messages
+WHERE id = $1
+`
+
+func (q *Queries) DeleteScheduledMessage(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteScheduledMessage, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const editMessageAndReturn = `-- name: EditMessageAndReturn :one
+WITH edited_message AS (
+    UPDATE messages
+    SET content = $2, edited_at = NOW(), updated_at = NOW()
+    WHERE id = $1 AND deleted_at IS NULL
+    RETURNING id, type, created_at, conversation_id, content, user_id, edited_at, parent_id
+)
+SELECT
+    em.id, em.type, em.created_at, em.conversation_id,
+    em.content as formatted_text,
+    em.edited_at,
+    (SELECT COUNT(*) FROM message_revisions mr WHERE mr.message_id = em.id) as revision_count,
+    u.id as user_id, u.name as user_name, u.avatar as user_avatar,
+    em.parent_id
+FROM edited_message em
+JOIN users u ON u.id = em.user_id
+WHERE u.deleted_at IS NULL
+`
+
+type EditMessageAndReturnParams struct {
+	ID      pgtype.UUID `json:"id"`
+	Content string      `json:"content"`
+}
+
+type EditMessageAndReturnRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	Type           int32              `json:"type"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	FormattedText  string             `json:"formatted_text"`
+	EditedAt       pgtype.Timestamptz `json:"edited_at"`
+	RevisionCount  int64              `json:"revision_count"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	UserName       string             `json:"user_name"`
+	UserAvatar     pgtype.Text        `json:"user_avatar"`
+	ParentID       pgtype.UUID        `json:"parent_id"`
+}
+
+func (q *Queries) EditMessageAndReturn(ctx context.Context, arg EditMessageAndReturnParams) (EditMessageAndReturnRow, error) {
+	row := q.db.QueryRow(ctx, editMessageAndReturn, arg.ID, arg.Content)
+	var i EditMessageAndReturnRow
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.CreatedAt,
+		&i.ConversationID,
+		&i.FormattedText,
+		&i.EditedAt,
+		&i.RevisionCount,
+		&i.UserID,
+		&i.UserName,
+		&i.UserAvatar,
+		&i.ParentID,
+	)
+	return i, err
+}
+
 const findParticipantByConversationAndUser = `-- name: FindParticipantByConversationAndUser :one
-SELECT id, conversation_id, user_id, created_at, updated_at, deleted_at FROM participants
+SELECT id, conversation_id, user_id, created_at, updated_at, deleted_at, last_read_message_id, last_read_at, role, notification_level, muted_until FROM participants
 WHERE conversation_id = $1 AND user_id = $2 AND deleted_at IS NULL
 LIMIT 1
 `
@@ -54,12 +366,17 @@ func (q *Queries) FindParticipantByConversationAndUser(ctx context.Context, arg
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.LastReadMessageID,
+		&i.LastReadAt,
+		&i.Role,
+		&i.NotificationLevel,
+		&i.MutedUntil,
 	)
 	return i, err
 }
 
 const findUserByUsername = `-- name: FindUserByUsername :one
-SELECT id, avatar, name, password, refresh_token, created_at, updated_at, deleted_at FROM users
+SELECT id, avatar, name, password, refresh_token, created_at, updated_at, deleted_at, last_seen_at FROM users
 WHERE name = $1 AND deleted_at IS NULL
 LIMIT 1
 `
@@ -76,41 +393,56 @@ func (q *Queries) FindUserByUsername(ctx context.Context, name string) (User, er
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.LastSeenAt,
 	)
 	return i, err
 }
 
-const getContacts = `-- name: GetContacts :many
-
-SELECT id, name, avatar
-FROM users
-WHERE deleted_at IS NULL AND id != $1
-LIMIT $2 OFFSET $3
+const getActiveInviteLinks = `-- name: GetActiveInviteLinks :many
+SELECT
+    il.id, il.conversation_id, il.max_uses, il.use_count, il.expires_at, il.created_at,
+    u.id as creator_id, u.name as creator_name, u.avatar as creator_avatar
+FROM invite_links il
+JOIN users u ON u.id = il.creator_id
+WHERE il.conversation_id = $1
+  AND il.revoked_at IS NULL
+  AND (il.expires_at IS NULL OR il.expires_at > NOW())
+  AND (il.max_uses IS NULL OR il.use_count < il.max_uses)
+ORDER BY il.created_at DESC
 `
 
-type GetContactsParams struct {
-	ID     pgtype.UUID `json:"id"`
-	Limit  int32       `json:"limit"`
-	Offset int32       `json:"offset"`
-}
-
-type GetContactsRow struct {
-	ID     pgtype.UUID `json:"id"`
-	Name   string      `json:"name"`
-	Avatar pgtype.Text `json:"avatar"`
+type GetActiveInviteLinksRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	MaxUses        pgtype.Int4        `json:"max_uses"`
+	UseCount       int32              `json:"use_count"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	CreatorID      pgtype.UUID        `json:"creator_id"`
+	CreatorName    string             `json:"creator_name"`
+	CreatorAvatar  pgtype.Text        `json:"creator_avatar"`
 }
 
-// Complex queries for read model
-func (q *Queries) GetContacts(ctx context.Context, arg GetContactsParams) ([]GetContactsRow, error) {
-	rows, err := q.db.Query(ctx, getContacts, arg.ID, arg.Limit, arg.Offset)
+func (q *Queries) GetActiveInviteLinks(ctx context.Context, conversationID pgtype.UUID) ([]GetActiveInviteLinksRow, error) {
+	rows, err := q.db.Query(ctx, getActiveInviteLinks, conversationID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetContactsRow
+	var items []GetActiveInviteLinksRow
 	for rows.Next() {
-		var i GetContactsRow
-		if err := rows.Scan(&i.ID, &i.Name, &i.Avatar); err != nil {
+		var i GetActiveInviteLinksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ConversationID,
+			&i.MaxUses,
+			&i.UseCount,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.CreatorID,
+			&i.CreatorName,
+			&i.CreatorAvatar,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -121,76 +453,307 @@ func (q *Queries) GetContacts(ctx context.Context, arg GetContactsParams) ([]Get
 	return items, nil
 }
 
-const getConversationFull = `-- name: GetConversationFull :one
-WITH participants_count AS (
-    SELECT COUNT(*) as count
-    FROM participants
-    WHERE conversation_id = $1
-      AND deleted_at IS NULL
-)
-SELECT
-    c.id as conversation_id,
-    c.created_at,
-    c.type,
-    ou.id as other_user_id,
-    ou.name as other_user_name,
-    ou.avatar as other_user_avatar,
-    gc.avatar as group_avatar,
-    gc.name as group_name,
-    gc.owner_id as group_owner_id,
-    pc.count as participants_count,
-    up.id as user_participant_id
-FROM conversations c
-LEFT JOIN participants op ON op.conversation_id = c.id
-    AND op.user_id <> $2
-LEFT JOIN users ou ON ou.id = op.user_id
-LEFT JOIN group_conversations gc ON gc.conversation_id = c.id
-LEFT JOIN participants pc_sub ON pc_sub.conversation_id = c.id AND pc_sub.user_id = $2
-LEFT JOIN participants up ON up.conversation_id = c.id AND up.user_id = $2
-CROSS JOIN participants_count pc
-WHERE c.id = $1 AND c.deleted_at IS NULL
+const getAttachmentByID = `-- name: GetAttachmentByID :one
+SELECT a.id, a.message_id, a.conversation_id, a.user_id, a.file_name, a.content_type, a.size, a.storage_key, a.created_at, a.status, a.width, a.height, a.blurhash, a.thumbnail_key, a.thumbnail_content_type, a.thumbnail_width, a.thumbnail_height
+FROM attachments a
+JOIN messages m ON m.id = a.message_id
+WHERE a.id = $1 AND m.deleted_at IS NULL
 LIMIT 1
 `
 
-type GetConversationFullParams struct {
-	ID     pgtype.UUID `json:"id"`
-	UserID pgtype.UUID `json:"user_id"`
+func (q *Queries) GetAttachmentByID(ctx context.Context, id pgtype.UUID) (Attachment, error) {
+	row := q.db.QueryRow(ctx, getAttachmentByID, id)
+	var i Attachment
+	err := row.Scan(
+		&i.ID,
+		&i.MessageID,
+		&i.ConversationID,
+		&i.UserID,
+		&i.FileName,
+		&i.ContentType,
+		&i.Size,
+		&i.StorageKey,
+		&i.CreatedAt,
+		&i.Status,
+		&i.Width,
+		&i.Height,
+		&i.Blurhash,
+		&i.ThumbnailKey,
+		&i.ThumbnailContentType,
+		&i.ThumbnailWidth,
+		&i.ThumbnailHeight,
+	)
+	return i, err
 }
 
-type GetConversationFullRow struct {
-	ConversationID    pgtype.UUID        `json:"conversation_id"`
-	CreatedAt         pgtype.Timestamptz `json:"created_at"`
-	Type              int32              `json:"type"`
-	OtherUserID       pgtype.UUID        `json:"other_user_id"`
-	OtherUserName     pgtype.Text        `json:"other_user_name"`
-	OtherUserAvatar   pgtype.Text        `json:"other_user_avatar"`
-	GroupAvatar       pgtype.Text        `json:"group_avatar"`
-	GroupName         pgtype.Text        `json:"group_name"`
-	GroupOwnerID      pgtype.UUID        `json:"group_owner_id"`
-	ParticipantsCount int64              `json:"participants_count"`
-	UserParticipantID pgtype.UUID        `json:"user_participant_id"`
-}
+const getAttachmentByMessageID = `-- name: GetAttachmentByMessageID :one
+SELECT id, message_id, conversation_id, user_id, file_name, content_type, size, storage_key, created_at, status, width, height, blurhash, thumbnail_key, thumbnail_content_type, thumbnail_width, thumbnail_height
+FROM attachments
+WHERE message_id = $1
+LIMIT 1
+`
 
-func (q *Queries) GetConversationFull(ctx context.Context, arg GetConversationFullParams) (GetConversationFullRow, error) {
-	row := q.db.QueryRow(ctx, getConversationFull, arg.ID, arg.UserID)
-	var i GetConversationFullRow
+func (q *Queries) GetAttachmentByMessageID(ctx context.Context, messageID pgtype.UUID) (Attachment, error) {
+	row := q.db.QueryRow(ctx, getAttachmentByMessageID, messageID)
+	var i Attachment
 	err := row.Scan(
+		&i.ID,
+		&i.MessageID,
 		&i.ConversationID,
+		&i.UserID,
+		&i.FileName,
+		&i.ContentType,
+		&i.Size,
+		&i.StorageKey,
 		&i.CreatedAt,
-		&i.Type,
-		&i.OtherUserID,
-		&i.OtherUserName,
-		&i.OtherUserAvatar,
-		&i.GroupAvatar,
-		&i.GroupName,
-		&i.GroupOwnerID,
-		&i.ParticipantsCount,
-		&i.UserParticipantID,
+		&i.Status,
+		&i.Width,
+		&i.Height,
+		&i.Blurhash,
+		&i.ThumbnailKey,
+		&i.ThumbnailContentType,
+		&i.ThumbnailWidth,
+		&i.ThumbnailHeight,
 	)
 	return i, err
 }
 
-const getConversationIDsByUserID = `-- name: GetConversationIDsByUserID :many
+const getAttachmentsByMessageIDs = `-- name: GetAttachmentsByMessageIDs :many
+SELECT id, message_id, conversation_id, user_id, file_name, content_type, size, storage_key, created_at, status, width, height, blurhash, thumbnail_key, thumbnail_content_type, thumbnail_width, thumbnail_height
+FROM attachments
+WHERE message_id = ANY($1::uuid[])
+`
+
+func (q *Queries) GetAttachmentsByMessageIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]Attachment, error) {
+	rows, err := q.db.Query(ctx, getAttachmentsByMessageIDs, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attachment
+	for rows.Next() {
+		var i Attachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.MessageID,
+			&i.ConversationID,
+			&i.UserID,
+			&i.FileName,
+			&i.ContentType,
+			&i.Size,
+			&i.StorageKey,
+			&i.CreatedAt,
+			&i.Status,
+			&i.Width,
+			&i.Height,
+			&i.Blurhash,
+			&i.ThumbnailKey,
+			&i.ThumbnailContentType,
+			&i.ThumbnailWidth,
+			&i.ThumbnailHeight,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBlockedUsers = `-- name: GetBlockedUsers :many
+SELECT u.id, u.name, u.avatar, u.last_seen_at
+FROM user_blocks b
+JOIN users u ON u.id = b.blocked_id
+WHERE b.blocker_id = $1
+  AND u.deleted_at IS NULL
+ORDER BY b.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type GetBlockedUsersParams struct {
+	BlockerID pgtype.UUID `json:"blocker_id"`
+	Limit     int32       `json:"limit"`
+	Offset    int32       `json:"offset"`
+}
+
+type GetBlockedUsersRow struct {
+	ID         pgtype.UUID        `json:"id"`
+	Name       string             `json:"name"`
+	Avatar     pgtype.Text        `json:"avatar"`
+	LastSeenAt pgtype.Timestamptz `json:"last_seen_at"`
+}
+
+func (q *Queries) GetBlockedUsers(ctx context.Context, arg GetBlockedUsersParams) ([]GetBlockedUsersRow, error) {
+	rows, err := q.db.Query(ctx, getBlockedUsers, arg.BlockerID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetBlockedUsersRow
+	for rows.Next() {
+		var i GetBlockedUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Avatar,
+			&i.LastSeenAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getContacts = `-- name: GetContacts :many
+
+SELECT id, name, avatar, last_seen_at
+FROM users
+WHERE deleted_at IS NULL AND id != $1
+  AND NOT EXISTS (
+    SELECT 1 FROM user_blocks b
+    WHERE (b.blocker_id = $1 AND b.blocked_id = users.id)
+       OR (b.blocker_id = users.id AND b.blocked_id = $1)
+  )
+LIMIT $2 OFFSET $3
+`
+
+type GetContactsParams struct {
+	ID     pgtype.UUID `json:"id"`
+	Limit  int32       `json:"limit"`
+	Offset int32       `json:"offset"`
+}
+
+type GetContactsRow struct {
+	ID         pgtype.UUID        `json:"id"`
+	Name       string             `json:"name"`
+	Avatar     pgtype.Text        `json:"avatar"`
+	LastSeenAt pgtype.Timestamptz `json:"last_seen_at"`
+}
+
+// Complex queries for read model
+func (q *Queries) GetContacts(ctx context.Context, arg GetContactsParams) ([]GetContactsRow, error) {
+	rows, err := q.db.Query(ctx, getContacts, arg.ID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetContactsRow
+	for rows.Next() {
+		var i GetContactsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Avatar,
+			&i.LastSeenAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getConversationFull = `-- name: GetConversationFull :one
+WITH participants_count AS (
+    SELECT COUNT(*) as count
+    FROM participants
+    WHERE conversation_id = $1
+      AND deleted_at IS NULL
+)
+SELECT
+    c.id as conversation_id,
+    c.created_at,
+    c.type,
+    ou.id as other_user_id,
+    ou.name as other_user_name,
+    ou.avatar as other_user_avatar,
+    gc.avatar as group_avatar,
+    gc.name as group_name,
+    gc.owner_id as group_owner_id,
+    gc.visibility as group_visibility,
+    pc.count as participants_count,
+    up.id as user_participant_id,
+    up.role as user_role,
+    up.notification_level as user_notification_level,
+    up.muted_until as user_muted_until,
+    (
+        SELECT COUNT(*) FROM pinned_messages pm
+        JOIN messages pmm ON pmm.id = pm.message_id
+        WHERE pm.conversation_id = c.id AND pmm.deleted_at IS NULL AND (pmm.expires_at IS NULL OR pmm.expires_at > NOW())
+    ) as pinned_count,
+    c.message_ttl_seconds
+FROM conversations c
+LEFT JOIN participants op ON op.conversation_id = c.id
+    AND op.user_id <> $2
+LEFT JOIN users ou ON ou.id = op.user_id
+LEFT JOIN group_conversations gc ON gc.conversation_id = c.id
+LEFT JOIN participants pc_sub ON pc_sub.conversation_id = c.id AND pc_sub.user_id = $2
+LEFT JOIN participants up ON up.conversation_id = c.id AND up.user_id = $2
+CROSS JOIN participants_count pc
+WHERE c.id = $1 AND c.deleted_at IS NULL
+LIMIT 1
+`
+
+type GetConversationFullParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type GetConversationFullRow struct {
+	ConversationID        pgtype.UUID        `json:"conversation_id"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	Type                  int32              `json:"type"`
+	OtherUserID           pgtype.UUID        `json:"other_user_id"`
+	OtherUserName         pgtype.Text        `json:"other_user_name"`
+	OtherUserAvatar       pgtype.Text        `json:"other_user_avatar"`
+	GroupAvatar           pgtype.Text        `json:"group_avatar"`
+	GroupName             pgtype.Text        `json:"group_name"`
+	GroupOwnerID          pgtype.UUID        `json:"group_owner_id"`
+	GroupVisibility       pgtype.Text        `json:"group_visibility"`
+	ParticipantsCount     int64              `json:"participants_count"`
+	UserParticipantID     pgtype.UUID        `json:"user_participant_id"`
+	UserRole              pgtype.Text        `json:"user_role"`
+	UserNotificationLevel pgtype.Text        `json:"user_notification_level"`
+	UserMutedUntil        pgtype.Timestamptz `json:"user_muted_until"`
+	PinnedCount           int64              `json:"pinned_count"`
+	MessageTtlSeconds     pgtype.Int4        `json:"message_ttl_seconds"`
+}
+
+func (q *Queries) GetConversationFull(ctx context.Context, arg GetConversationFullParams) (GetConversationFullRow, error) {
+	row := q.db.QueryRow(ctx, getConversationFull, arg.ID, arg.UserID)
+	var i GetConversationFullRow
+	err := row.Scan(
+		&i.ConversationID,
+		&i.CreatedAt,
+		&i.Type,
+		&i.OtherUserID,
+		&i.OtherUserName,
+		&i.OtherUserAvatar,
+		&i.GroupAvatar,
+		&i.GroupName,
+		&i.GroupOwnerID,
+		&i.GroupVisibility,
+		&i.ParticipantsCount,
+		&i.UserParticipantID,
+		&i.UserRole,
+		&i.UserNotificationLevel,
+		&i.UserMutedUntil,
+		&i.PinnedCount,
+		&i.MessageTtlSeconds,
+	)
+	return i, err
+}
+
+const getConversationIDsByUserID = `-- name: GetConversationIDsByUserID :many
 SELECT conversation_id
 FROM participants
 WHERE user_id = $1 AND deleted_at IS NULL
@@ -222,25 +785,36 @@ SELECT
     m.type,
     m.created_at,
     m.conversation_id,
-    m.content,
-    m.user_id
+    CASE WHEN m.deleted_at IS NULL THEN m.content ELSE '' END as content,
+    m.user_id,
+    m.edited_at,
+    (SELECT COUNT(*) FROM message_revisions mr WHERE mr.message_id = m.id) as revision_count,
+    m.deleted_at,
+    (SELECT COUNT(*) FROM messages r WHERE r.parent_id = m.id AND r.deleted_at IS NULL AND (r.expires_at IS NULL OR r.expires_at > NOW())) as reply_count,
+    (SELECT MAX(r.created_at) FROM messages r WHERE r.parent_id = m.id AND r.deleted_at IS NULL AND (r.expires_at IS NULL OR r.expires_at > NOW()))::timestamptz as last_reply_at
 FROM messages m
 WHERE m.conversation_id = $1
-  AND m.deleted_at IS NULL
+  AND m.parent_id IS NULL
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+  AND NOT EXISTS (
+    SELECT 1 FROM hidden_messages hm
+    WHERE hm.message_id = m.id AND hm.user_id = $2
+  )
   AND (
-    $2::timestamptz IS NULL
-    OR m.created_at < $2
+    $3::timestamptz IS NULL
+    OR m.created_at < $3
     OR (
-      m.created_at = $2
-      AND m.id < $3
+      m.created_at = $3
+      AND m.id < $4
     )
   )
 ORDER BY m.created_at DESC, m.id DESC
-LIMIT $4
+LIMIT $5
 `
 
 type GetConversationMessagesRawParams struct {
 	ConversationID  pgtype.UUID        `json:"conversation_id"`
+	UserID          pgtype.UUID        `json:"user_id"`
 	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
 	CursorID        pgtype.UUID        `json:"cursor_id"`
 	PageLimit       int32              `json:"page_limit"`
@@ -253,11 +827,17 @@ type GetConversationMessagesRawRow struct {
 	ConversationID pgtype.UUID        `json:"conversation_id"`
 	Content        string             `json:"content"`
 	UserID         pgtype.UUID        `json:"user_id"`
+	EditedAt       pgtype.Timestamptz `json:"edited_at"`
+	RevisionCount  int64              `json:"revision_count"`
+	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
+	ReplyCount     int64              `json:"reply_count"`
+	LastReplyAt    pgtype.Timestamptz `json:"last_reply_at"`
 }
 
 func (q *Queries) GetConversationMessagesRaw(ctx context.Context, arg GetConversationMessagesRawParams) ([]GetConversationMessagesRawRow, error) {
 	rows, err := q.db.Query(ctx, getConversationMessagesRaw,
 		arg.ConversationID,
+		arg.UserID,
 		arg.CursorCreatedAt,
 		arg.CursorID,
 		arg.PageLimit,
@@ -276,6 +856,11 @@ func (q *Queries) GetConversationMessagesRaw(ctx context.Context, arg GetConvers
 			&i.ConversationID,
 			&i.Content,
 			&i.UserID,
+			&i.EditedAt,
+			&i.RevisionCount,
+			&i.DeletedAt,
+			&i.ReplyCount,
+			&i.LastReplyAt,
 		); err != nil {
 			return nil, err
 		}
@@ -288,7 +873,7 @@ func (q *Queries) GetConversationMessagesRaw(ctx context.Context, arg GetConvers
 }
 
 const getDirectConversationBetweenUsers = `-- name: GetDirectConversationBetweenUsers :one
-SELECT c.id, c.type, c.created_at, c.updated_at, c.deleted_at
+SELECT c.id, c.type, c.created_at, c.updated_at, c.deleted_at, c.message_ttl_seconds
 FROM conversations c
 JOIN participants p1 ON p1.conversation_id = c.id AND p1.user_id = $1
 JOIN participants p2 ON p2.conversation_id = c.id AND p2.user_id = $2
@@ -313,6 +898,7 @@ func (q *Queries) GetDirectConversationBetweenUsers(ctx context.Context, arg Get
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.MessageTtlSeconds,
 	)
 	return i, err
 }
@@ -347,6 +933,55 @@ func (q *Queries) GetDirectConversationWithParticipants(ctx context.Context, id
 	return i, err
 }
 
+const getForwardsByMessageIDs = `-- name: GetForwardsByMessageIDs :many
+SELECT
+    mf.message_id,
+    mf.original_message_id,
+    mf.original_conversation_id,
+    u.id as user_id,
+    u.name as user_name,
+    u.avatar as user_avatar
+FROM message_forwards mf
+JOIN users u ON u.id = mf.original_user_id
+WHERE mf.message_id = ANY($1::uuid[])
+`
+
+type GetForwardsByMessageIDsRow struct {
+	MessageID              pgtype.UUID `json:"message_id"`
+	OriginalMessageID      pgtype.UUID `json:"original_message_id"`
+	OriginalConversationID pgtype.UUID `json:"original_conversation_id"`
+	UserID                 pgtype.UUID `json:"user_id"`
+	UserName               string      `json:"user_name"`
+	UserAvatar             pgtype.Text `json:"user_avatar"`
+}
+
+func (q *Queries) GetForwardsByMessageIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]GetForwardsByMessageIDsRow, error) {
+	rows, err := q.db.Query(ctx, getForwardsByMessageIDs, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetForwardsByMessageIDsRow
+	for rows.Next() {
+		var i GetForwardsByMessageIDsRow
+		if err := rows.Scan(
+			&i.MessageID,
+			&i.OriginalMessageID,
+			&i.OriginalConversationID,
+			&i.UserID,
+			&i.UserName,
+			&i.UserAvatar,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getGroupConversationWithOwner = `-- name: GetGroupConversationWithOwner :one
 SELECT
     gc.id,
@@ -354,6 +989,7 @@ SELECT
     gc.avatar,
     gc.conversation_id,
     gc.owner_id,
+    gc.visibility,
     c.type as conversation_type,
     p.id as owner_participant_id,
     p.user_id as owner_user_id,
@@ -374,6 +1010,7 @@ type GetGroupConversationWithOwnerRow struct {
 	Avatar              pgtype.Text `json:"avatar"`
 	ConversationID      pgtype.UUID `json:"conversation_id"`
 	OwnerID             pgtype.UUID `json:"owner_id"`
+	Visibility          string      `json:"visibility"`
 	ConversationType    int32       `json:"conversation_type"`
 	OwnerParticipantID  pgtype.UUID `json:"owner_participant_id"`
 	OwnerUserID         pgtype.UUID `json:"owner_user_id"`
@@ -389,6 +1026,7 @@ func (q *Queries) GetGroupConversationWithOwner(ctx context.Context, conversatio
 		&i.Avatar,
 		&i.ConversationID,
 		&i.OwnerID,
+		&i.Visibility,
 		&i.ConversationType,
 		&i.OwnerParticipantID,
 		&i.OwnerUserID,
@@ -397,118 +1035,1094 @@ func (q *Queries) GetGroupConversationWithOwner(ctx context.Context, conversatio
 	return i, err
 }
 
-const getMessageWithUser = `-- name: GetMessageWithUser :one
-SELECT
-    m.id, m.type, m.created_at, m.conversation_id, m.content,
-    u.id as user_id, u.name as user_name, u.avatar as user_avatar
-FROM messages m
-JOIN users u ON u.id = m.user_id
-WHERE m.id = $1 AND u.deleted_at IS NULL
-LIMIT 1
+const getGroupVisibility = `-- name: GetGroupVisibility :one
+SELECT gc.visibility
+FROM group_conversations gc
+JOIN conversations c ON c.id = gc.conversation_id
+WHERE gc.conversation_id = $1
+  AND gc.deleted_at IS NULL
+  AND c.deleted_at IS NULL
 `
 
-type GetMessageWithUserRow struct {
-	ID             pgtype.UUID        `json:"id"`
-	Type           int32              `json:"type"`
-	CreatedAt      pgtype.Timestamptz `json:"created_at"`
-	ConversationID pgtype.UUID        `json:"conversation_id"`
-	Content        string             `json:"content"`
-	UserID         pgtype.UUID        `json:"user_id"`
-	UserName       string             `json:"user_name"`
-	UserAvatar     pgtype.Text        `json:"user_avatar"`
+func (q *Queries) GetGroupVisibility(ctx context.Context, conversationID pgtype.UUID) (string, error) {
+	row := q.db.QueryRow(ctx, getGroupVisibility, conversationID)
+	var visibility string
+	err := row.Scan(&visibility)
+	return visibility, err
 }
 
-func (q *Queries) GetMessageWithUser(ctx context.Context, id pgtype.UUID) (GetMessageWithUserRow, error) {
-	row := q.db.QueryRow(ctx, getMessageWithUser, id)
-	var i GetMessageWithUserRow
+const getInviteLinkByID = `-- name: GetInviteLinkByID :one
+SELECT id, conversation_id, creator_id, max_uses, use_count, expires_at, revoked_at, created_at, updated_at
+FROM invite_links
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetInviteLinkByID(ctx context.Context, id pgtype.UUID) (InviteLink, error) {
+	row := q.db.QueryRow(ctx, getInviteLinkByID, id)
+	var i InviteLink
 	err := row.Scan(
 		&i.ID,
-		&i.Type,
-		&i.CreatedAt,
 		&i.ConversationID,
-		&i.Content,
-		&i.UserID,
-		&i.UserName,
-		&i.UserAvatar,
+		&i.CreatorID,
+		&i.MaxUses,
+		&i.UseCount,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const getNotificationMessageRaw = `-- name: GetNotificationMessageRaw :one
-SELECT
-    m.id,
-    m.type,
-    m.created_at,
-    m.conversation_id,
-    m.content,
-    u.id as user_id,
-    u.name as user_name,
-    u.avatar as user_avatar
-FROM messages m
-LEFT JOIN users u ON u.id = m.user_id
-WHERE m.id = $1 AND m.deleted_at IS NULL
-LIMIT 1
+const getJoinRequestByID = `-- name: GetJoinRequestByID :one
+SELECT id, conversation_id, user_id, status, decided_by, created_at
+FROM join_requests
+WHERE id = $1
 `
 
-type GetNotificationMessageRawRow struct {
+type GetJoinRequestByIDRow struct {
 	ID             pgtype.UUID        `json:"id"`
-	Type           int32              `json:"type"`
-	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	ConversationID pgtype.UUID        `json:"conversation_id"`
-	Content        string             `json:"content"`
 	UserID         pgtype.UUID        `json:"user_id"`
-	UserName       pgtype.Text        `json:"user_name"`
-	UserAvatar     pgtype.Text        `json:"user_avatar"`
+	Status         string             `json:"status"`
+	DecidedBy      pgtype.UUID        `json:"decided_by"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 }
 
-func (q *Queries) GetNotificationMessageRaw(ctx context.Context, id pgtype.UUID) (GetNotificationMessageRawRow, error) {
-	row := q.db.QueryRow(ctx, getNotificationMessageRaw, id)
-	var i GetNotificationMessageRawRow
+func (q *Queries) GetJoinRequestByID(ctx context.Context, id pgtype.UUID) (GetJoinRequestByIDRow, error) {
+	row := q.db.QueryRow(ctx, getJoinRequestByID, id)
+	var i GetJoinRequestByIDRow
 	err := row.Scan(
 		&i.ID,
-		&i.Type,
-		&i.CreatedAt,
 		&i.ConversationID,
-		&i.Content,
 		&i.UserID,
-		&i.UserName,
-		&i.UserAvatar,
+		&i.Status,
+		&i.DecidedBy,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
-const getParticipantsByConversationID = `-- name: GetParticipantsByConversationID :many
-SELECT u.id, u.name, u.avatar
-FROM users u
-JOIN participants p ON p.user_id = u.id
-WHERE p.conversation_id = $1
+const getLongestStandingMember = `-- name: GetLongestStandingMember :one
+SELECT user_id
+FROM participants
+WHERE conversation_id = $1
+  AND user_id <> $2
+  AND deleted_at IS NULL
+ORDER BY created_at ASC, id ASC
+LIMIT 1
+`
+
+type GetLongestStandingMemberParams struct {
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	UserID         pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetLongestStandingMember(ctx context.Context, arg GetLongestStandingMemberParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getLongestStandingMember, arg.ConversationID, arg.UserID)
+	var user_id pgtype.UUID
+	err := row.Scan(&user_id)
+	return user_id, err
+}
+
+const getMemberIDsByRoles = `-- name: GetMemberIDsByRoles :many
+SELECT p.user_id
+FROM participants p
+WHERE p.conversation_id = $1
+  AND p.role = ANY($2::text[])
+  AND p.deleted_at IS NULL
+`
+
+type GetMemberIDsByRolesParams struct {
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	Roles          []string    `json:"roles"`
+}
+
+func (q *Queries) GetMemberIDsByRoles(ctx context.Context, arg GetMemberIDsByRolesParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, getMemberIDsByRoles, arg.ConversationID, arg.Roles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var user_id pgtype.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMemberRole = `-- name: GetMemberRole :one
+SELECT p.role
+FROM participants p
+JOIN conversations c ON c.id = p.conversation_id
+WHERE p.conversation_id = $1 AND p.user_id = $2
+  AND p.deleted_at IS NULL AND c.deleted_at IS NULL
+`
+
+type GetMemberRoleParams struct {
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	UserID         pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetMemberRole(ctx context.Context, arg GetMemberRoleParams) (string, error) {
+	row := q.db.QueryRow(ctx, getMemberRole, arg.ConversationID, arg.UserID)
+	var role string
+	err := row.Scan(&role)
+	return role, err
+}
+
+const getMentionsByMessageIDs = `-- name: GetMentionsByMessageIDs :many
+SELECT message_id, user_id
+FROM message_mentions
+WHERE message_id = ANY($1::uuid[])
+`
+
+type GetMentionsByMessageIDsRow struct {
+	MessageID pgtype.UUID `json:"message_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetMentionsByMessageIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]GetMentionsByMessageIDsRow, error) {
+	rows, err := q.db.Query(ctx, getMentionsByMessageIDs, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMentionsByMessageIDsRow
+	for rows.Next() {
+		var i GetMentionsByMessageIDsRow
+		if err := rows.Scan(&i.MessageID, &i.UserID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMentionsRaw = `-- name: GetMentionsRaw :many
+SELECT
+    m.id,
+    m.type,
+    m.created_at,
+    m.conversation_id,
+    m.content,
+    u.id as user_id,
+    u.name as user_name,
+    u.avatar as user_avatar,
+    m.edited_at,
+    m.parent_id
+FROM message_mentions mm
+JOIN messages m ON m.id = mm.message_id
+JOIN users u ON u.id = m.user_id
+JOIN participants p ON p.conversation_id = m.conversation_id AND p.user_id = mm.user_id
+WHERE mm.user_id = $1
+  AND m.deleted_at IS NULL
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+  AND p.deleted_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM hidden_messages hm
+    WHERE hm.message_id = m.id AND hm.user_id = $1
+  )
+  AND (
+    $2::timestamptz IS NULL
+    OR m.created_at < $2
+    OR (
+      m.created_at = $2
+      AND m.id < $3
+    )
+  )
+ORDER BY m.created_at DESC, m.id DESC
+LIMIT $4
+`
+
+type GetMentionsRawParams struct {
+	UserID          pgtype.UUID        `json:"user_id"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.UUID        `json:"cursor_id"`
+	PageLimit       int32              `json:"page_limit"`
+}
+
+type GetMentionsRawRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	Type           int32              `json:"type"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	Content        string             `json:"content"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	UserName       string             `json:"user_name"`
+	UserAvatar     pgtype.Text        `json:"user_avatar"`
+	EditedAt       pgtype.Timestamptz `json:"edited_at"`
+	ParentID       pgtype.UUID        `json:"parent_id"`
+}
+
+func (q *Queries) GetMentionsRaw(ctx context.Context, arg GetMentionsRawParams) ([]GetMentionsRawRow, error) {
+	rows, err := q.db.Query(ctx, getMentionsRaw,
+		arg.UserID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMentionsRawRow
+	for rows.Next() {
+		var i GetMentionsRawRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.CreatedAt,
+			&i.ConversationID,
+			&i.Content,
+			&i.UserID,
+			&i.UserName,
+			&i.UserAvatar,
+			&i.EditedAt,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMessageByID = `-- name: GetMessageByID :one
+SELECT id, conversation_id, user_id, content, type, parent_id
+FROM messages
+WHERE id = $1 AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+LIMIT 1
+`
+
+type GetMessageByIDRow struct {
+	ID             pgtype.UUID `json:"id"`
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	UserID         pgtype.UUID `json:"user_id"`
+	Content        string      `json:"content"`
+	Type           int32       `json:"type"`
+	ParentID       pgtype.UUID `json:"parent_id"`
+}
+
+func (q *Queries) GetMessageByID(ctx context.Context, id pgtype.UUID) (GetMessageByIDRow, error) {
+	row := q.db.QueryRow(ctx, getMessageByID, id)
+	var i GetMessageByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.ConversationID,
+		&i.UserID,
+		&i.Content,
+		&i.Type,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const getMessageForward = `-- name: GetMessageForward :one
+SELECT original_message_id, original_user_id, original_conversation_id
+FROM message_forwards
+WHERE message_id = $1
+`
+
+type GetMessageForwardRow struct {
+	OriginalMessageID      pgtype.UUID `json:"original_message_id"`
+	OriginalUserID         pgtype.UUID `json:"original_user_id"`
+	OriginalConversationID pgtype.UUID `json:"original_conversation_id"`
+}
+
+func (q *Queries) GetMessageForward(ctx context.Context, messageID pgtype.UUID) (GetMessageForwardRow, error) {
+	row := q.db.QueryRow(ctx, getMessageForward, messageID)
+	var i GetMessageForwardRow
+	err := row.Scan(&i.OriginalMessageID, &i.OriginalUserID, &i.OriginalConversationID)
+	return i, err
+}
+
+const getMessageRevisions = `-- name: GetMessageRevisions :many
+SELECT mr.id, mr.message_id, mr.content, mr.created_at
+FROM message_revisions mr
+JOIN messages m ON m.id = mr.message_id
+JOIN participants p ON p.conversation_id = m.conversation_id AND p.user_id = $2
+WHERE mr.message_id = $1
+  AND m.deleted_at IS NULL
+  AND p.deleted_at IS NULL
+ORDER BY mr.created_at DESC
+`
+
+type GetMessageRevisionsParams struct {
+	MessageID pgtype.UUID `json:"message_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetMessageRevisions(ctx context.Context, arg GetMessageRevisionsParams) ([]MessageRevision, error) {
+	rows, err := q.db.Query(ctx, getMessageRevisions, arg.MessageID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MessageRevision
+	for rows.Next() {
+		var i MessageRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.MessageID,
+			&i.Content,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMessageWithUser = `-- name: GetMessageWithUser :one
+SELECT
+    m.id, m.type, m.created_at, m.conversation_id, m.content,
+    u.id as user_id, u.name as user_name, u.avatar as user_avatar
+FROM messages m
+JOIN users u ON u.id = m.user_id
+WHERE m.id = $1 AND u.deleted_at IS NULL
+LIMIT 1
+`
+
+type GetMessageWithUserRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	Type           int32              `json:"type"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	Content        string             `json:"content"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	UserName       string             `json:"user_name"`
+	UserAvatar     pgtype.Text        `json:"user_avatar"`
+}
+
+func (q *Queries) GetMessageWithUser(ctx context.Context, id pgtype.UUID) (GetMessageWithUserRow, error) {
+	row := q.db.QueryRow(ctx, getMessageWithUser, id)
+	var i GetMessageWithUserRow
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.CreatedAt,
+		&i.ConversationID,
+		&i.Content,
+		&i.UserID,
+		&i.UserName,
+		&i.UserAvatar,
+	)
+	return i, err
+}
+
+const getNotificationMessageRaw = `-- name: GetNotificationMessageRaw :one
+SELECT
+    m.id,
+    m.type,
+    m.created_at,
+    m.conversation_id,
+    m.content,
+    u.id as user_id,
+    u.name as user_name,
+    u.avatar as user_avatar,
+    m.edited_at,
+    (SELECT COUNT(*) FROM message_revisions mr WHERE mr.message_id = m.id) as revision_count
+FROM messages m
+LEFT JOIN users u ON u.id = m.user_id
+WHERE m.id = $1 AND m.deleted_at IS NULL
+LIMIT 1
+`
+
+type GetNotificationMessageRawRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	Type           int32              `json:"type"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	Content        string             `json:"content"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	UserName       pgtype.Text        `json:"user_name"`
+	UserAvatar     pgtype.Text        `json:"user_avatar"`
+	EditedAt       pgtype.Timestamptz `json:"edited_at"`
+	RevisionCount  int64              `json:"revision_count"`
+}
+
+func (q *Queries) GetNotificationMessageRaw(ctx context.Context, id pgtype.UUID) (GetNotificationMessageRawRow, error) {
+	row := q.db.QueryRow(ctx, getNotificationMessageRaw, id)
+	var i GetNotificationMessageRawRow
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.CreatedAt,
+		&i.ConversationID,
+		&i.Content,
+		&i.UserID,
+		&i.UserName,
+		&i.UserAvatar,
+		&i.EditedAt,
+		&i.RevisionCount,
+	)
+	return i, err
+}
+
+const getNotificationPreferencesByUserID = `-- name: GetNotificationPreferencesByUserID :many
+SELECT conversation_id, notification_level, muted_until
+FROM participants
+WHERE user_id = $1 AND deleted_at IS NULL
+`
+
+type GetNotificationPreferencesByUserIDRow struct {
+	ConversationID    pgtype.UUID        `json:"conversation_id"`
+	NotificationLevel string             `json:"notification_level"`
+	MutedUntil        pgtype.Timestamptz `json:"muted_until"`
+}
+
+func (q *Queries) GetNotificationPreferencesByUserID(ctx context.Context, userID pgtype.UUID) ([]GetNotificationPreferencesByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, getNotificationPreferencesByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNotificationPreferencesByUserIDRow
+	for rows.Next() {
+		var i GetNotificationPreferencesByUserIDRow
+		if err := rows.Scan(&i.ConversationID, &i.NotificationLevel, &i.MutedUntil); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getParticipantIDsByNames = `-- name: GetParticipantIDsByNames :many
+SELECT u.id
+FROM users u
+JOIN participants p ON p.user_id = u.id
+WHERE p.conversation_id = $1
+  AND lower(u.name) = ANY($2::text[])
+  AND p.deleted_at IS NULL
+  AND u.deleted_at IS NULL
+`
+
+type GetParticipantIDsByNamesParams struct {
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	Names          []string    `json:"names"`
+}
+
+func (q *Queries) GetParticipantIDsByNames(ctx context.Context, arg GetParticipantIDsByNamesParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, getParticipantIDsByNames, arg.ConversationID, arg.Names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getParticipantsByConversationID = `-- name: GetParticipantsByConversationID :many
+SELECT u.id, u.name, u.avatar, u.last_seen_at, p.role
+FROM users u
+JOIN participants p ON p.user_id = u.id
+WHERE p.conversation_id = $1
   AND p.deleted_at IS NULL
   AND u.deleted_at IS NULL
 LIMIT $2 OFFSET $3
 `
 
-type GetParticipantsByConversationIDParams struct {
+type GetParticipantsByConversationIDParams struct {
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	Limit          int32       `json:"limit"`
+	Offset         int32       `json:"offset"`
+}
+
+type GetParticipantsByConversationIDRow struct {
+	ID         pgtype.UUID        `json:"id"`
+	Name       string             `json:"name"`
+	Avatar     pgtype.Text        `json:"avatar"`
+	LastSeenAt pgtype.Timestamptz `json:"last_seen_at"`
+	Role       string             `json:"role"`
+}
+
+func (q *Queries) GetParticipantsByConversationID(ctx context.Context, arg GetParticipantsByConversationIDParams) ([]GetParticipantsByConversationIDRow, error) {
+	rows, err := q.db.Query(ctx, getParticipantsByConversationID, arg.ConversationID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetParticipantsByConversationIDRow
+	for rows.Next() {
+		var i GetParticipantsByConversationIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Avatar,
+			&i.LastSeenAt,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getParticipantsIDsByConversationID = `-- name: GetParticipantsIDsByConversationID :many
+SELECT user_id
+FROM participants
+WHERE conversation_id = $1 AND deleted_at IS NULL
+ORDER BY created_at ASC
+`
+
+func (q *Queries) GetParticipantsIDsByConversationID(ctx context.Context, conversationID pgtype.UUID) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, getParticipantsIDsByConversationID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var user_id pgtype.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPendingJoinRequests = `-- name: GetPendingJoinRequests :many
+SELECT
+    jr.id, jr.conversation_id, jr.created_at,
+    u.id as user_id, u.name as user_name, u.avatar as user_avatar
+FROM join_requests jr
+JOIN users u ON u.id = jr.user_id
+WHERE jr.conversation_id = $1
+  AND jr.status = 'pending'
+  AND u.deleted_at IS NULL
+ORDER BY jr.created_at
+`
+
+type GetPendingJoinRequestsRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	UserName       string             `json:"user_name"`
+	UserAvatar     pgtype.Text        `json:"user_avatar"`
+}
+
+func (q *Queries) GetPendingJoinRequests(ctx context.Context, conversationID pgtype.UUID) ([]GetPendingJoinRequestsRow, error) {
+	rows, err := q.db.Query(ctx, getPendingJoinRequests, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPendingJoinRequestsRow
+	for rows.Next() {
+		var i GetPendingJoinRequestsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ConversationID,
+			&i.CreatedAt,
+			&i.UserID,
+			&i.UserName,
+			&i.UserAvatar,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPinnedMessagesRaw = `-- name: GetPinnedMessagesRaw :many
+SELECT
+    m.id,
+    m.type,
+    m.created_at,
+    m.conversation_id,
+    m.content,
+    u.id as user_id,
+    u.name as user_name,
+    u.avatar as user_avatar,
+    m.edited_at,
+    m.parent_id,
+    pm.created_at as pinned_at,
+    pb.id as pinned_by_id,
+    pb.name as pinned_by_name,
+    pb.avatar as pinned_by_avatar
+FROM pinned_messages pm
+JOIN messages m ON m.id = pm.message_id
+JOIN users u ON u.id = m.user_id
+JOIN users pb ON pb.id = pm.pinned_by
+WHERE pm.conversation_id = $1
+  AND m.deleted_at IS NULL
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+  AND NOT EXISTS (
+    SELECT 1 FROM hidden_messages hm
+    WHERE hm.message_id = m.id AND hm.user_id = $4
+  )
+ORDER BY pm.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type GetPinnedMessagesRawParams struct {
 	ConversationID pgtype.UUID `json:"conversation_id"`
 	Limit          int32       `json:"limit"`
 	Offset         int32       `json:"offset"`
+	UserID         pgtype.UUID `json:"user_id"`
 }
 
-type GetParticipantsByConversationIDRow struct {
-	ID     pgtype.UUID `json:"id"`
-	Name   string      `json:"name"`
-	Avatar pgtype.Text `json:"avatar"`
+type GetPinnedMessagesRawRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	Type           int32              `json:"type"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	Content        string             `json:"content"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	UserName       string             `json:"user_name"`
+	UserAvatar     pgtype.Text        `json:"user_avatar"`
+	EditedAt       pgtype.Timestamptz `json:"edited_at"`
+	ParentID       pgtype.UUID        `json:"parent_id"`
+	PinnedAt       pgtype.Timestamptz `json:"pinned_at"`
+	PinnedByID     pgtype.UUID        `json:"pinned_by_id"`
+	PinnedByName   string             `json:"pinned_by_name"`
+	PinnedByAvatar pgtype.Text        `json:"pinned_by_avatar"`
 }
 
-func (q *Queries) GetParticipantsByConversationID(ctx context.Context, arg GetParticipantsByConversationIDParams) ([]GetParticipantsByConversationIDRow, error) {
-	rows, err := q.db.Query(ctx, getParticipantsByConversationID, arg.ConversationID, arg.Limit, arg.Offset)
+func (q *Queries) GetPinnedMessagesRaw(ctx context.Context, arg GetPinnedMessagesRawParams) ([]GetPinnedMessagesRawRow, error) {
+	rows, err := q.db.Query(ctx, getPinnedMessagesRaw,
+		arg.ConversationID,
+		arg.Limit,
+		arg.Offset,
+		arg.UserID,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetParticipantsByConversationIDRow
+	var items []GetPinnedMessagesRawRow
 	for rows.Next() {
-		var i GetParticipantsByConversationIDRow
-		if err := rows.Scan(&i.ID, &i.Name, &i.Avatar); err != nil {
+		var i GetPinnedMessagesRawRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.CreatedAt,
+			&i.ConversationID,
+			&i.Content,
+			&i.UserID,
+			&i.UserName,
+			&i.UserAvatar,
+			&i.EditedAt,
+			&i.ParentID,
+			&i.PinnedAt,
+			&i.PinnedByID,
+			&i.PinnedByName,
+			&i.PinnedByAvatar,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPollByMessageID = `-- name: GetPollByMessageID :one
+SELECT id, message_id, conversation_id, user_id, question, multiple_choice, anonymous, closed_at, created_at FROM polls
+WHERE message_id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetPollByMessageID(ctx context.Context, messageID pgtype.UUID) (Poll, error) {
+	row := q.db.QueryRow(ctx, getPollByMessageID, messageID)
+	var i Poll
+	err := row.Scan(
+		&i.ID,
+		&i.MessageID,
+		&i.ConversationID,
+		&i.UserID,
+		&i.Question,
+		&i.MultipleChoice,
+		&i.Anonymous,
+		&i.ClosedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPollOptions = `-- name: GetPollOptions :many
+SELECT id, poll_id, position, text FROM poll_options
+WHERE poll_id = $1
+ORDER BY position ASC
+`
+
+func (q *Queries) GetPollOptions(ctx context.Context, pollID pgtype.UUID) ([]PollOption, error) {
+	rows, err := q.db.Query(ctx, getPollOptions, pollID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PollOption
+	for rows.Next() {
+		var i PollOption
+		if err := rows.Scan(
+			&i.ID,
+			&i.PollID,
+			&i.Position,
+			&i.Text,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPollTalliesByPollIDs = `-- name: GetPollTalliesByPollIDs :many
+SELECT
+    o.id,
+    o.poll_id,
+    o.text,
+    COUNT(v.user_id) as vote_count,
+    COALESCE(
+        array_agg(v.user_id ORDER BY v.created_at) FILTER (WHERE v.user_id IS NOT NULL AND NOT p.anonymous),
+        '{}'
+    )::uuid[] as user_ids
+FROM poll_options o
+JOIN polls p ON p.id = o.poll_id
+LEFT JOIN poll_votes v ON v.option_id = o.id
+WHERE o.poll_id = ANY($1::uuid[])
+GROUP BY o.id, p.anonymous
+ORDER BY o.poll_id, o.position
+`
+
+type GetPollTalliesByPollIDsRow struct {
+	ID        pgtype.UUID   `json:"id"`
+	PollID    pgtype.UUID   `json:"poll_id"`
+	Text      string        `json:"text"`
+	VoteCount int64         `json:"vote_count"`
+	UserIds   []pgtype.UUID `json:"user_ids"`
+}
+
+func (q *Queries) GetPollTalliesByPollIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]GetPollTalliesByPollIDsRow, error) {
+	rows, err := q.db.Query(ctx, getPollTalliesByPollIDs, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPollTalliesByPollIDsRow
+	for rows.Next() {
+		var i GetPollTalliesByPollIDsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PollID,
+			&i.Text,
+			&i.VoteCount,
+			&i.UserIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPollsByMessageIDs = `-- name: GetPollsByMessageIDs :many
+SELECT
+    p.id,
+    p.message_id,
+    p.question,
+    p.multiple_choice,
+    p.anonymous,
+    p.closed_at,
+    (SELECT COUNT(DISTINCT v.user_id) FROM poll_votes v WHERE v.poll_id = p.id) as total_voters
+FROM polls p
+WHERE p.message_id = ANY($1::uuid[])
+`
+
+type GetPollsByMessageIDsRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	MessageID      pgtype.UUID        `json:"message_id"`
+	Question       string             `json:"question"`
+	MultipleChoice bool               `json:"multiple_choice"`
+	Anonymous      bool               `json:"anonymous"`
+	ClosedAt       pgtype.Timestamptz `json:"closed_at"`
+	TotalVoters    int64              `json:"total_voters"`
+}
+
+func (q *Queries) GetPollsByMessageIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]GetPollsByMessageIDsRow, error) {
+	rows, err := q.db.Query(ctx, getPollsByMessageIDs, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPollsByMessageIDsRow
+	for rows.Next() {
+		var i GetPollsByMessageIDsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.MessageID,
+			&i.Question,
+			&i.MultipleChoice,
+			&i.Anonymous,
+			&i.ClosedAt,
+			&i.TotalVoters,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPotentialInvitees = `-- name: GetPotentialInvitees :many
+SELECT u.id, u.name, u.avatar, u.last_seen_at
+FROM users u
+WHERE u.deleted_at IS NULL
+  AND u.id NOT IN (
+    SELECT user_id
+    FROM participants
+    WHERE conversation_id = $1
+      AND deleted_at IS NULL
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM user_blocks b
+    WHERE (b.blocker_id = $4 AND b.blocked_id = u.id)
+       OR (b.blocker_id = u.id AND b.blocked_id = $4)
+  )
+LIMIT $2 OFFSET $3
+`
+
+type GetPotentialInviteesParams struct {
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	Limit          int32       `json:"limit"`
+	Offset         int32       `json:"offset"`
+	UserID         pgtype.UUID `json:"user_id"`
+}
+
+type GetPotentialInviteesRow struct {
+	ID         pgtype.UUID        `json:"id"`
+	Name       string             `json:"name"`
+	Avatar     pgtype.Text        `json:"avatar"`
+	LastSeenAt pgtype.Timestamptz `json:"last_seen_at"`
+}
+
+func (q *Queries) GetPotentialInvitees(ctx context.Context, arg GetPotentialInviteesParams) ([]GetPotentialInviteesRow, error) {
+	rows, err := q.db.Query(ctx, getPotentialInvitees,
+		arg.ConversationID,
+		arg.Limit,
+		arg.Offset,
+		arg.UserID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPotentialInviteesRow
+	for rows.Next() {
+		var i GetPotentialInviteesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Avatar,
+			&i.LastSeenAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProcessingAttachmentIDs = `-- name: GetProcessingAttachmentIDs :many
+SELECT id
+FROM attachments
+WHERE status = 'processing' AND created_at < $1
+ORDER BY created_at ASC
+LIMIT $2
+`
+
+type GetProcessingAttachmentIDsParams struct {
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Limit     int32              `json:"limit"`
+}
+
+func (q *Queries) GetProcessingAttachmentIDs(ctx context.Context, arg GetProcessingAttachmentIDsParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, getProcessingAttachmentIDs, arg.CreatedAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublicGroups = `-- name: GetPublicGroups :many
+SELECT
+    gc.conversation_id,
+    gc.name,
+    gc.avatar,
+    gc.created_at,
+    (
+        SELECT COUNT(*) FROM participants p
+        WHERE p.conversation_id = gc.conversation_id AND p.deleted_at IS NULL
+    ) as participants_count,
+    EXISTS(
+        SELECT 1 FROM participants p
+        WHERE p.conversation_id = gc.conversation_id AND p.user_id = $1 AND p.deleted_at IS NULL
+    ) as has_joined
+FROM group_conversations gc
+JOIN conversations c ON c.id = gc.conversation_id
+WHERE gc.visibility = 'public'
+  AND gc.deleted_at IS NULL
+  AND c.deleted_at IS NULL
+  AND ($2::text = '' OR gc.name ILIKE '%' || $2::text || '%' ESCAPE '\')
+ORDER BY gc.created_at DESC, gc.conversation_id
+LIMIT $3 OFFSET $4
+`
+
+type GetPublicGroupsParams struct {
+	UserID     pgtype.UUID `json:"user_id"`
+	Search     string      `json:"search"`
+	PageLimit  int32       `json:"page_limit"`
+	PageOffset int32       `json:"page_offset"`
+}
+
+type GetPublicGroupsRow struct {
+	ConversationID    pgtype.UUID        `json:"conversation_id"`
+	Name              string             `json:"name"`
+	Avatar            pgtype.Text        `json:"avatar"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	ParticipantsCount int64              `json:"participants_count"`
+	HasJoined         bool               `json:"has_joined"`
+}
+
+func (q *Queries) GetPublicGroups(ctx context.Context, arg GetPublicGroupsParams) ([]GetPublicGroupsRow, error) {
+	rows, err := q.db.Query(ctx, getPublicGroups,
+		arg.UserID,
+		arg.Search,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPublicGroupsRow
+	for rows.Next() {
+		var i GetPublicGroupsRow
+		if err := rows.Scan(
+			&i.ConversationID,
+			&i.Name,
+			&i.Avatar,
+			&i.CreatedAt,
+			&i.ParticipantsCount,
+			&i.HasJoined,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReactionsByMessageIDs = `-- name: GetReactionsByMessageIDs :many
+SELECT
+    message_id,
+    emoji,
+    COUNT(*) as count,
+    array_agg(user_id ORDER BY created_at)::uuid[] as user_ids
+FROM message_reactions
+WHERE message_id = ANY($1::uuid[])
+GROUP BY message_id, emoji
+ORDER BY message_id, MIN(created_at)
+`
+
+type GetReactionsByMessageIDsRow struct {
+	MessageID pgtype.UUID   `json:"message_id"`
+	Emoji     string        `json:"emoji"`
+	Count     int64         `json:"count"`
+	UserIds   []pgtype.UUID `json:"user_ids"`
+}
+
+func (q *Queries) GetReactionsByMessageIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]GetReactionsByMessageIDsRow, error) {
+	rows, err := q.db.Query(ctx, getReactionsByMessageIDs, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReactionsByMessageIDsRow
+	for rows.Next() {
+		var i GetReactionsByMessageIDsRow
+		if err := rows.Scan(
+			&i.MessageID,
+			&i.Emoji,
+			&i.Count,
+			&i.UserIds,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -519,26 +2133,76 @@ func (q *Queries) GetParticipantsByConversationID(ctx context.Context, arg GetPa
 	return items, nil
 }
 
-const getParticipantsIDsByConversationID = `-- name: GetParticipantsIDsByConversationID :many
-SELECT user_id
-FROM participants
-WHERE conversation_id = $1 AND deleted_at IS NULL
-ORDER BY created_at ASC
+const getScheduledMessageByID = `-- name: GetScheduledMessageByID :one
+SELECT id, conversation_id, user_id, content, scheduled_at, created_at, updated_at, attempts, next_attempt_at, failed_at, last_error FROM scheduled_messages
+WHERE id = $1
+LIMIT 1
 `
 
-func (q *Queries) GetParticipantsIDsByConversationID(ctx context.Context, conversationID pgtype.UUID) ([]pgtype.UUID, error) {
-	rows, err := q.db.Query(ctx, getParticipantsIDsByConversationID, conversationID)
+func (q *Queries) GetScheduledMessageByID(ctx context.Context, id pgtype.UUID) (ScheduledMessage, error) {
+	row := q.db.QueryRow(ctx, getScheduledMessageByID, id)
+	var i ScheduledMessage
+	err := row.Scan(
+		&i.ID,
+		&i.ConversationID,
+		&i.UserID,
+		&i.Content,
+		&i.ScheduledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.FailedAt,
+		&i.LastError,
+	)
+	return i, err
+}
+
+const getScheduledMessages = `-- name: GetScheduledMessages :many
+SELECT id, conversation_id, user_id, content, scheduled_at, created_at, updated_at, attempts, next_attempt_at, failed_at, last_error FROM scheduled_messages
+WHERE user_id = $1
+  AND ($2::uuid IS NULL OR conversation_id = $2::uuid)
+ORDER BY scheduled_at ASC
+LIMIT $3 OFFSET $4
+`
+
+type GetScheduledMessagesParams struct {
+	UserID         pgtype.UUID `json:"user_id"`
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	PageLimit      int32       `json:"page_limit"`
+	PageOffset     int32       `json:"page_offset"`
+}
+
+func (q *Queries) GetScheduledMessages(ctx context.Context, arg GetScheduledMessagesParams) ([]ScheduledMessage, error) {
+	rows, err := q.db.Query(ctx, getScheduledMessages,
+		arg.UserID,
+		arg.ConversationID,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []pgtype.UUID
+	var items []ScheduledMessage
 	for rows.Next() {
-		var user_id pgtype.UUID
-		if err := rows.Scan(&user_id); err != nil {
+		var i ScheduledMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ConversationID,
+			&i.UserID,
+			&i.Content,
+			&i.ScheduledAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.FailedAt,
+			&i.LastError,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, user_id)
+		items = append(items, i)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -546,41 +2210,87 @@ func (q *Queries) GetParticipantsIDsByConversationID(ctx context.Context, conver
 	return items, nil
 }
 
-const getPotentialInvitees = `-- name: GetPotentialInvitees :many
-SELECT u.id, u.name, u.avatar
-FROM users u
-WHERE u.deleted_at IS NULL
-  AND u.id NOT IN (
-    SELECT user_id
-    FROM participants
-    WHERE conversation_id = $1
-      AND deleted_at IS NULL
+const getThreadMessagesRaw = `-- name: GetThreadMessagesRaw :many
+SELECT
+    m.id,
+    m.type,
+    m.created_at,
+    m.conversation_id,
+    CASE WHEN m.deleted_at IS NULL THEN m.content ELSE '' END as content,
+    m.user_id,
+    m.edited_at,
+    (SELECT COUNT(*) FROM message_revisions mr WHERE mr.message_id = m.id) as revision_count,
+    m.deleted_at,
+    m.parent_id
+FROM messages m
+JOIN participants p ON p.conversation_id = m.conversation_id AND p.user_id = $1
+WHERE m.parent_id = $2
+  AND p.deleted_at IS NULL
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+  AND NOT EXISTS (
+    SELECT 1 FROM hidden_messages hm
+    WHERE hm.message_id = m.id AND hm.user_id = $1
   )
-LIMIT $2 OFFSET $3
+  AND (
+    $3::timestamptz IS NULL
+    OR m.created_at < $3
+    OR (
+      m.created_at = $3
+      AND m.id < $4
+    )
+  )
+ORDER BY m.created_at DESC, m.id DESC
+LIMIT $5
 `
 
-type GetPotentialInviteesParams struct {
-	ConversationID pgtype.UUID `json:"conversation_id"`
-	Limit          int32       `json:"limit"`
-	Offset         int32       `json:"offset"`
+type GetThreadMessagesRawParams struct {
+	UserID          pgtype.UUID        `json:"user_id"`
+	ParentID        pgtype.UUID        `json:"parent_id"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.UUID        `json:"cursor_id"`
+	PageLimit       int32              `json:"page_limit"`
 }
 
-type GetPotentialInviteesRow struct {
-	ID     pgtype.UUID `json:"id"`
-	Name   string      `json:"name"`
-	Avatar pgtype.Text `json:"avatar"`
+type GetThreadMessagesRawRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	Type           int32              `json:"type"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	Content        string             `json:"content"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	EditedAt       pgtype.Timestamptz `json:"edited_at"`
+	RevisionCount  int64              `json:"revision_count"`
+	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
+	ParentID       pgtype.UUID        `json:"parent_id"`
 }
 
-func (q *Queries) GetPotentialInvitees(ctx context.Context, arg GetPotentialInviteesParams) ([]GetPotentialInviteesRow, error) {
-	rows, err := q.db.Query(ctx, getPotentialInvitees, arg.ConversationID, arg.Limit, arg.Offset)
+func (q *Queries) GetThreadMessagesRaw(ctx context.Context, arg GetThreadMessagesRawParams) ([]GetThreadMessagesRawRow, error) {
+	rows, err := q.db.Query(ctx, getThreadMessagesRaw,
+		arg.UserID,
+		arg.ParentID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetPotentialInviteesRow
+	var items []GetThreadMessagesRawRow
 	for rows.Next() {
-		var i GetPotentialInviteesRow
-		if err := rows.Scan(&i.ID, &i.Name, &i.Avatar); err != nil {
+		var i GetThreadMessagesRawRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.CreatedAt,
+			&i.ConversationID,
+			&i.Content,
+			&i.UserID,
+			&i.EditedAt,
+			&i.RevisionCount,
+			&i.DeletedAt,
+			&i.ParentID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -592,7 +2302,7 @@ func (q *Queries) GetPotentialInvitees(ctx context.Context, arg GetPotentialInvi
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, avatar, name, password, refresh_token, created_at, updated_at, deleted_at FROM users
+SELECT id, avatar, name, password, refresh_token, created_at, updated_at, deleted_at, last_seen_at FROM users
 WHERE id = $1 AND deleted_at IS NULL
 LIMIT 1
 `
@@ -609,6 +2319,7 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.LastSeenAt,
 	)
 	return i, err
 }
@@ -617,6 +2328,8 @@ const getUserConversations = `-- name: GetUserConversations :many
 WITH last_messages AS (
     SELECT conversation_id, MAX(created_at) as max_created_at
     FROM messages
+    WHERE deleted_at IS NULL
+      AND (expires_at IS NULL OR expires_at > NOW())
     GROUP BY conversation_id
 )
 SELECT
@@ -634,11 +2347,25 @@ SELECT
     gc.name as group_name,
     ou.id as other_user_id,
     ou.name as other_user_name,
-    ou.avatar as other_user_avatar
+    ou.avatar as other_user_avatar,
+    (
+        SELECT COUNT(*) FROM messages um
+        WHERE um.conversation_id = c.id
+          AND um.deleted_at IS NULL
+          AND (um.expires_at IS NULL OR um.expires_at > NOW())
+          AND um.type = 0
+          AND um.parent_id IS NULL
+          AND um.user_id <> p.user_id
+          AND (p.last_read_at IS NULL OR um.created_at > p.last_read_at)
+          AND NOT EXISTS (
+            SELECT 1 FROM hidden_messages hm
+            WHERE hm.message_id = um.id AND hm.user_id = p.user_id
+          )
+    ) as unread_count
 FROM conversations c
 JOIN participants p ON p.conversation_id = c.id
 LEFT JOIN last_messages lm ON lm.conversation_id = c.id
-LEFT JOIN messages m ON m.conversation_id = c.id AND m.created_at = lm.max_created_at
+LEFT JOIN messages m ON m.conversation_id = c.id AND m.created_at = lm.max_created_at AND m.deleted_at IS NULL AND (m.expires_at IS NULL OR m.expires_at > NOW())
 LEFT JOIN users u ON u.id = m.user_id
 LEFT JOIN group_conversations gc ON gc.conversation_id = c.id
 LEFT JOIN participants op ON op.conversation_id = c.id
@@ -673,6 +2400,7 @@ type GetUserConversationsRow struct {
 	OtherUserID       pgtype.UUID        `json:"other_user_id"`
 	OtherUserName     pgtype.Text        `json:"other_user_name"`
 	OtherUserAvatar   pgtype.Text        `json:"other_user_avatar"`
+	UnreadCount       int64              `json:"unread_count"`
 }
 
 func (q *Queries) GetUserConversations(ctx context.Context, arg GetUserConversationsParams) ([]GetUserConversationsRow, error) {
@@ -700,6 +2428,7 @@ func (q *Queries) GetUserConversations(ctx context.Context, arg GetUserConversat
 			&i.OtherUserID,
 			&i.OtherUserName,
 			&i.OtherUserAvatar,
+			&i.UnreadCount,
 		); err != nil {
 			return nil, err
 		}
@@ -712,16 +2441,17 @@ func (q *Queries) GetUserConversations(ctx context.Context, arg GetUserConversat
 }
 
 const getUsersByIDs = `-- name: GetUsersByIDs :many
-SELECT id, name, avatar
+SELECT id, name, avatar, last_seen_at
 FROM users
 WHERE id = ANY($1::uuid[])
   AND deleted_at IS NULL
 `
 
 type GetUsersByIDsRow struct {
-	ID     pgtype.UUID `json:"id"`
-	Name   string      `json:"name"`
-	Avatar pgtype.Text `json:"avatar"`
+	ID         pgtype.UUID        `json:"id"`
+	Name       string             `json:"name"`
+	Avatar     pgtype.Text        `json:"avatar"`
+	LastSeenAt pgtype.Timestamptz `json:"last_seen_at"`
 }
 
 func (q *Queries) GetUsersByIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]GetUsersByIDsRow, error) {
@@ -733,7 +2463,12 @@ func (q *Queries) GetUsersByIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]
 	var items []GetUsersByIDsRow
 	for rows.Next() {
 		var i GetUsersByIDsRow
-		if err := rows.Scan(&i.ID, &i.Name, &i.Avatar); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Avatar,
+			&i.LastSeenAt,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -744,6 +2479,41 @@ func (q *Queries) GetUsersByIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]
 	return items, nil
 }
 
+const hasBlocked = `-- name: HasBlocked :one
+SELECT EXISTS(
+    SELECT 1 FROM user_blocks
+    WHERE blocker_id = $1 AND blocked_id = $2
+)
+`
+
+type HasBlockedParams struct {
+	BlockerID pgtype.UUID `json:"blocker_id"`
+	BlockedID pgtype.UUID `json:"blocked_id"`
+}
+
+func (q *Queries) HasBlocked(ctx context.Context, arg HasBlockedParams) (bool, error) {
+	row := q.db.QueryRow(ctx, hasBlocked, arg.BlockerID, arg.BlockedID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const hideMessageForUser = `-- name: HideMessageForUser :exec
+INSERT INTO hidden_messages (message_id, user_id, created_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT DO NOTHING
+`
+
+type HideMessageForUserParams struct {
+	MessageID pgtype.UUID `json:"message_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) HideMessageForUser(ctx context.Context, arg HideMessageForUserParams) error {
+	_, err := q.db.Exec(ctx, hideMessageForUser, arg.MessageID, arg.UserID)
+	return err
+}
+
 const inviteToConversationAtomic = `-- name: InviteToConversationAtomic :one
 WITH valid_conversation AS (
     SELECT gc.conversation_id as conv_id
@@ -779,6 +2549,27 @@ func (q *Queries) InviteToConversationAtomic(ctx context.Context, arg InviteToCo
 	return user_id, err
 }
 
+const isBlockedInDirectConversation = `-- name: IsBlockedInDirectConversation :one
+SELECT EXISTS(
+    SELECT 1 FROM conversations c
+    JOIN participants p ON p.conversation_id = c.id AND p.user_id <> $1 AND p.deleted_at IS NULL
+    JOIN user_blocks b ON b.blocker_id = p.user_id AND b.blocked_id = $1
+    WHERE c.id = $2 AND c.type = 1 AND c.deleted_at IS NULL
+)
+`
+
+type IsBlockedInDirectConversationParams struct {
+	UserID         pgtype.UUID `json:"user_id"`
+	ConversationID pgtype.UUID `json:"conversation_id"`
+}
+
+func (q *Queries) IsBlockedInDirectConversation(ctx context.Context, arg IsBlockedInDirectConversationParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isBlockedInDirectConversation, arg.UserID, arg.ConversationID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const isMember = `-- name: IsMember :one
 SELECT EXISTS(
     SELECT 1 FROM participants
@@ -820,6 +2611,25 @@ func (q *Queries) IsMemberOwner(ctx context.Context, arg IsMemberOwnerParams) (b
 	return exists, err
 }
 
+const isMessageHidden = `-- name: IsMessageHidden :one
+SELECT EXISTS(
+    SELECT 1 FROM hidden_messages
+    WHERE message_id = $1 AND user_id = $2
+)
+`
+
+type IsMessageHiddenParams struct {
+	MessageID pgtype.UUID `json:"message_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) IsMessageHidden(ctx context.Context, arg IsMessageHiddenParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isMessageHidden, arg.MessageID, arg.UserID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const leaveConversationAtomic = `-- name: LeaveConversationAtomic :execrows
 UPDATE participants
 SET deleted_at = NOW(), updated_at = NOW()
@@ -841,6 +2651,89 @@ func (q *Queries) LeaveConversationAtomic(ctx context.Context, arg LeaveConversa
 	return result.RowsAffected(), nil
 }
 
+const lockConversation = `-- name: LockConversation :one
+SELECT id FROM conversations
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE
+`
+
+func (q *Queries) LockConversation(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, lockConversation, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const lockOpenPoll = `-- name: LockOpenPoll :one
+SELECT id FROM polls
+WHERE id = $1 AND closed_at IS NULL
+FOR UPDATE
+`
+
+func (q *Queries) LockOpenPoll(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, lockOpenPoll, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const markParticipantRead = `-- name: MarkParticipantRead :execrows
+UPDATE participants p
+SET last_read_message_id = m.id, last_read_at = m.created_at, updated_at = NOW()
+FROM messages m
+WHERE p.conversation_id = $1
+  AND p.user_id = $2
+  AND p.deleted_at IS NULL
+  AND m.id = $3
+  AND m.conversation_id = p.conversation_id
+  AND (p.last_read_at IS NULL OR m.created_at > p.last_read_at)
+`
+
+type MarkParticipantReadParams struct {
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	UserID         pgtype.UUID `json:"user_id"`
+	ID             pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) MarkParticipantRead(ctx context.Context, arg MarkParticipantReadParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markParticipantRead, arg.ConversationID, arg.UserID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const recordScheduledMessageFailure = `-- name: RecordScheduledMessageFailure :exec
+UPDATE scheduled_messages
+SET last_error = $2, failed_at = $3, updated_at = NOW()
+WHERE id = $1
+`
+
+type RecordScheduledMessageFailureParams struct {
+	ID        pgtype.UUID        `json:"id"`
+	LastError pgtype.Text        `json:"last_error"`
+	FailedAt  pgtype.Timestamptz `json:"failed_at"`
+}
+
+func (q *Queries) RecordScheduledMessageFailure(ctx context.Context, arg RecordScheduledMessageFailureParams) error {
+	_, err := q.db.Exec(ctx, recordScheduledMessageFailure, arg.ID, arg.LastError, arg.FailedAt)
+	return err
+}
+
+const removeMessageReaction = `-- name: RemoveMessageReaction :exec
+DELETE FROM message_reactions
+WHERE message_id = $1 AND user_id = $2 AND emoji = $3
+`
+
+type RemoveMessageReactionParams struct {
+	MessageID pgtype.UUID `json:"message_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+	Emoji     string      `json:"emoji"`
+}
+
+func (q *Queries) RemoveMessageReaction(ctx context.Context, arg RemoveMessageReactionParams) error {
+	_, err := q.db.Exec(ctx, removeMessageReaction, arg.MessageID, arg.UserID, arg.Emoji)
+	return err
+}
+
 const renameConversationAndReturn = `-- name: RenameConversationAndReturn :execrows
 UPDATE group_conversations
 SET name = $2, updated_at = NOW()
@@ -864,19 +2757,180 @@ func (q *Queries) RenameConversationAndReturn(ctx context.Context, arg RenameCon
 	return result.RowsAffected(), nil
 }
 
-const renameGroupConversation = `-- name: RenameGroupConversation :exec
-UPDATE group_conversations
-SET name = $2, updated_at = NOW()
-WHERE conversation_id = $1
+const renameGroupConversation = `-- name: RenameGroupConversation :exec
+UPDATE group_conversations
+SET name = $2, updated_at = NOW()
+WHERE conversation_id = $1
+`
+
+type RenameGroupConversationParams struct {
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	Name           string      `json:"name"`
+}
+
+func (q *Queries) RenameGroupConversation(ctx context.Context, arg RenameGroupConversationParams) error {
+	_, err := q.db.Exec(ctx, renameGroupConversation, arg.ConversationID, arg.Name)
+	return err
+}
+
+const revokeInviteLink = `-- name: RevokeInviteLink :execrows
+UPDATE invite_links
+SET revoked_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND conversation_id = $2 AND revoked_at IS NULL
+`
+
+type RevokeInviteLinkParams struct {
+	ID             pgtype.UUID `json:"id"`
+	ConversationID pgtype.UUID `json:"conversation_id"`
+}
+
+func (q *Queries) RevokeInviteLink(ctx context.Context, arg RevokeInviteLinkParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeInviteLink, arg.ID, arg.ConversationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const searchMessagesRaw = `-- name: SearchMessagesRaw :many
+WITH matches AS (
+    SELECT
+        m.id,
+        m.type,
+        m.created_at,
+        m.conversation_id,
+        m.content,
+        m.user_id,
+        m.parent_id,
+        q.query,
+        ts_rank(m.search_vector, q.query)::real AS rank
+    FROM messages m
+    CROSS JOIN websearch_to_tsquery('simple', $1::text) AS q(query)
+    JOIN conversations c ON c.id = m.conversation_id AND c.deleted_at IS NULL
+    JOIN participants p ON p.conversation_id = m.conversation_id
+        AND p.user_id = $2
+        AND p.deleted_at IS NULL
+    WHERE m.search_vector @@ q.query
+      AND m.deleted_at IS NULL
+      AND (m.expires_at IS NULL OR m.expires_at > NOW())
+      AND m.type = 0
+      AND ($3::uuid IS NULL OR m.conversation_id = $3::uuid)
+      AND NOT EXISTS (
+        SELECT 1 FROM hidden_messages hm
+        WHERE hm.message_id = m.id AND hm.user_id = $2
+      )
+)
+SELECT
+    id,
+    type,
+    created_at,
+    conversation_id,
+    user_id,
+    parent_id,
+    ts_headline('simple', content, query, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5')::text AS snippet,
+    rank
+FROM matches
+WHERE (
+    $4::real IS NULL
+    OR rank < $4::real
+    OR (rank = $4::real AND created_at < $5)
+    OR (
+      rank = $4::real
+      AND created_at = $5
+      AND id < $6
+    )
+)
+ORDER BY rank DESC, created_at DESC, id DESC
+LIMIT $7
+`
+
+type SearchMessagesRawParams struct {
+	Query           string             `json:"query"`
+	UserID          pgtype.UUID        `json:"user_id"`
+	ConversationID  pgtype.UUID        `json:"conversation_id"`
+	CursorRank      pgtype.Float4      `json:"cursor_rank"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.UUID        `json:"cursor_id"`
+	PageLimit       int32              `json:"page_limit"`
+}
+
+type SearchMessagesRawRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	Type           int32              `json:"type"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	ParentID       pgtype.UUID        `json:"parent_id"`
+	Snippet        string             `json:"snippet"`
+	Rank           float32            `json:"rank"`
+}
+
+func (q *Queries) SearchMessagesRaw(ctx context.Context, arg SearchMessagesRawParams) ([]SearchMessagesRawRow, error) {
+	rows, err := q.db.Query(ctx, searchMessagesRaw,
+		arg.Query,
+		arg.UserID,
+		arg.ConversationID,
+		arg.CursorRank,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchMessagesRawRow
+	for rows.Next() {
+		var i SearchMessagesRawRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.CreatedAt,
+			&i.ConversationID,
+			&i.UserID,
+			&i.ParentID,
+			&i.Snippet,
+			&i.Rank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const storeAttachment = `-- name: StoreAttachment :exec
+INSERT INTO attachments (id, message_id, conversation_id, user_id, file_name, content_type, size, storage_key, status, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
 `
 
-type RenameGroupConversationParams struct {
+type StoreAttachmentParams struct {
+	ID             pgtype.UUID `json:"id"`
+	MessageID      pgtype.UUID `json:"message_id"`
 	ConversationID pgtype.UUID `json:"conversation_id"`
-	Name           string      `json:"name"`
+	UserID         pgtype.UUID `json:"user_id"`
+	FileName       string      `json:"file_name"`
+	ContentType    string      `json:"content_type"`
+	Size           int64       `json:"size"`
+	StorageKey     string      `json:"storage_key"`
+	Status         string      `json:"status"`
 }
 
-func (q *Queries) RenameGroupConversation(ctx context.Context, arg RenameGroupConversationParams) error {
-	_, err := q.db.Exec(ctx, renameGroupConversation, arg.ConversationID, arg.Name)
+func (q *Queries) StoreAttachment(ctx context.Context, arg StoreAttachmentParams) error {
+	_, err := q.db.Exec(ctx, storeAttachment,
+		arg.ID,
+		arg.MessageID,
+		arg.ConversationID,
+		arg.UserID,
+		arg.FileName,
+		arg.ContentType,
+		arg.Size,
+		arg.StorageKey,
+		arg.Status,
+	)
 	return err
 }
 
@@ -899,8 +2953,8 @@ func (q *Queries) StoreConversation(ctx context.Context, arg StoreConversationPa
 
 const storeGroupConversation = `-- name: StoreGroupConversation :exec
 
-INSERT INTO group_conversations (id, name, avatar, conversation_id, owner_id)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO group_conversations (id, name, avatar, conversation_id, owner_id, visibility)
+VALUES ($1, $2, $3, $4, $5, $6)
 `
 
 type StoreGroupConversationParams struct {
@@ -909,6 +2963,7 @@ type StoreGroupConversationParams struct {
 	Avatar         pgtype.Text `json:"avatar"`
 	ConversationID pgtype.UUID `json:"conversation_id"`
 	OwnerID        pgtype.UUID `json:"owner_id"`
+	Visibility     string      `json:"visibility"`
 }
 
 // GroupConversation queries
@@ -919,10 +2974,61 @@ func (q *Queries) StoreGroupConversation(ctx context.Context, arg StoreGroupConv
 		arg.Avatar,
 		arg.ConversationID,
 		arg.OwnerID,
+		arg.Visibility,
 	)
 	return err
 }
 
+const storeInviteLink = `-- name: StoreInviteLink :exec
+INSERT INTO invite_links (id, conversation_id, creator_id, max_uses, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, NOW())
+`
+
+type StoreInviteLinkParams struct {
+	ID             pgtype.UUID        `json:"id"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	CreatorID      pgtype.UUID        `json:"creator_id"`
+	MaxUses        pgtype.Int4        `json:"max_uses"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) StoreInviteLink(ctx context.Context, arg StoreInviteLinkParams) error {
+	_, err := q.db.Exec(ctx, storeInviteLink,
+		arg.ID,
+		arg.ConversationID,
+		arg.CreatorID,
+		arg.MaxUses,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const storeJoinRequest = `-- name: StoreJoinRequest :execrows
+INSERT INTO join_requests (id, conversation_id, user_id, status)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (conversation_id, user_id) WHERE status = 'pending' DO NOTHING
+`
+
+type StoreJoinRequestParams struct {
+	ID             pgtype.UUID `json:"id"`
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	UserID         pgtype.UUID `json:"user_id"`
+	Status         string      `json:"status"`
+}
+
+func (q *Queries) StoreJoinRequest(ctx context.Context, arg StoreJoinRequestParams) (int64, error) {
+	result, err := q.db.Exec(ctx, storeJoinRequest,
+		arg.ID,
+		arg.ConversationID,
+		arg.UserID,
+		arg.Status,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const storeMessage = `-- name: StoreMessage :exec
 
 INSERT INTO messages (id, conversation_id, user_id, content, type, created_at)
@@ -951,14 +3057,18 @@ func (q *Queries) StoreMessage(ctx context.Context, arg StoreMessageParams) erro
 
 const storeMessageAndReturn = `-- name: StoreMessageAndReturn :one
 WITH new_message AS (
-    INSERT INTO messages (id, conversation_id, user_id, content, type, created_at)
-    VALUES ($1, $2, $3, $4, $5, NOW())
-    RETURNING id, type, created_at, conversation_id, content, user_id
+    INSERT INTO messages (id, conversation_id, user_id, content, type, parent_id, created_at, expires_at)
+    VALUES (
+        $1, $2, $3, $4, $5, $6, NOW(),
+        (SELECT NOW() + make_interval(secs => c.message_ttl_seconds) FROM conversations c WHERE c.id = $2)
+    )
+    RETURNING id, type, created_at, conversation_id, content, user_id, parent_id
 )
 SELECT
     nm.id, nm.type, nm.created_at, nm.conversation_id,
     nm.content as formatted_text,
-    u.id as user_id, u.name as user_name, u.avatar as user_avatar
+    u.id as user_id, u.name as user_name, u.avatar as user_avatar,
+    nm.parent_id
 FROM new_message nm
 JOIN users u ON u.id = nm.user_id
 WHERE u.deleted_at IS NULL
@@ -970,6 +3080,7 @@ type StoreMessageAndReturnParams struct {
 	UserID         pgtype.UUID `json:"user_id"`
 	Content        string      `json:"content"`
 	Type           int32       `json:"type"`
+	ParentID       pgtype.UUID `json:"parent_id"`
 }
 
 type StoreMessageAndReturnRow struct {
@@ -981,6 +3092,7 @@ type StoreMessageAndReturnRow struct {
 	UserID         pgtype.UUID        `json:"user_id"`
 	UserName       string             `json:"user_name"`
 	UserAvatar     pgtype.Text        `json:"user_avatar"`
+	ParentID       pgtype.UUID        `json:"parent_id"`
 }
 
 func (q *Queries) StoreMessageAndReturn(ctx context.Context, arg StoreMessageAndReturnParams) (StoreMessageAndReturnRow, error) {
@@ -990,6 +3102,7 @@ func (q *Queries) StoreMessageAndReturn(ctx context.Context, arg StoreMessageAnd
 		arg.UserID,
 		arg.Content,
 		arg.Type,
+		arg.ParentID,
 	)
 	var i StoreMessageAndReturnRow
 	err := row.Scan(
@@ -1001,25 +3114,86 @@ func (q *Queries) StoreMessageAndReturn(ctx context.Context, arg StoreMessageAnd
 		&i.UserID,
 		&i.UserName,
 		&i.UserAvatar,
+		&i.ParentID,
 	)
 	return i, err
 }
 
+const storeMessageForward = `-- name: StoreMessageForward :exec
+INSERT INTO message_forwards (message_id, original_message_id, original_user_id, original_conversation_id)
+VALUES ($1, $2, $3, $4)
+`
+
+type StoreMessageForwardParams struct {
+	MessageID              pgtype.UUID `json:"message_id"`
+	OriginalMessageID      pgtype.UUID `json:"original_message_id"`
+	OriginalUserID         pgtype.UUID `json:"original_user_id"`
+	OriginalConversationID pgtype.UUID `json:"original_conversation_id"`
+}
+
+func (q *Queries) StoreMessageForward(ctx context.Context, arg StoreMessageForwardParams) error {
+	_, err := q.db.Exec(ctx, storeMessageForward,
+		arg.MessageID,
+		arg.OriginalMessageID,
+		arg.OriginalUserID,
+		arg.OriginalConversationID,
+	)
+	return err
+}
+
+const storeMessageMentions = `-- name: StoreMessageMentions :exec
+INSERT INTO message_mentions (message_id, user_id)
+SELECT $1, unnest($2::uuid[])
+`
+
+type StoreMessageMentionsParams struct {
+	MessageID pgtype.UUID   `json:"message_id"`
+	UserIds   []pgtype.UUID `json:"user_ids"`
+}
+
+func (q *Queries) StoreMessageMentions(ctx context.Context, arg StoreMessageMentionsParams) error {
+	_, err := q.db.Exec(ctx, storeMessageMentions, arg.MessageID, arg.UserIds)
+	return err
+}
+
+const storeMessageRevision = `-- name: StoreMessageRevision :exec
+INSERT INTO message_revisions (id, message_id, content, created_at)
+SELECT $1, m.id, m.content, COALESCE(m.edited_at, m.created_at)
+FROM messages m
+WHERE m.id = $2 AND m.deleted_at IS NULL
+`
+
+type StoreMessageRevisionParams struct {
+	ID        pgtype.UUID `json:"id"`
+	MessageID pgtype.UUID `json:"message_id"`
+}
+
+func (q *Queries) StoreMessageRevision(ctx context.Context, arg StoreMessageRevisionParams) error {
+	_, err := q.db.Exec(ctx, storeMessageRevision, arg.ID, arg.MessageID)
+	return err
+}
+
 const storeParticipant = `-- name: StoreParticipant :exec
 
-INSERT INTO participants (id, conversation_id, user_id)
-VALUES ($1, $2, $3)
+INSERT INTO participants (id, conversation_id, user_id, role)
+VALUES ($1, $2, $3, $4)
 `
 
 type StoreParticipantParams struct {
 	ID             pgtype.UUID `json:"id"`
 	ConversationID pgtype.UUID `json:"conversation_id"`
 	UserID         pgtype.UUID `json:"user_id"`
+	Role           string      `json:"role"`
 }
 
 // Participant queries
 func (q *Queries) StoreParticipant(ctx context.Context, arg StoreParticipantParams) error {
-	_, err := q.db.Exec(ctx, storeParticipant, arg.ID, arg.ConversationID, arg.UserID)
+	_, err := q.db.Exec(ctx, storeParticipant,
+		arg.ID,
+		arg.ConversationID,
+		arg.UserID,
+		arg.Role,
+	)
 	return err
 }
 
@@ -1039,6 +3213,122 @@ func (q *Queries) StoreParticipantsBatch(ctx context.Context, arg StoreParticipa
 	return err
 }
 
+const storePinnedMessage = `-- name: StorePinnedMessage :execrows
+INSERT INTO pinned_messages (message_id, conversation_id, pinned_by, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT DO NOTHING
+`
+
+type StorePinnedMessageParams struct {
+	MessageID      pgtype.UUID        `json:"message_id"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	PinnedBy       pgtype.UUID        `json:"pinned_by"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) StorePinnedMessage(ctx context.Context, arg StorePinnedMessageParams) (int64, error) {
+	result, err := q.db.Exec(ctx, storePinnedMessage,
+		arg.MessageID,
+		arg.ConversationID,
+		arg.PinnedBy,
+		arg.CreatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const storePoll = `-- name: StorePoll :exec
+INSERT INTO polls (id, message_id, conversation_id, user_id, question, multiple_choice, anonymous, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+`
+
+type StorePollParams struct {
+	ID             pgtype.UUID `json:"id"`
+	MessageID      pgtype.UUID `json:"message_id"`
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	UserID         pgtype.UUID `json:"user_id"`
+	Question       string      `json:"question"`
+	MultipleChoice bool        `json:"multiple_choice"`
+	Anonymous      bool        `json:"anonymous"`
+}
+
+func (q *Queries) StorePoll(ctx context.Context, arg StorePollParams) error {
+	_, err := q.db.Exec(ctx, storePoll,
+		arg.ID,
+		arg.MessageID,
+		arg.ConversationID,
+		arg.UserID,
+		arg.Question,
+		arg.MultipleChoice,
+		arg.Anonymous,
+	)
+	return err
+}
+
+const storePollOption = `-- name: StorePollOption :exec
+INSERT INTO poll_options (id, poll_id, position, text)
+VALUES ($1, $2, $3, $4)
+`
+
+type StorePollOptionParams struct {
+	ID       pgtype.UUID `json:"id"`
+	PollID   pgtype.UUID `json:"poll_id"`
+	Position int32       `json:"position"`
+	Text     string      `json:"text"`
+}
+
+func (q *Queries) StorePollOption(ctx context.Context, arg StorePollOptionParams) error {
+	_, err := q.db.Exec(ctx, storePollOption,
+		arg.ID,
+		arg.PollID,
+		arg.Position,
+		arg.Text,
+	)
+	return err
+}
+
+const storePollVote = `-- name: StorePollVote :exec
+INSERT INTO poll_votes (poll_id, option_id, user_id, created_at)
+VALUES ($1, $2, $3, NOW())
+`
+
+type StorePollVoteParams struct {
+	PollID   pgtype.UUID `json:"poll_id"`
+	OptionID pgtype.UUID `json:"option_id"`
+	UserID   pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) StorePollVote(ctx context.Context, arg StorePollVoteParams) error {
+	_, err := q.db.Exec(ctx, storePollVote, arg.PollID, arg.OptionID, arg.UserID)
+	return err
+}
+
+const storeScheduledMessage = `-- name: StoreScheduledMessage :exec
+INSERT INTO scheduled_messages (id, conversation_id, user_id, content, scheduled_at, next_attempt_at)
+VALUES ($1, $2, $3, $4, $5, $5)
+`
+
+type StoreScheduledMessageParams struct {
+	ID             pgtype.UUID        `json:"id"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	Content        string             `json:"content"`
+	ScheduledAt    pgtype.Timestamptz `json:"scheduled_at"`
+}
+
+func (q *Queries) StoreScheduledMessage(ctx context.Context, arg StoreScheduledMessageParams) error {
+	_, err := q.db.Exec(ctx, storeScheduledMessage,
+		arg.ID,
+		arg.ConversationID,
+		arg.UserID,
+		arg.Content,
+		arg.ScheduledAt,
+	)
+	return err
+}
+
 const storeUser = `-- name: StoreUser :exec
 
 INSERT INTO users (id, avatar, name, password, refresh_token)
@@ -1065,6 +3355,71 @@ func (q *Queries) StoreUser(ctx context.Context, arg StoreUserParams) error {
 	return err
 }
 
+const unblockUser = `-- name: UnblockUser :execrows
+DELETE FROM user_blocks
+WHERE blocker_id = $1 AND blocked_id = $2
+`
+
+type UnblockUserParams struct {
+	BlockerID pgtype.UUID `json:"blocker_id"`
+	BlockedID pgtype.UUID `json:"blocked_id"`
+}
+
+func (q *Queries) UnblockUser(ctx context.Context, arg UnblockUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, unblockUser, arg.BlockerID, arg.BlockedID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateAttachmentProcessing = `-- name: UpdateAttachmentProcessing :exec
+UPDATE attachments
+SET
+    status = $2,
+    size = $3,
+    width = $4,
+    height = $5,
+    blurhash = $6,
+    thumbnail_key = $7,
+    thumbnail_content_type = $8,
+    thumbnail_width = $9,
+    thumbnail_height = $10,
+    storage_key = $11
+WHERE id = $1
+`
+
+type UpdateAttachmentProcessingParams struct {
+	ID                   pgtype.UUID `json:"id"`
+	Status               string      `json:"status"`
+	Size                 int64       `json:"size"`
+	Width                pgtype.Int4 `json:"width"`
+	Height               pgtype.Int4 `json:"height"`
+	Blurhash             pgtype.Text `json:"blurhash"`
+	ThumbnailKey         pgtype.Text `json:"thumbnail_key"`
+	ThumbnailContentType pgtype.Text `json:"thumbnail_content_type"`
+	ThumbnailWidth       pgtype.Int4 `json:"thumbnail_width"`
+	ThumbnailHeight      pgtype.Int4 `json:"thumbnail_height"`
+	StorageKey           string      `json:"storage_key"`
+}
+
+func (q *Queries) UpdateAttachmentProcessing(ctx context.Context, arg UpdateAttachmentProcessingParams) error {
+	_, err := q.db.Exec(ctx, updateAttachmentProcessing,
+		arg.ID,
+		arg.Status,
+		arg.Size,
+		arg.Width,
+		arg.Height,
+		arg.Blurhash,
+		arg.ThumbnailKey,
+		arg.ThumbnailContentType,
+		arg.ThumbnailWidth,
+		arg.ThumbnailHeight,
+		arg.StorageKey,
+	)
+	return err
+}
+
 const updateConversation = `-- name: UpdateConversation :exec
 UPDATE conversations
 SET type = $2, updated_at = NOW()
@@ -1081,6 +3436,25 @@ func (q *Queries) UpdateConversation(ctx context.Context, arg UpdateConversation
 	return err
 }
 
+const updateConversationMessageTTL = `-- name: UpdateConversationMessageTTL :execrows
+UPDATE conversations
+SET message_ttl_seconds = $2, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type UpdateConversationMessageTTLParams struct {
+	ID                pgtype.UUID `json:"id"`
+	MessageTtlSeconds pgtype.Int4 `json:"message_ttl_seconds"`
+}
+
+func (q *Queries) UpdateConversationMessageTTL(ctx context.Context, arg UpdateConversationMessageTTLParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateConversationMessageTTL, arg.ID, arg.MessageTtlSeconds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateGroupConversation = `-- name: UpdateGroupConversation :exec
 UPDATE group_conversations
 SET name = $2, avatar = $3, updated_at = NOW()
@@ -1098,6 +3472,120 @@ func (q *Queries) UpdateGroupConversation(ctx context.Context, arg UpdateGroupCo
 	return err
 }
 
+const updateGroupConversationOwner = `-- name: UpdateGroupConversationOwner :execrows
+UPDATE group_conversations
+SET owner_id = $2, updated_at = NOW()
+WHERE conversation_id = $1 AND deleted_at IS NULL
+`
+
+type UpdateGroupConversationOwnerParams struct {
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	OwnerID        pgtype.UUID `json:"owner_id"`
+}
+
+func (q *Queries) UpdateGroupConversationOwner(ctx context.Context, arg UpdateGroupConversationOwnerParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateGroupConversationOwner, arg.ConversationID, arg.OwnerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateGroupConversationVisibility = `-- name: UpdateGroupConversationVisibility :execrows
+UPDATE group_conversations
+SET visibility = $2, updated_at = NOW()
+WHERE conversation_id = $1 AND deleted_at IS NULL
+`
+
+type UpdateGroupConversationVisibilityParams struct {
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	Visibility     string      `json:"visibility"`
+}
+
+func (q *Queries) UpdateGroupConversationVisibility(ctx context.Context, arg UpdateGroupConversationVisibilityParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateGroupConversationVisibility, arg.ConversationID, arg.Visibility)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateParticipantNotificationPreferences = `-- name: UpdateParticipantNotificationPreferences :execrows
+UPDATE participants
+SET notification_level = $3, muted_until = $4, updated_at = NOW()
+WHERE conversation_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+`
+
+type UpdateParticipantNotificationPreferencesParams struct {
+	ConversationID    pgtype.UUID        `json:"conversation_id"`
+	UserID            pgtype.UUID        `json:"user_id"`
+	NotificationLevel string             `json:"notification_level"`
+	MutedUntil        pgtype.Timestamptz `json:"muted_until"`
+}
+
+func (q *Queries) UpdateParticipantNotificationPreferences(ctx context.Context, arg UpdateParticipantNotificationPreferencesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateParticipantNotificationPreferences,
+		arg.ConversationID,
+		arg.UserID,
+		arg.NotificationLevel,
+		arg.MutedUntil,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateParticipantRole = `-- name: UpdateParticipantRole :execrows
+UPDATE participants
+SET role = $3, updated_at = NOW()
+WHERE conversation_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+`
+
+type UpdateParticipantRoleParams struct {
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	UserID         pgtype.UUID `json:"user_id"`
+	Role           string      `json:"role"`
+}
+
+func (q *Queries) UpdateParticipantRole(ctx context.Context, arg UpdateParticipantRoleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateParticipantRole, arg.ConversationID, arg.UserID, arg.Role)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateScheduledMessage = `-- name: UpdateScheduledMessage :execrows
+UPDATE scheduled_messages
+SET content = $2,
+    scheduled_at = $3,
+    next_attempt_at = $3,
+    attempts = 0,
+    failed_at = NULL,
+    last_error = NULL,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateScheduledMessageParams struct {
+	ID          pgtype.UUID        `json:"id"`
+	Content     string             `json:"content"`
+	ScheduledAt pgtype.Timestamptz `json:"scheduled_at"`
+}
+
+func (q *Queries) UpdateScheduledMessage(ctx context.Context, arg UpdateScheduledMessageParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateScheduledMessage, arg.ID, arg.Content, arg.ScheduledAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateUser = `-- name: UpdateUser :exec
 UPDATE users
 SET avatar = $2, name = $3, password = $4, refresh_token = $5, updated_at = NOW()
@@ -1123,6 +3611,22 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
 	return err
 }
 
+const updateUserLastSeen = `-- name: UpdateUserLastSeen :exec
+UPDATE users
+SET last_seen_at = $2
+WHERE id = $1
+`
+
+type UpdateUserLastSeenParams struct {
+	ID         pgtype.UUID        `json:"id"`
+	LastSeenAt pgtype.Timestamptz `json:"last_seen_at"`
+}
+
+func (q *Queries) UpdateUserLastSeen(ctx context.Context, arg UpdateUserLastSeenParams) error {
+	_, err := q.db.Exec(ctx, updateUserLastSeen, arg.ID, arg.LastSeenAt)
+	return err
+}
+
 const updateUserRefreshToken = `-- name: UpdateUserRefreshToken :exec
 UPDATE users
 SET refresh_token = $2, updated_at = NOW()
@@ -1138,3 +3642,20 @@ func (q *Queries) UpdateUserRefreshToken(ctx context.Context, arg UpdateUserRefr
 	_, err := q.db.Exec(ctx, updateUserRefreshToken, arg.ID, arg.RefreshToken)
 	return err
 }
+
+const useInviteLink = `-- name: UseInviteLink :execrows
+UPDATE invite_links
+SET use_count = use_count + 1, updated_at = NOW()
+WHERE id = $1
+  AND revoked_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW())
+  AND (max_uses IS NULL OR use_count < max_uses)
+`
+
+func (q *Queries) UseInviteLink(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, useInviteLink, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"GitHub/go-chat/backend/internal/domain"
 	"GitHub/go-chat/backend/internal/infra/postgres/db"
@@ -42,6 +43,7 @@ func (r *groupConversationRepository) Store(ctx context.Context, conversation *d
 			Avatar:         pgtype.Text{String: conversation.Avatar, Valid: conversation.Avatar != ""},
 			ConversationID: uuidToPgtype(conversation.Conversation.ID),
 			OwnerID:        uuidToPgtype(conversation.Owner.UserID),
+			Visibility:     conversation.Visibility.String(),
 		}
 
 		if err := qtx.StoreGroupConversation(ctx, groupConversationParams); err != nil {
@@ -52,6 +54,7 @@ func (r *groupConversationRepository) Store(ctx context.Context, conversation *d
 			ID:             uuidToPgtype(conversation.Owner.ID),
 			ConversationID: uuidToPgtype(conversation.Owner.ConversationID),
 			UserID:         uuidToPgtype(conversation.Owner.UserID),
+			Role:           conversation.Owner.Role.String(),
 		}
 
 		if err := qtx.StoreParticipant(ctx, participantParams); err != nil {
@@ -101,6 +104,43 @@ func (r *groupConversationRepository) Rename(ctx context.Context, id uuid.UUID,
 	return nil
 }
 
+func (r *groupConversationRepository) SetVisibility(ctx context.Context, id uuid.UUID, visibility domain.ConversationVisibility) error {
+	rowsAffected, err := r.queries.UpdateGroupConversationVisibility(ctx, db.UpdateGroupConversationVisibilityParams{
+		ConversationID: uuidToPgtype(id),
+		Visibility:     visibility.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("update group conversation visibility error: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrorConversationNotFound
+	}
+
+	return nil
+}
+
+func (r *groupConversationRepository) SetMessageRetention(ctx context.Context, id uuid.UUID, retention domain.MessageRetention) error {
+	ttl := pgtype.Int4{}
+	if retention.Enabled() {
+		ttl = pgtype.Int4{Int32: int32(retention.TTL() / time.Second), Valid: true}
+	}
+
+	rowsAffected, err := r.queries.UpdateConversationMessageTTL(ctx, db.UpdateConversationMessageTTLParams{
+		ID:                uuidToPgtype(id),
+		MessageTtlSeconds: ttl,
+	})
+	if err != nil {
+		return fmt.Errorf("update conversation message ttl error: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrorConversationNotFound
+	}
+
+	return nil
+}
+
 func (r *groupConversationRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	if err := r.queries.DeleteConversation(ctx, uuidToPgtype(id)); err != nil {
 		return fmt.Errorf("delete conversation error: %w", err)
@@ -109,20 +149,92 @@ func (r *groupConversationRepository) Delete(ctx context.Context, id uuid.UUID)
 	return nil
 }
 
+func (r *groupConversationRepository) TransferOwnership(ctx context.Context, previousOwner *domain.Participant, newOwner *domain.Participant) error {
+	return r.withTx(ctx, func(tx pgx.Tx) error {
+		return transferOwnership(ctx, r.queries.WithTx(tx), previousOwner, newOwner)
+	})
+}
+
+func (r *groupConversationRepository) TransferOwnershipAndLeave(ctx context.Context, previousOwner *domain.Participant, newOwner *domain.Participant) error {
+	return r.withTx(ctx, func(tx pgx.Tx) error {
+		qtx := r.queries.WithTx(tx)
+
+		if err := transferOwnership(ctx, qtx, previousOwner, newOwner); err != nil {
+			return err
+		}
+
+		rowsAffected, err := qtx.LeaveConversationAtomic(ctx, db.LeaveConversationAtomicParams{
+			ConversationID: uuidToPgtype(previousOwner.ConversationID),
+			UserID:         uuidToPgtype(previousOwner.UserID),
+		})
+		if err != nil {
+			return fmt.Errorf("leave conversation error: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrorUserNotInConversation
+		}
+
+		return nil
+	})
+}
+
+func transferOwnership(ctx context.Context, qtx *db.Queries, previousOwner *domain.Participant, newOwner *domain.Participant) error {
+	ownerParams := db.UpdateGroupConversationOwnerParams{
+		ConversationID: uuidToPgtype(newOwner.ConversationID),
+		OwnerID:        uuidToPgtype(newOwner.UserID),
+	}
+
+	rowsAffected, err := qtx.UpdateGroupConversationOwner(ctx, ownerParams)
+	if err != nil {
+		return fmt.Errorf("update group conversation owner error: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrorConversationNotFound
+	}
+
+	for _, participant := range []*domain.Participant{previousOwner, newOwner} {
+		roleParams := db.UpdateParticipantRoleParams{
+			ConversationID: uuidToPgtype(participant.ConversationID),
+			UserID:         uuidToPgtype(participant.UserID),
+			Role:           participant.Role.String(),
+		}
+
+		rowsAffected, err := qtx.UpdateParticipantRole(ctx, roleParams)
+		if err != nil {
+			return fmt.Errorf("update participant role error: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrorUserNotInConversation
+		}
+	}
+
+	return nil
+}
+
 func (r *groupConversationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.GroupConversation, error) {
 	result, err := r.queries.GetGroupConversationWithOwner(ctx, uuidToPgtype(id))
 	if err != nil {
 		return nil, fmt.Errorf("get group conversation error: %w", err)
 	}
 
+	visibility, err := domain.NewConversationVisibility(result.Visibility)
+	if err != nil {
+		return nil, fmt.Errorf("group conversation visibility error: %w", err)
+	}
+
 	return &domain.GroupConversation{
-		ID:     pgtypeToUUID(result.ID),
-		Name:   result.Name,
-		Avatar: result.Avatar.String,
+		ID:         pgtypeToUUID(result.ID),
+		Name:       result.Name,
+		Avatar:     result.Avatar.String,
+		Visibility: visibility,
 		Owner: domain.Participant{
 			UserID:         pgtypeToUUID(result.OwnerUserID),
 			ID:             pgtypeToUUID(result.OwnerParticipantID),
 			ConversationID: pgtypeToUUID(result.OwnerConversationID),
+			Role:           domain.ParticipantRoleOwner,
 		},
 		Conversation: domain.Conversation{
 			ID:   pgtypeToUUID(result.ConversationID),
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/infra/postgres/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type inviteLinkRepository struct {
+	*repository
+}
+
+func NewInviteLinkRepository(pool *pgxpool.Pool) *inviteLinkRepository {
+	return &inviteLinkRepository{
+		repository: newRepository(pool, db.New(pool)),
+	}
+}
+
+func (r *inviteLinkRepository) Store(ctx context.Context, link *domain.InviteLink) error {
+	params := db.StoreInviteLinkParams{
+		ID:             uuidToPgtype(link.ID),
+		ConversationID: uuidToPgtype(link.ConversationID),
+		CreatorID:      uuidToPgtype(link.CreatorID),
+		MaxUses:        intPtrToPgtype(link.MaxUses),
+		ExpiresAt:      timePtrToPgtype(link.ExpiresAt),
+	}
+
+	if err := r.queries.StoreInviteLink(ctx, params); err != nil {
+		return fmt.Errorf("store invite link error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *inviteLinkRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.InviteLink, error) {
+	link, err := r.queries.GetInviteLinkByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrorInviteLinkNotFound
+		}
+		return nil, fmt.Errorf("get invite link error: %w", err)
+	}
+
+	return &domain.InviteLink{
+		ID:             pgtypeToUUID(link.ID),
+		ConversationID: pgtypeToUUID(link.ConversationID),
+		CreatorID:      pgtypeToUUID(link.CreatorID),
+		MaxUses:        pgtypeToIntPtr(link.MaxUses),
+		UseCount:       int(link.UseCount),
+		ExpiresAt:      pgtypeToTimePtr(link.ExpiresAt),
+		RevokedAt:      pgtypeToTimePtr(link.RevokedAt),
+		CreatedAt:      link.CreatedAt.Time,
+	}, nil
+}
+
+func (r *inviteLinkRepository) Revoke(ctx context.Context, conversationID uuid.UUID, id uuid.UUID) error {
+	rowsAffected, err := r.queries.RevokeInviteLink(ctx, db.RevokeInviteLinkParams{
+		ID:             uuidToPgtype(id),
+		ConversationID: uuidToPgtype(conversationID),
+	})
+	if err != nil {
+		return fmt.Errorf("revoke invite link error: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrorInviteLinkNotFound
+	}
+
+	return nil
+}
+
+func (r *inviteLinkRepository) Redeem(ctx context.Context, id uuid.UUID, participant *domain.Participant) error {
+	return r.withTx(ctx, func(tx pgx.Tx) error {
+		qtx := r.queries.WithTx(tx)
+
+		rowsAffected, err := qtx.UseInviteLink(ctx, uuidToPgtype(id))
+		if err != nil {
+			return fmt.Errorf("use invite link error: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrorInviteLinkExhausted
+		}
+
+		participantParams := db.StoreParticipantParams{
+			ID:             uuidToPgtype(participant.ID),
+			ConversationID: uuidToPgtype(participant.ConversationID),
+			UserID:         uuidToPgtype(participant.UserID),
+			Role:           participant.Role.String(),
+		}
+
+		if err := qtx.StoreParticipant(ctx, participantParams); err != nil {
+			return fmt.Errorf("store participant error: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/infra/postgres/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type joinRequestRepository struct {
+	*repository
+}
+
+func NewJoinRequestRepository(pool *pgxpool.Pool) *joinRequestRepository {
+	return &joinRequestRepository{
+		repository: newRepository(pool, db.New(pool)),
+	}
+}
+
+func (r *joinRequestRepository) Store(ctx context.Context, request *domain.JoinRequest) error {
+	rowsAffected, err := r.queries.StoreJoinRequest(ctx, db.StoreJoinRequestParams{
+		ID:             uuidToPgtype(request.ID),
+		ConversationID: uuidToPgtype(request.ConversationID),
+		UserID:         uuidToPgtype(request.UserID),
+		Status:         request.Status.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("store join request error: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrorJoinRequestPending
+	}
+
+	return nil
+}
+
+func (r *joinRequestRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.JoinRequest, error) {
+	request, err := r.queries.GetJoinRequestByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrorJoinRequestNotFound
+		}
+		return nil, fmt.Errorf("get join request error: %w", err)
+	}
+
+	status, err := domain.NewJoinRequestStatus(request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("join request status error: %w", err)
+	}
+
+	return &domain.JoinRequest{
+		ID:             pgtypeToUUID(request.ID),
+		ConversationID: pgtypeToUUID(request.ConversationID),
+		UserID:         pgtypeToUUID(request.UserID),
+		Status:         status,
+		DecidedBy:      pgtypeToUUIDPtr(request.DecidedBy),
+		CreatedAt:      request.CreatedAt.Time,
+	}, nil
+}
+
+func (r *joinRequestRepository) Approve(ctx context.Context, request *domain.JoinRequest, participant *domain.Participant) error {
+	return r.withTx(ctx, func(tx pgx.Tx) error {
+		qtx := r.queries.WithTx(tx)
+
+		if err := decideJoinRequest(ctx, qtx, request); err != nil {
+			return err
+		}
+
+		participantParams := db.StoreParticipantParams{
+			ID:             uuidToPgtype(participant.ID),
+			ConversationID: uuidToPgtype(participant.ConversationID),
+			UserID:         uuidToPgtype(participant.UserID),
+			Role:           participant.Role.String(),
+		}
+
+		if err := qtx.StoreParticipant(ctx, participantParams); err != nil {
+			return fmt.Errorf("store participant error: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *joinRequestRepository) Reject(ctx context.Context, request *domain.JoinRequest) error {
+	return decideJoinRequest(ctx, r.queries, request)
+}
+
+func decideJoinRequest(ctx context.Context, queries *db.Queries, request *domain.JoinRequest) error {
+	rowsAffected, err := queries.DecideJoinRequest(ctx, db.DecideJoinRequestParams{
+		ID:        uuidToPgtype(request.ID),
+		Status:    request.Status.String(),
+		DecidedBy: uuidPtrToPgtype(request.DecidedBy),
+	})
+	if err != nil {
+		return fmt.Errorf("decide join request error: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrorJoinRequestAlreadyDecided
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"GitHub/go-chat/backend/internal/domain"
@@ -9,6 +10,10 @@ import (
 	"GitHub/go-chat/backend/internal/presentation"
 	"GitHub/go-chat/backend/internal/readModel"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -23,17 +28,178 @@ func NewMessageRepository(pool *pgxpool.Pool) *messageRepository {
 }
 
 func (r *messageRepository) Send(ctx context.Context, message *domain.Message) (readModel.MessageDTO, error) {
-	params := db.StoreMessageAndReturnParams{
+	var msg db.StoreMessageAndReturnRow
+
+	err := r.withTx(ctx, func(tx pgx.Tx) error {
+		stored, err := storeMessage(ctx, r.queries.WithTx(tx), message)
+		if err != nil {
+			return err
+		}
+
+		msg = stored
+
+		return nil
+	})
+	if err != nil {
+		return readModel.MessageDTO{}, err
+	}
+
+	return toSentMessageDTO(ctx, r.queries, message, msg)
+}
+
+func storeMessage(ctx context.Context, qtx *db.Queries, message *domain.Message) (db.StoreMessageAndReturnRow, error) {
+	stored, err := qtx.StoreMessageAndReturn(ctx, db.StoreMessageAndReturnParams{
 		ID:             uuidToPgtype(message.ID),
 		ConversationID: uuidToPgtype(message.ConversationID),
 		UserID:         uuidToPgtype(message.UserID),
 		Content:        message.Content.String(),
 		Type:           int32(toMessageTypePersistence(message.Type)),
+		ParentID:       uuidPtrToPgtype(message.ParentID),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return stored, fmt.Errorf("store message error: %w", domain.ErrorMessageAlreadyExists)
+		}
+		return stored, fmt.Errorf("store message error: %w", err)
+	}
+
+	if attachment := message.Attachment(); attachment != nil {
+		if err := qtx.StoreAttachment(ctx, db.StoreAttachmentParams{
+			ID:             uuidToPgtype(attachment.ID),
+			MessageID:      uuidToPgtype(message.ID),
+			ConversationID: uuidToPgtype(attachment.ConversationID),
+			UserID:         uuidToPgtype(attachment.UserID),
+			FileName:       attachment.FileName,
+			ContentType:    attachment.ContentType,
+			Size:           attachment.Size,
+			StorageKey:     attachment.StorageKey,
+			Status:         attachment.Status.String(),
+		}); err != nil {
+			return stored, fmt.Errorf("store attachment error: %w", err)
+		}
+
+		if attachment.Thumbnail != nil {
+			if err := qtx.UpdateAttachmentProcessing(ctx, toAttachmentProcessingParams(attachment)); err != nil {
+				return stored, fmt.Errorf("store attachment processing error: %w", err)
+			}
+		}
+	}
+
+	if poll := message.Poll(); poll != nil {
+		if err := storePoll(ctx, qtx, poll); err != nil {
+			return stored, err
+		}
+	}
+
+	if message.ForwardedFrom != nil {
+		if err := qtx.StoreMessageForward(ctx, db.StoreMessageForwardParams{
+			MessageID:              uuidToPgtype(message.ID),
+			OriginalMessageID:      uuidToPgtype(message.ForwardedFrom.MessageID),
+			OriginalUserID:         uuidToPgtype(message.ForwardedFrom.UserID),
+			OriginalConversationID: uuidToPgtype(message.ForwardedFrom.ConversationID),
+		}); err != nil {
+			return stored, fmt.Errorf("store message forward error: %w", err)
+		}
+	}
+
+	if err := storeMentions(ctx, qtx, message); err != nil {
+		return stored, err
+	}
+
+	return stored, nil
+}
+
+func storeMentions(ctx context.Context, qtx *db.Queries, message *domain.Message) error {
+	if len(message.Mentions) == 0 {
+		return nil
+	}
+
+	userIDs := make([]pgtype.UUID, len(message.Mentions))
+	for i, userID := range message.Mentions {
+		userIDs[i] = uuidToPgtype(userID)
 	}
 
-	msg, err := r.queries.StoreMessageAndReturn(ctx, params)
+	if err := qtx.StoreMessageMentions(ctx, db.StoreMessageMentionsParams{
+		MessageID: uuidToPgtype(message.ID),
+		UserIds:   userIDs,
+	}); err != nil {
+		return fmt.Errorf("store message mentions error: %w", err)
+	}
+
+	return nil
+}
+
+func toSentMessageDTO(ctx context.Context, queries *db.Queries, message *domain.Message, msg db.StoreMessageAndReturnRow) (readModel.MessageDTO, error) {
+	formatter := presentation.NewMessageFormatter()
+	rawMessage := readModel.RawMessageDTO{
+		ID:             pgtypeToUUID(msg.ID),
+		Type:           uint8(msg.Type),
+		CreatedAt:      msg.CreatedAt.Time,
+		ConversationID: pgtypeToUUID(msg.ConversationID),
+		Content:        msg.FormattedText,
+		UserID:         pgtypeToUUID(msg.UserID),
+		UserName:       msg.UserName,
+		UserAvatar:     msg.UserAvatar.String,
+		ParentID:       pgtypeToUUIDPtr(msg.ParentID),
+	}
+
+	dto := formatter.FormatMessageDTO(rawMessage)
+	dto.Attachment = presentation.FormatAttachmentDTO(message.Attachment())
+	dto.Mentions = message.Mentions
+
+	if message.ForwardedFrom != nil {
+		forwards, err := getForwardsByMessageIDs(ctx, queries, []uuid.UUID{dto.ID})
+		if err != nil {
+			return readModel.MessageDTO{}, fmt.Errorf("get message forward error: %w", err)
+		}
+		dto.ForwardedFrom = forwards[dto.ID]
+	}
+
+	if message.Poll() != nil {
+		polls, err := getPollsByMessageIDs(ctx, queries, []uuid.UUID{dto.ID})
+		if err != nil {
+			return readModel.MessageDTO{}, fmt.Errorf("get poll error: %w", err)
+		}
+		dto.Poll = polls[dto.ID]
+	}
+
+	return dto, nil
+}
+
+func (r *messageRepository) Edit(ctx context.Context, message *domain.Message) (readModel.MessageDTO, error) {
+	var msg db.EditMessageAndReturnRow
+
+	err := r.withTx(ctx, func(tx pgx.Tx) error {
+		qtx := r.queries.WithTx(tx)
+
+		revisionParams := db.StoreMessageRevisionParams{
+			ID:        uuidToPgtype(uuid.New()),
+			MessageID: uuidToPgtype(message.ID),
+		}
+
+		if err := qtx.StoreMessageRevision(ctx, revisionParams); err != nil {
+			return fmt.Errorf("store message revision error: %w", err)
+		}
+
+		edited, err := qtx.EditMessageAndReturn(ctx, db.EditMessageAndReturnParams{
+			ID:      uuidToPgtype(message.ID),
+			Content: message.Content.String(),
+		})
+		if err != nil {
+			return fmt.Errorf("edit message error: %w", err)
+		}
+
+		msg = edited
+
+		if err := qtx.DeleteMessageMentions(ctx, uuidToPgtype(message.ID)); err != nil {
+			return fmt.Errorf("delete message mentions error: %w", err)
+		}
+
+		return storeMentions(ctx, qtx, message)
+	})
 	if err != nil {
-		return readModel.MessageDTO{}, fmt.Errorf("store message error: %w", err)
+		return readModel.MessageDTO{}, err
 	}
 
 	formatter := presentation.NewMessageFormatter()
@@ -46,7 +212,114 @@ func (r *messageRepository) Send(ctx context.Context, message *domain.Message) (
 		UserID:         pgtypeToUUID(msg.UserID),
 		UserName:       msg.UserName,
 		UserAvatar:     msg.UserAvatar.String,
+		EditedAt:       pgtypeToTimePtr(msg.EditedAt),
+		RevisionCount:  msg.RevisionCount,
+		ParentID:       pgtypeToUUIDPtr(msg.ParentID),
+	}
+
+	dto := formatter.FormatMessageDTO(rawMessage)
+	dto.Attachment = presentation.FormatAttachmentDTO(message.Attachment())
+	dto.Mentions = message.Mentions
+
+	return dto, nil
+}
+
+func (r *messageRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Message, error) {
+	msg, err := r.queries.GetMessageByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrorMessageNotFound
+		}
+		return nil, fmt.Errorf("get message error: %w", err)
+	}
+
+	message := &domain.Message{
+		ID:             pgtypeToUUID(msg.ID),
+		ConversationID: pgtypeToUUID(msg.ConversationID),
+		UserID:         pgtypeToUUID(msg.UserID),
+		Type:           MessageTypePersistenceToDomain(uint8(msg.Type)),
+		ParentID:       pgtypeToUUIDPtr(msg.ParentID),
+	}
+
+	mentions, err := r.queries.GetMentionsByMessageIDs(ctx, []pgtype.UUID{msg.ID})
+	if err != nil {
+		return nil, fmt.Errorf("get message mentions error: %w", err)
+	}
+	for _, mention := range mentions {
+		message.Mentions = append(message.Mentions, pgtypeToUUID(mention.UserID))
+	}
+
+	forward, err := r.queries.GetMessageForward(ctx, msg.ID)
+	switch {
+	case err == nil:
+		message.ForwardedFrom = &domain.ForwardedFrom{
+			MessageID:      pgtypeToUUID(forward.OriginalMessageID),
+			UserID:         pgtypeToUUID(forward.OriginalUserID),
+			ConversationID: pgtypeToUUID(forward.OriginalConversationID),
+		}
+	case !errors.Is(err, pgx.ErrNoRows):
+		return nil, fmt.Errorf("get message forward error: %w", err)
+	}
+
+	poll, err := getPollByMessageID(ctx, r.queries, msg.ID)
+	switch {
+	case err == nil:
+		message.Content = domain.NewPollMessageContent(*poll)
+		return message, nil
+	case !errors.Is(err, pgx.ErrNoRows):
+		return nil, fmt.Errorf("get poll error: %w", err)
+	}
+
+	attachment, err := r.queries.GetAttachmentByMessageID(ctx, msg.ID)
+	switch {
+	case err == nil:
+		message.Content = domain.RestoreAttachmentMessageContent(*toAttachmentDomain(attachment), msg.Content)
+	case errors.Is(err, pgx.ErrNoRows):
+		message.Content = domain.RestoreTextMessageContent(msg.Content)
+	default:
+		return nil, fmt.Errorf("get attachment error: %w", err)
+	}
+
+	return message, nil
+}
+
+func (r *messageRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	rowsAffected, err := r.queries.DeleteMessage(ctx, uuidToPgtype(id))
+	if err != nil {
+		return fmt.Errorf("delete message error: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrorMessageNotDeletable
+	}
+
+	return nil
+}
+
+func (r *messageRepository) DeleteExpired(ctx context.Context, limit int) ([]domain.ExpiredMessage, error) {
+	rows, err := r.queries.DeleteExpiredMessages(ctx, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("delete expired messages error: %w", err)
+	}
+
+	expired := make([]domain.ExpiredMessage, len(rows))
+	for i, row := range rows {
+		expired[i] = domain.ExpiredMessage{
+			ID:             pgtypeToUUID(row.ID),
+			ConversationID: pgtypeToUUID(row.ConversationID),
+		}
+	}
+
+	return expired, nil
+}
+
+func (r *messageRepository) Hide(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) error {
+	if err := r.queries.HideMessageForUser(ctx, db.HideMessageForUserParams{
+		MessageID: uuidToPgtype(messageID),
+		UserID:    uuidToPgtype(userID),
+	}); err != nil {
+		return fmt.Errorf("hide message error: %w", err)
 	}
 
-	return formatter.FormatMessageDTO(rawMessage), nil
+	return nil
 }
@@ -0,0 +1,6 @@
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_messages_conversation_created_id_all;
+DROP INDEX IF EXISTS idx_hidden_messages_user_id;
+DROP TABLE IF EXISTS hidden_messages;
+-- +goose StatementEnd
@@ -0,0 +1,5 @@
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_messages_parent_created_id;
+ALTER TABLE messages DROP COLUMN IF EXISTS parent_id;
+-- +goose StatementEnd
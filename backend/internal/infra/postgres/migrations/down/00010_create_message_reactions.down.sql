@@ -0,0 +1,5 @@
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_message_reactions_message_id;
+DROP TABLE IF EXISTS message_reactions;
+-- +goose StatementEnd
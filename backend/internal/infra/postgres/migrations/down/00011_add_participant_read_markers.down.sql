@@ -0,0 +1,5 @@
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE participants DROP COLUMN IF EXISTS last_read_at;
+ALTER TABLE participants DROP COLUMN IF EXISTS last_read_message_id;
+-- +goose StatementEnd
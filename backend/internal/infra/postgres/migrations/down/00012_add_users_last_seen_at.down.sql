@@ -0,0 +1,4 @@
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS last_seen_at;
+-- +goose StatementEnd
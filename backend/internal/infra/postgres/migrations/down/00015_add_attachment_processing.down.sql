@@ -0,0 +1,12 @@
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE attachments
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS blurhash,
+    DROP COLUMN IF EXISTS thumbnail_key,
+    DROP COLUMN IF EXISTS thumbnail_content_type,
+    DROP COLUMN IF EXISTS thumbnail_width,
+    DROP COLUMN IF EXISTS thumbnail_height;
+-- +goose StatementEnd
@@ -0,0 +1,4 @@
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE participants DROP COLUMN IF EXISTS role;
+-- +goose StatementEnd
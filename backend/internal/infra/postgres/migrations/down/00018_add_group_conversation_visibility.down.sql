@@ -0,0 +1,5 @@
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_group_conversations_public;
+ALTER TABLE group_conversations DROP COLUMN IF EXISTS visibility;
+-- +goose StatementEnd
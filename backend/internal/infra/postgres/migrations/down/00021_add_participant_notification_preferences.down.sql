@@ -0,0 +1,5 @@
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE participants DROP COLUMN IF EXISTS muted_until;
+ALTER TABLE participants DROP COLUMN IF EXISTS notification_level;
+-- +goose StatementEnd
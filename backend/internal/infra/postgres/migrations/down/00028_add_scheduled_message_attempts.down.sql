@@ -0,0 +1,12 @@
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_scheduled_messages_next_attempt_at;
+
+CREATE INDEX idx_scheduled_messages_scheduled_at ON scheduled_messages(scheduled_at);
+
+ALTER TABLE scheduled_messages
+    DROP COLUMN IF EXISTS last_error,
+    DROP COLUMN IF EXISTS failed_at,
+    DROP COLUMN IF EXISTS next_attempt_at,
+    DROP COLUMN IF EXISTS attempts;
+-- +goose StatementEnd
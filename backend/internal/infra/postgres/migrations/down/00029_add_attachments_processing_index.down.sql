@@ -0,0 +1,4 @@
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_attachments_processing_created_at;
+-- +goose StatementEnd
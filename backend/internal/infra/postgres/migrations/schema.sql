@@ -69,3 +69,206 @@ CREATE INDEX idx_messages_conversation_id ON messages(conversation_id) WHERE del
 CREATE INDEX idx_messages_user_id ON messages(user_id) WHERE deleted_at IS NULL;
 CREATE INDEX idx_messages_created_at ON messages(created_at) WHERE deleted_at IS NULL;
 CREATE INDEX idx_messages_deleted_at ON messages(deleted_at);
+
+ALTER TABLE messages ADD COLUMN edited_at TIMESTAMPTZ;
+
+CREATE TABLE message_revisions (
+    id UUID PRIMARY KEY,
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_message_revisions_message_id ON message_revisions(message_id, created_at DESC);
+
+CREATE TABLE hidden_messages (
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (message_id, user_id)
+);
+
+CREATE INDEX idx_hidden_messages_user_id ON hidden_messages(user_id);
+CREATE INDEX idx_messages_conversation_created_id_all ON messages(conversation_id, created_at DESC, id DESC);
+
+ALTER TABLE messages ADD COLUMN parent_id UUID REFERENCES messages(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_messages_parent_created_id ON messages(parent_id, created_at DESC, id DESC) WHERE parent_id IS NOT NULL;
+
+CREATE TABLE message_reactions (
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    emoji TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (message_id, user_id, emoji)
+);
+
+CREATE INDEX idx_message_reactions_message_id ON message_reactions(message_id, created_at);
+
+ALTER TABLE participants ADD COLUMN last_read_message_id UUID REFERENCES messages(id) ON DELETE SET NULL;
+ALTER TABLE participants ADD COLUMN last_read_at TIMESTAMPTZ;
+
+ALTER TABLE users ADD COLUMN last_seen_at TIMESTAMPTZ;
+
+ALTER TABLE messages ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', content)) STORED;
+
+CREATE INDEX idx_messages_search_vector ON messages USING GIN (search_vector) WHERE deleted_at IS NULL;
+
+CREATE TABLE attachments (
+    id UUID PRIMARY KEY,
+    message_id UUID NOT NULL UNIQUE REFERENCES messages(id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_attachments_conversation_id ON attachments(conversation_id);
+
+ALTER TABLE attachments
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'ready',
+    ADD COLUMN width INTEGER,
+    ADD COLUMN height INTEGER,
+    ADD COLUMN blurhash TEXT,
+    ADD COLUMN thumbnail_key TEXT,
+    ADD COLUMN thumbnail_content_type TEXT,
+    ADD COLUMN thumbnail_width INTEGER,
+    ADD COLUMN thumbnail_height INTEGER;
+
+ALTER TABLE participants ADD COLUMN role TEXT NOT NULL DEFAULT 'member';
+
+CREATE TABLE invite_links (
+    id UUID PRIMARY KEY,
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    creator_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    max_uses INTEGER,
+    use_count INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_invite_links_conversation_id ON invite_links(conversation_id) WHERE revoked_at IS NULL;
+
+ALTER TABLE group_conversations ADD COLUMN visibility TEXT NOT NULL DEFAULT 'public';
+
+CREATE INDEX idx_group_conversations_public ON group_conversations(created_at DESC) WHERE visibility = 'public' AND deleted_at IS NULL;
+
+CREATE TABLE join_requests (
+    id UUID PRIMARY KEY,
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'pending',
+    decided_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    decided_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_join_requests_pending ON join_requests(conversation_id, user_id) WHERE status = 'pending';
+
+CREATE TABLE user_blocks (
+    blocker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (blocker_id, blocked_id)
+);
+
+CREATE INDEX idx_user_blocks_blocked_id ON user_blocks(blocked_id);
+
+ALTER TABLE participants ADD COLUMN notification_level TEXT NOT NULL DEFAULT 'all';
+ALTER TABLE participants ADD COLUMN muted_until TIMESTAMPTZ;
+
+CREATE TABLE message_mentions (
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (message_id, user_id)
+);
+
+CREATE INDEX idx_message_mentions_user_id ON message_mentions(user_id, created_at DESC);
+
+CREATE TABLE pinned_messages (
+    message_id UUID PRIMARY KEY REFERENCES messages(id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    pinned_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_pinned_messages_conversation_id ON pinned_messages(conversation_id, created_at DESC);
+
+CREATE TABLE message_forwards (
+    message_id UUID PRIMARY KEY REFERENCES messages(id) ON DELETE CASCADE,
+    original_message_id UUID REFERENCES messages(id) ON DELETE SET NULL,
+    original_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    original_conversation_id UUID REFERENCES conversations(id) ON DELETE SET NULL
+);
+
+CREATE TABLE scheduled_messages (
+    id UUID PRIMARY KEY,
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_scheduled_messages_scheduled_at ON scheduled_messages(scheduled_at);
+CREATE INDEX idx_scheduled_messages_user_id ON scheduled_messages(user_id, scheduled_at);
+
+ALTER TABLE conversations ADD COLUMN message_ttl_seconds INTEGER;
+
+ALTER TABLE messages ADD COLUMN expires_at TIMESTAMPTZ;
+
+CREATE INDEX idx_messages_expires_at ON messages(expires_at) WHERE expires_at IS NOT NULL AND deleted_at IS NULL;
+
+CREATE TABLE polls (
+    id UUID PRIMARY KEY,
+    message_id UUID NOT NULL UNIQUE REFERENCES messages(id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    question TEXT NOT NULL,
+    multiple_choice BOOLEAN NOT NULL DEFAULT FALSE,
+    anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE poll_options (
+    id UUID PRIMARY KEY,
+    poll_id UUID NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    UNIQUE (poll_id, position)
+);
+
+CREATE TABLE poll_votes (
+    poll_id UUID NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    option_id UUID NOT NULL REFERENCES poll_options(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (option_id, user_id)
+);
+
+CREATE INDEX idx_poll_votes_poll_id ON poll_votes(poll_id, user_id);
+
+ALTER TABLE scheduled_messages
+    ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN next_attempt_at TIMESTAMPTZ,
+    ADD COLUMN failed_at TIMESTAMPTZ,
+    ADD COLUMN last_error TEXT;
+
+UPDATE scheduled_messages SET next_attempt_at = scheduled_at;
+
+ALTER TABLE scheduled_messages ALTER COLUMN next_attempt_at SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_scheduled_messages_scheduled_at;
+
+CREATE INDEX idx_scheduled_messages_next_attempt_at ON scheduled_messages(next_attempt_at) WHERE failed_at IS NULL;
+
+CREATE INDEX idx_attachments_processing_created_at ON attachments(created_at) WHERE status = 'processing';
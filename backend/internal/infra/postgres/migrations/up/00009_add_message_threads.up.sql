@@ -0,0 +1,6 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE messages ADD COLUMN parent_id UUID REFERENCES messages(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_messages_parent_created_id ON messages(parent_id, created_at DESC, id DESC) WHERE parent_id IS NOT NULL;
+-- +goose StatementEnd
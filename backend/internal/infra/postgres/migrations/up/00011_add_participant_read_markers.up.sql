@@ -0,0 +1,5 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE participants ADD COLUMN last_read_message_id UUID REFERENCES messages(id) ON DELETE SET NULL;
+ALTER TABLE participants ADD COLUMN last_read_at TIMESTAMPTZ;
+-- +goose StatementEnd
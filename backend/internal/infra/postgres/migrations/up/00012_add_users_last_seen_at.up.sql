@@ -0,0 +1,4 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN last_seen_at TIMESTAMPTZ;
+-- +goose StatementEnd
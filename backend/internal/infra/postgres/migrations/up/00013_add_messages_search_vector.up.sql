@@ -0,0 +1,6 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE messages ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', content)) STORED;
+
+CREATE INDEX idx_messages_search_vector ON messages USING GIN (search_vector) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
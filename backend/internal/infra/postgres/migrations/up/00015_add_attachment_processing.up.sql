@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE attachments
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'ready',
+    ADD COLUMN width INTEGER,
+    ADD COLUMN height INTEGER,
+    ADD COLUMN blurhash TEXT,
+    ADD COLUMN thumbnail_key TEXT,
+    ADD COLUMN thumbnail_content_type TEXT,
+    ADD COLUMN thumbnail_width INTEGER,
+    ADD COLUMN thumbnail_height INTEGER;
+-- +goose StatementEnd
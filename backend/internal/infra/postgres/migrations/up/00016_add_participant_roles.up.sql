@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE participants ADD COLUMN role TEXT NOT NULL DEFAULT 'member';
+
+UPDATE participants p
+SET role = 'owner'
+FROM group_conversations gc
+WHERE gc.conversation_id = p.conversation_id
+  AND gc.owner_id = p.user_id;
+-- +goose StatementEnd
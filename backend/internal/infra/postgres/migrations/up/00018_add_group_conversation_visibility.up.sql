@@ -0,0 +1,6 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE group_conversations ADD COLUMN visibility TEXT NOT NULL DEFAULT 'public';
+
+CREATE INDEX idx_group_conversations_public ON group_conversations(created_at DESC) WHERE visibility = 'public' AND deleted_at IS NULL;
+-- +goose StatementEnd
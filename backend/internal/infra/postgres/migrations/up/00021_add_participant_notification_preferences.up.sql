@@ -0,0 +1,5 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE participants ADD COLUMN notification_level TEXT NOT NULL DEFAULT 'all';
+ALTER TABLE participants ADD COLUMN muted_until TIMESTAMPTZ;
+-- +goose StatementEnd
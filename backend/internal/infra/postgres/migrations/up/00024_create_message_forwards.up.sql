@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE message_forwards (
+    message_id UUID PRIMARY KEY REFERENCES messages(id) ON DELETE CASCADE,
+    original_message_id UUID REFERENCES messages(id) ON DELETE SET NULL,
+    original_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    original_conversation_id UUID REFERENCES conversations(id) ON DELETE SET NULL
+);
+-- +goose StatementEnd
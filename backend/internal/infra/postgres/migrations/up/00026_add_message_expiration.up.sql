@@ -0,0 +1,8 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE conversations ADD COLUMN message_ttl_seconds INTEGER;
+
+ALTER TABLE messages ADD COLUMN expires_at TIMESTAMPTZ;
+
+CREATE INDEX idx_messages_expires_at ON messages(expires_at) WHERE expires_at IS NOT NULL AND deleted_at IS NULL;
+-- +goose StatementEnd
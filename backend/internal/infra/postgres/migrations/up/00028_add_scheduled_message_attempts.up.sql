@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE scheduled_messages
+    ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN next_attempt_at TIMESTAMPTZ,
+    ADD COLUMN failed_at TIMESTAMPTZ,
+    ADD COLUMN last_error TEXT;
+
+UPDATE scheduled_messages SET next_attempt_at = scheduled_at;
+
+ALTER TABLE scheduled_messages ALTER COLUMN next_attempt_at SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_scheduled_messages_scheduled_at;
+
+CREATE INDEX idx_scheduled_messages_next_attempt_at ON scheduled_messages(next_attempt_at) WHERE failed_at IS NULL;
+-- +goose StatementEnd
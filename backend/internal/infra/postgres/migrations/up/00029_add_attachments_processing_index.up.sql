@@ -0,0 +1,4 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX idx_attachments_processing_created_at ON attachments(created_at) WHERE status = 'processing';
+-- +goose StatementEnd
@@ -26,6 +26,7 @@ func (r *participantRepository) Store(ctx context.Context, participant *domain.P
 		ID:             uuidToPgtype(participant.ID),
 		ConversationID: uuidToPgtype(participant.ConversationID),
 		UserID:         uuidToPgtype(participant.UserID),
+		Role:           participant.Role.String(),
 	}
 
 	if err := r.queries.StoreParticipant(ctx, params); err != nil {
@@ -54,10 +55,22 @@ func (r *participantRepository) GetByConversationIDAndUserID(ctx context.Context
 		return nil, fmt.Errorf("get participant error: %w", err)
 	}
 
+	role, err := domain.NewParticipantRole(participant.Role)
+	if err != nil {
+		return nil, fmt.Errorf("participant role error: %w", err)
+	}
+
+	preferences, err := domain.NewNotificationPreferences(participant.NotificationLevel, pgtypeToTimePtr(participant.MutedUntil))
+	if err != nil {
+		return nil, fmt.Errorf("notification preferences error: %w", err)
+	}
+
 	return &domain.Participant{
-		ID:             pgtypeToUUID(participant.ID),
-		ConversationID: pgtypeToUUID(participant.ConversationID),
-		UserID:         pgtypeToUUID(participant.UserID),
+		ID:                      pgtypeToUUID(participant.ID),
+		ConversationID:          pgtypeToUUID(participant.ConversationID),
+		UserID:                  pgtypeToUUID(participant.UserID),
+		Role:                    role,
+		NotificationPreferences: preferences,
 	}, nil
 }
 
@@ -89,3 +102,69 @@ func (r *participantRepository) GetConversationIDsByUserID(ctx context.Context,
 
 	return ids, nil
 }
+
+func (r *participantRepository) MarkRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, messageID uuid.UUID) (bool, error) {
+	rowsAffected, err := r.queries.MarkParticipantRead(ctx, db.MarkParticipantReadParams{
+		ConversationID: uuidToPgtype(conversationID),
+		UserID:         uuidToPgtype(userID),
+		ID:             uuidToPgtype(messageID),
+	})
+	if err != nil {
+		return false, fmt.Errorf("mark read error: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *participantRepository) UpdateRole(ctx context.Context, participant *domain.Participant) error {
+	rowsAffected, err := r.queries.UpdateParticipantRole(ctx, db.UpdateParticipantRoleParams{
+		ConversationID: uuidToPgtype(participant.ConversationID),
+		UserID:         uuidToPgtype(participant.UserID),
+		Role:           participant.Role.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("update participant role error: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrorUserNotInConversation
+	}
+
+	return nil
+}
+
+func (r *participantRepository) UpdateNotificationPreferences(ctx context.Context, participant *domain.Participant) error {
+	rowsAffected, err := r.queries.UpdateParticipantNotificationPreferences(ctx, db.UpdateParticipantNotificationPreferencesParams{
+		ConversationID:    uuidToPgtype(participant.ConversationID),
+		UserID:            uuidToPgtype(participant.UserID),
+		NotificationLevel: participant.NotificationPreferences.Level.String(),
+		MutedUntil:        timePtrToPgtype(participant.NotificationPreferences.MutedUntil),
+	})
+	if err != nil {
+		return fmt.Errorf("update notification preferences error: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrorUserNotInConversation
+	}
+
+	return nil
+}
+
+func (r *participantRepository) GetNotificationPreferencesByUserID(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]domain.NotificationPreferences, error) {
+	rows, err := r.queries.GetNotificationPreferencesByUserID(ctx, uuidToPgtype(userID))
+	if err != nil {
+		return nil, fmt.Errorf("get notification preferences error: %w", err)
+	}
+
+	preferences := make(map[uuid.UUID]domain.NotificationPreferences, len(rows))
+	for _, row := range rows {
+		conversationPreferences, err := domain.NewNotificationPreferences(row.NotificationLevel, pgtypeToTimePtr(row.MutedUntil))
+		if err != nil {
+			return nil, fmt.Errorf("notification preferences error: %w", err)
+		}
+		preferences[pgtypeToUUID(row.ConversationID)] = conversationPreferences
+	}
+
+	return preferences, nil
+}
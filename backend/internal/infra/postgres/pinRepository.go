@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/infra/postgres/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pinRepository struct {
+	*repository
+}
+
+func NewPinRepository(pool *pgxpool.Pool) *pinRepository {
+	return &pinRepository{
+		repository: newRepository(pool, db.New(pool)),
+	}
+}
+
+// Store locks the conversation row before counting, so concurrent pins are
+// serialized and the limit cannot be overshot.
+func (r *pinRepository) Store(ctx context.Context, pin *domain.Pin, limit int) error {
+	return r.withTx(ctx, func(tx pgx.Tx) error {
+		qtx := r.queries.WithTx(tx)
+
+		if _, err := qtx.LockConversation(ctx, uuidToPgtype(pin.ConversationID)); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrorConversationNotFound
+			}
+			return fmt.Errorf("lock conversation error: %w", err)
+		}
+
+		count, err := qtx.CountPinnedMessages(ctx, uuidToPgtype(pin.ConversationID))
+		if err != nil {
+			return fmt.Errorf("count pins error: %w", err)
+		}
+
+		if count >= int64(limit) {
+			return domain.ErrorPinLimitReached
+		}
+
+		rowsAffected, err := qtx.StorePinnedMessage(ctx, db.StorePinnedMessageParams{
+			MessageID:      uuidToPgtype(pin.MessageID),
+			ConversationID: uuidToPgtype(pin.ConversationID),
+			PinnedBy:       uuidToPgtype(pin.PinnedBy),
+			CreatedAt:      pgtype.Timestamptz{Time: pin.CreatedAt, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("store pin error: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrorMessageAlreadyPinned
+		}
+
+		return nil
+	})
+}
+
+func (r *pinRepository) Delete(ctx context.Context, conversationID uuid.UUID, messageID uuid.UUID) error {
+	rowsAffected, err := r.queries.DeletePinnedMessage(ctx, db.DeletePinnedMessageParams{
+		ConversationID: uuidToPgtype(conversationID),
+		MessageID:      uuidToPgtype(messageID),
+	})
+	if err != nil {
+		return fmt.Errorf("delete pin error: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrorMessageNotPinned
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/infra/postgres/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pollRepository struct {
+	*repository
+}
+
+func NewPollRepository(pool *pgxpool.Pool) *pollRepository {
+	return &pollRepository{
+		repository: newRepository(pool, db.New(pool)),
+	}
+}
+
+func (r *pollRepository) Vote(ctx context.Context, vote *domain.PollVote) error {
+	return r.withTx(ctx, func(tx pgx.Tx) error {
+		qtx := r.queries.WithTx(tx)
+
+		if _, err := qtx.LockOpenPoll(ctx, uuidToPgtype(vote.PollID)); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrorPollClosed
+			}
+			return fmt.Errorf("lock poll error: %w", err)
+		}
+
+		if err := qtx.DeletePollVotes(ctx, db.DeletePollVotesParams{
+			PollID: uuidToPgtype(vote.PollID),
+			UserID: uuidToPgtype(vote.UserID),
+		}); err != nil {
+			return fmt.Errorf("delete poll votes error: %w", err)
+		}
+
+		for _, optionID := range vote.OptionIDs {
+			if err := qtx.StorePollVote(ctx, db.StorePollVoteParams{
+				PollID:   uuidToPgtype(vote.PollID),
+				OptionID: uuidToPgtype(optionID),
+				UserID:   uuidToPgtype(vote.UserID),
+			}); err != nil {
+				return fmt.Errorf("store poll vote error: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *pollRepository) Close(ctx context.Context, poll *domain.Poll) error {
+	rowsAffected, err := r.queries.ClosePoll(ctx, db.ClosePollParams{
+		ID:       uuidToPgtype(poll.ID),
+		ClosedAt: pgtype.Timestamptz{Time: *poll.ClosedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("close poll error: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrorPollClosed
+	}
+
+	return nil
+}
+
+func storePoll(ctx context.Context, queries *db.Queries, poll *domain.Poll) error {
+	if err := queries.StorePoll(ctx, db.StorePollParams{
+		ID:             uuidToPgtype(poll.ID),
+		MessageID:      uuidToPgtype(poll.MessageID),
+		ConversationID: uuidToPgtype(poll.ConversationID),
+		UserID:         uuidToPgtype(poll.UserID),
+		Question:       poll.Question,
+		MultipleChoice: poll.MultipleChoice,
+		Anonymous:      poll.Anonymous,
+	}); err != nil {
+		return fmt.Errorf("store poll error: %w", err)
+	}
+
+	for i, option := range poll.Options {
+		if err := queries.StorePollOption(ctx, db.StorePollOptionParams{
+			ID:       uuidToPgtype(option.ID),
+			PollID:   uuidToPgtype(poll.ID),
+			Position: int32(i),
+			Text:     option.Text,
+		}); err != nil {
+			return fmt.Errorf("store poll option error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func getPollByMessageID(ctx context.Context, queries *db.Queries, messageID pgtype.UUID) (*domain.Poll, error) {
+	row, err := queries.GetPollByMessageID(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := queries.GetPollOptions(ctx, row.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get poll options error: %w", err)
+	}
+
+	poll := &domain.Poll{
+		ID:             pgtypeToUUID(row.ID),
+		MessageID:      pgtypeToUUID(row.MessageID),
+		ConversationID: pgtypeToUUID(row.ConversationID),
+		UserID:         pgtypeToUUID(row.UserID),
+		Question:       row.Question,
+		Options:        make([]domain.PollOption, len(options)),
+		MultipleChoice: row.MultipleChoice,
+		Anonymous:      row.Anonymous,
+		ClosedAt:       pgtypeToTimePtr(row.ClosedAt),
+	}
+
+	for i, option := range options {
+		poll.Options[i] = domain.PollOption{
+			ID:   pgtypeToUUID(option.ID),
+			Text: option.Text,
+		}
+	}
+
+	return poll, nil
+}
@@ -24,6 +24,11 @@ UPDATE users
 SET refresh_token = $2, updated_at = NOW()
 WHERE id = $1;
 
+-- name: UpdateUserLastSeen :exec
+UPDATE users
+SET last_seen_at = $2
+WHERE id = $1;
+
 -- Conversation queries
 
 -- name: StoreConversation :exec
@@ -40,11 +45,16 @@ UPDATE conversations
 SET deleted_at = NOW(), updated_at = NOW()
 WHERE id = $1;
 
+-- name: UpdateConversationMessageTTL :execrows
+UPDATE conversations
+SET message_ttl_seconds = $2, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
 -- GroupConversation queries
 
 -- name: StoreGroupConversation :exec
-INSERT INTO group_conversations (id, name, avatar, conversation_id, owner_id)
-VALUES ($1, $2, $3, $4, $5);
+INSERT INTO group_conversations (id, name, avatar, conversation_id, owner_id, visibility)
+VALUES ($1, $2, $3, $4, $5, $6);
 
 -- name: UpdateGroupConversation :exec
 UPDATE group_conversations
@@ -56,6 +66,16 @@ UPDATE group_conversations
 SET name = $2, updated_at = NOW()
 WHERE conversation_id = $1;
 
+-- name: UpdateGroupConversationVisibility :execrows
+UPDATE group_conversations
+SET visibility = $2, updated_at = NOW()
+WHERE conversation_id = $1 AND deleted_at IS NULL;
+
+-- name: UpdateGroupConversationOwner :execrows
+UPDATE group_conversations
+SET owner_id = $2, updated_at = NOW()
+WHERE conversation_id = $1 AND deleted_at IS NULL;
+
 -- name: GetGroupConversationWithOwner :one
 SELECT
     gc.id,
@@ -63,6 +83,7 @@ SELECT
     gc.avatar,
     gc.conversation_id,
     gc.owner_id,
+    gc.visibility,
     c.type as conversation_type,
     p.id as owner_participant_id,
     p.user_id as owner_user_id,
@@ -79,8 +100,8 @@ LIMIT 1;
 -- Participant queries
 
 -- name: StoreParticipant :exec
-INSERT INTO participants (id, conversation_id, user_id)
-VALUES ($1, $2, $3);
+INSERT INTO participants (id, conversation_id, user_id, role)
+VALUES ($1, $2, $3, $4);
 
 -- name: StoreParticipantsBatch :exec
 INSERT INTO participants (id, conversation_id, user_id, created_at)
@@ -96,6 +117,17 @@ SELECT * FROM participants
 WHERE conversation_id = $1 AND user_id = $2 AND deleted_at IS NULL
 LIMIT 1;
 
+-- name: MarkParticipantRead :execrows
+UPDATE participants p
+SET last_read_message_id = m.id, last_read_at = m.created_at, updated_at = NOW()
+FROM messages m
+WHERE p.conversation_id = $1
+  AND p.user_id = $2
+  AND p.deleted_at IS NULL
+  AND m.id = $3
+  AND m.conversation_id = p.conversation_id
+  AND (p.last_read_at IS NULL OR m.created_at > p.last_read_at);
+
 -- name: GetParticipantsIDsByConversationID :many
 SELECT user_id
 FROM participants
@@ -116,6 +148,212 @@ GROUP BY c.id;
 INSERT INTO messages (id, conversation_id, user_id, content, type, created_at)
 VALUES ($1, $2, $3, $4, $5, NOW());
 
+-- name: GetMessageByID :one
+SELECT id, conversation_id, user_id, content, type, parent_id
+FROM messages
+WHERE id = $1 AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+LIMIT 1;
+
+-- name: StoreMessageRevision :exec
+INSERT INTO message_revisions (id, message_id, content, created_at)
+SELECT $1, m.id, m.content, COALESCE(m.edited_at, m.created_at)
+FROM messages m
+WHERE m.id = sqlc.arg(message_id) AND m.deleted_at IS NULL;
+
+-- name: EditMessageAndReturn :one
+WITH edited_message AS (
+    UPDATE messages
+    SET content = $2, edited_at = NOW(), updated_at = NOW()
+    WHERE id = $1 AND deleted_at IS NULL
+    RETURNING id, type, created_at, conversation_id, content, user_id, edited_at, parent_id
+)
+SELECT
+    em.id, em.type, em.created_at, em.conversation_id,
+    em.content as formatted_text,
+    em.edited_at,
+    (SELECT COUNT(*) FROM message_revisions mr WHERE mr.message_id = em.id) as revision_count,
+    u.id as user_id, u.name as user_name, u.avatar as user_avatar,
+    em.parent_id
+FROM edited_message em
+JOIN users u ON u.id = em.user_id
+WHERE u.deleted_at IS NULL;
+
+-- name: DeleteMessage :execrows
+UPDATE messages
+SET deleted_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: DeleteExpiredMessages :many
+UPDATE messages
+SET deleted_at = NOW(), updated_at = NOW()
+WHERE id IN (
+    SELECT id FROM messages
+    WHERE expires_at <= NOW() AND deleted_at IS NULL
+    ORDER BY expires_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, conversation_id;
+
+-- name: HideMessageForUser :exec
+INSERT INTO hidden_messages (message_id, user_id, created_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT DO NOTHING;
+
+-- name: GetMessageRevisions :many
+SELECT mr.*
+FROM message_revisions mr
+JOIN messages m ON m.id = mr.message_id
+JOIN participants p ON p.conversation_id = m.conversation_id AND p.user_id = $2
+WHERE mr.message_id = $1
+  AND m.deleted_at IS NULL
+  AND p.deleted_at IS NULL
+ORDER BY mr.created_at DESC;
+
+-- name: AddMessageReaction :exec
+INSERT INTO message_reactions (message_id, user_id, emoji, created_at)
+VALUES ($1, $2, $3, NOW())
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveMessageReaction :exec
+DELETE FROM message_reactions
+WHERE message_id = $1 AND user_id = $2 AND emoji = $3;
+
+-- name: GetReactionsByMessageIDs :many
+SELECT
+    message_id,
+    emoji,
+    COUNT(*) as count,
+    array_agg(user_id ORDER BY created_at)::uuid[] as user_ids
+FROM message_reactions
+WHERE message_id = ANY($1::uuid[])
+GROUP BY message_id, emoji
+ORDER BY message_id, MIN(created_at);
+
+-- name: StoreMessageMentions :exec
+INSERT INTO message_mentions (message_id, user_id)
+SELECT $1, unnest(sqlc.arg(user_ids)::uuid[]);
+
+-- name: DeleteMessageMentions :exec
+DELETE FROM message_mentions
+WHERE message_id = $1;
+
+-- name: GetMentionsByMessageIDs :many
+SELECT message_id, user_id
+FROM message_mentions
+WHERE message_id = ANY($1::uuid[]);
+
+-- name: GetParticipantIDsByNames :many
+SELECT u.id
+FROM users u
+JOIN participants p ON p.user_id = u.id
+WHERE p.conversation_id = $1
+  AND lower(u.name) = ANY(sqlc.arg(names)::text[])
+  AND p.deleted_at IS NULL
+  AND u.deleted_at IS NULL;
+
+-- name: GetMentionsRaw :many
+SELECT
+    m.id,
+    m.type,
+    m.created_at,
+    m.conversation_id,
+    m.content,
+    u.id as user_id,
+    u.name as user_name,
+    u.avatar as user_avatar,
+    m.edited_at,
+    m.parent_id
+FROM message_mentions mm
+JOIN messages m ON m.id = mm.message_id
+JOIN users u ON u.id = m.user_id
+JOIN participants p ON p.conversation_id = m.conversation_id AND p.user_id = mm.user_id
+WHERE mm.user_id = sqlc.arg(user_id)
+  AND m.deleted_at IS NULL
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+  AND p.deleted_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM hidden_messages hm
+    WHERE hm.message_id = m.id AND hm.user_id = sqlc.arg(user_id)
+  )
+  AND (
+    sqlc.arg(cursor_created_at)::timestamptz IS NULL
+    OR m.created_at < sqlc.arg(cursor_created_at)
+    OR (
+      m.created_at = sqlc.arg(cursor_created_at)
+      AND m.id < sqlc.arg(cursor_id)
+    )
+  )
+ORDER BY m.created_at DESC, m.id DESC
+LIMIT sqlc.arg(page_limit);
+
+-- name: StoreAttachment :exec
+INSERT INTO attachments (id, message_id, conversation_id, user_id, file_name, content_type, size, storage_key, status, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW());
+
+-- name: UpdateAttachmentProcessing :exec
+UPDATE attachments
+SET
+    status = $2,
+    size = $3,
+    width = $4,
+    height = $5,
+    blurhash = $6,
+    thumbnail_key = $7,
+    thumbnail_content_type = $8,
+    thumbnail_width = $9,
+    thumbnail_height = $10,
+    storage_key = $11
+WHERE id = $1;
+
+-- name: GetProcessingAttachmentIDs :many
+SELECT id
+FROM attachments
+WHERE status = 'processing' AND created_at < $1
+ORDER BY created_at ASC
+LIMIT $2;
+
+-- name: GetAttachmentByID :one
+SELECT a.id, a.message_id, a.conversation_id, a.user_id, a.file_name, a.content_type, a.size, a.storage_key, a.created_at, a.status, a.width, a.height, a.blurhash, a.thumbnail_key, a.thumbnail_content_type, a.thumbnail_width, a.thumbnail_height
+FROM attachments a
+JOIN messages m ON m.id = a.message_id
+WHERE a.id = $1 AND m.deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetAttachmentByMessageID :one
+SELECT id, message_id, conversation_id, user_id, file_name, content_type, size, storage_key, created_at, status, width, height, blurhash, thumbnail_key, thumbnail_content_type, thumbnail_width, thumbnail_height
+FROM attachments
+WHERE message_id = $1
+LIMIT 1;
+
+-- name: GetAttachmentsByMessageIDs :many
+SELECT id, message_id, conversation_id, user_id, file_name, content_type, size, storage_key, created_at, status, width, height, blurhash, thumbnail_key, thumbnail_content_type, thumbnail_width, thumbnail_height
+FROM attachments
+WHERE message_id = ANY($1::uuid[]);
+
+-- name: StoreInviteLink :exec
+INSERT INTO invite_links (id, conversation_id, creator_id, max_uses, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, NOW());
+
+-- name: GetInviteLinkByID :one
+SELECT id, conversation_id, creator_id, max_uses, use_count, expires_at, revoked_at, created_at, updated_at
+FROM invite_links
+WHERE id = $1
+LIMIT 1;
+
+-- name: UseInviteLink :execrows
+UPDATE invite_links
+SET use_count = use_count + 1, updated_at = NOW()
+WHERE id = $1
+  AND revoked_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW())
+  AND (max_uses IS NULL OR use_count < max_uses);
+
+-- name: RevokeInviteLink :execrows
+UPDATE invite_links
+SET revoked_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND conversation_id = $2 AND revoked_at IS NULL;
+
 -- name: GetMessageWithUser :one
 SELECT
     m.id, m.type, m.created_at, m.conversation_id, m.content,
@@ -128,13 +366,18 @@ LIMIT 1;
 -- Complex queries for read model
 
 -- name: GetContacts :many
-SELECT id, name, avatar
+SELECT id, name, avatar, last_seen_at
 FROM users
 WHERE deleted_at IS NULL AND id != $1
+  AND NOT EXISTS (
+    SELECT 1 FROM user_blocks b
+    WHERE (b.blocker_id = $1 AND b.blocked_id = users.id)
+       OR (b.blocker_id = users.id AND b.blocked_id = $1)
+  )
 LIMIT $2 OFFSET $3;
 
 -- name: GetParticipantsByConversationID :many
-SELECT u.id, u.name, u.avatar
+SELECT u.id, u.name, u.avatar, u.last_seen_at, p.role
 FROM users u
 JOIN participants p ON p.user_id = u.id
 WHERE p.conversation_id = $1
@@ -143,7 +386,7 @@ WHERE p.conversation_id = $1
 LIMIT $2 OFFSET $3;
 
 -- name: GetPotentialInvitees :many
-SELECT u.id, u.name, u.avatar
+SELECT u.id, u.name, u.avatar, u.last_seen_at
 FROM users u
 WHERE u.deleted_at IS NULL
   AND u.id NOT IN (
@@ -152,10 +395,15 @@ WHERE u.deleted_at IS NULL
     WHERE conversation_id = $1
       AND deleted_at IS NULL
   )
+  AND NOT EXISTS (
+    SELECT 1 FROM user_blocks b
+    WHERE (b.blocker_id = sqlc.arg(user_id) AND b.blocked_id = u.id)
+       OR (b.blocker_id = u.id AND b.blocked_id = sqlc.arg(user_id))
+  )
 LIMIT $2 OFFSET $3;
 
 -- name: GetUsersByIDs :many
-SELECT id, name, avatar
+SELECT id, name, avatar, last_seen_at
 FROM users
 WHERE id = ANY($1::uuid[])
   AND deleted_at IS NULL;
@@ -166,11 +414,104 @@ SELECT
     m.type,
     m.created_at,
     m.conversation_id,
-    m.content,
-    m.user_id
+    CASE WHEN m.deleted_at IS NULL THEN m.content ELSE '' END as content,
+    m.user_id,
+    m.edited_at,
+    (SELECT COUNT(*) FROM message_revisions mr WHERE mr.message_id = m.id) as revision_count,
+    m.deleted_at,
+    (SELECT COUNT(*) FROM messages r WHERE r.parent_id = m.id AND r.deleted_at IS NULL AND (r.expires_at IS NULL OR r.expires_at > NOW())) as reply_count,
+    (SELECT MAX(r.created_at) FROM messages r WHERE r.parent_id = m.id AND r.deleted_at IS NULL AND (r.expires_at IS NULL OR r.expires_at > NOW()))::timestamptz as last_reply_at
 FROM messages m
 WHERE m.conversation_id = $1
-  AND m.deleted_at IS NULL
+  AND m.parent_id IS NULL
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+  AND NOT EXISTS (
+    SELECT 1 FROM hidden_messages hm
+    WHERE hm.message_id = m.id AND hm.user_id = sqlc.arg(user_id)
+  )
+  AND (
+    sqlc.arg(cursor_created_at)::timestamptz IS NULL
+    OR m.created_at < sqlc.arg(cursor_created_at)
+    OR (
+      m.created_at = sqlc.arg(cursor_created_at)
+      AND m.id < sqlc.arg(cursor_id)
+    )
+  )
+ORDER BY m.created_at DESC, m.id DESC
+LIMIT sqlc.arg(page_limit);
+
+-- name: SearchMessagesRaw :many
+WITH matches AS (
+    SELECT
+        m.id,
+        m.type,
+        m.created_at,
+        m.conversation_id,
+        m.content,
+        m.user_id,
+        m.parent_id,
+        q.query,
+        ts_rank(m.search_vector, q.query)::real AS rank
+    FROM messages m
+    CROSS JOIN websearch_to_tsquery('simple', sqlc.arg(query)::text) AS q(query)
+    JOIN conversations c ON c.id = m.conversation_id AND c.deleted_at IS NULL
+    JOIN participants p ON p.conversation_id = m.conversation_id
+        AND p.user_id = sqlc.arg(user_id)
+        AND p.deleted_at IS NULL
+    WHERE m.search_vector @@ q.query
+      AND m.deleted_at IS NULL
+      AND (m.expires_at IS NULL OR m.expires_at > NOW())
+      AND m.type = 0
+      AND (sqlc.narg(conversation_id)::uuid IS NULL OR m.conversation_id = sqlc.narg(conversation_id)::uuid)
+      AND NOT EXISTS (
+        SELECT 1 FROM hidden_messages hm
+        WHERE hm.message_id = m.id AND hm.user_id = sqlc.arg(user_id)
+      )
+)
+SELECT
+    id,
+    type,
+    created_at,
+    conversation_id,
+    user_id,
+    parent_id,
+    ts_headline('simple', content, query, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5')::text AS snippet,
+    rank
+FROM matches
+WHERE (
+    sqlc.narg(cursor_rank)::real IS NULL
+    OR rank < sqlc.narg(cursor_rank)::real
+    OR (rank = sqlc.narg(cursor_rank)::real AND created_at < sqlc.arg(cursor_created_at))
+    OR (
+      rank = sqlc.narg(cursor_rank)::real
+      AND created_at = sqlc.arg(cursor_created_at)
+      AND id < sqlc.arg(cursor_id)
+    )
+)
+ORDER BY rank DESC, created_at DESC, id DESC
+LIMIT sqlc.arg(page_limit);
+
+-- name: GetThreadMessagesRaw :many
+SELECT
+    m.id,
+    m.type,
+    m.created_at,
+    m.conversation_id,
+    CASE WHEN m.deleted_at IS NULL THEN m.content ELSE '' END as content,
+    m.user_id,
+    m.edited_at,
+    (SELECT COUNT(*) FROM message_revisions mr WHERE mr.message_id = m.id) as revision_count,
+    m.deleted_at,
+    m.parent_id
+FROM messages m
+JOIN participants p ON p.conversation_id = m.conversation_id AND p.user_id = sqlc.arg(user_id)
+WHERE m.parent_id = sqlc.arg(parent_id)
+  AND p.deleted_at IS NULL
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+  AND NOT EXISTS (
+    SELECT 1 FROM hidden_messages hm
+    WHERE hm.message_id = m.id AND hm.user_id = sqlc.arg(user_id)
+  )
   AND (
     sqlc.arg(cursor_created_at)::timestamptz IS NULL
     OR m.created_at < sqlc.arg(cursor_created_at)
@@ -191,7 +532,9 @@ SELECT
     m.content,
     u.id as user_id,
     u.name as user_name,
-    u.avatar as user_avatar
+    u.avatar as user_avatar,
+    m.edited_at,
+    (SELECT COUNT(*) FROM message_revisions mr WHERE mr.message_id = m.id) as revision_count
 FROM messages m
 LEFT JOIN users u ON u.id = m.user_id
 WHERE m.id = $1 AND m.deleted_at IS NULL
@@ -201,6 +544,8 @@ LIMIT 1;
 WITH last_messages AS (
     SELECT conversation_id, MAX(created_at) as max_created_at
     FROM messages
+    WHERE deleted_at IS NULL
+      AND (expires_at IS NULL OR expires_at > NOW())
     GROUP BY conversation_id
 )
 SELECT
@@ -218,11 +563,25 @@ SELECT
     gc.name as group_name,
     ou.id as other_user_id,
     ou.name as other_user_name,
-    ou.avatar as other_user_avatar
+    ou.avatar as other_user_avatar,
+    (
+        SELECT COUNT(*) FROM messages um
+        WHERE um.conversation_id = c.id
+          AND um.deleted_at IS NULL
+          AND (um.expires_at IS NULL OR um.expires_at > NOW())
+          AND um.type = 0
+          AND um.parent_id IS NULL
+          AND um.user_id <> p.user_id
+          AND (p.last_read_at IS NULL OR um.created_at > p.last_read_at)
+          AND NOT EXISTS (
+            SELECT 1 FROM hidden_messages hm
+            WHERE hm.message_id = um.id AND hm.user_id = p.user_id
+          )
+    ) as unread_count
 FROM conversations c
 JOIN participants p ON p.conversation_id = c.id
 LEFT JOIN last_messages lm ON lm.conversation_id = c.id
-LEFT JOIN messages m ON m.conversation_id = c.id AND m.created_at = lm.max_created_at
+LEFT JOIN messages m ON m.conversation_id = c.id AND m.created_at = lm.max_created_at AND m.deleted_at IS NULL AND (m.expires_at IS NULL OR m.expires_at > NOW())
 LEFT JOIN users u ON u.id = m.user_id
 LEFT JOIN group_conversations gc ON gc.conversation_id = c.id
 LEFT JOIN participants op ON op.conversation_id = c.id
@@ -251,8 +610,18 @@ SELECT
     gc.avatar as group_avatar,
     gc.name as group_name,
     gc.owner_id as group_owner_id,
+    gc.visibility as group_visibility,
     pc.count as participants_count,
-    up.id as user_participant_id
+    up.id as user_participant_id,
+    up.role as user_role,
+    up.notification_level as user_notification_level,
+    up.muted_until as user_muted_until,
+    (
+        SELECT COUNT(*) FROM pinned_messages pm
+        JOIN messages pmm ON pmm.id = pm.message_id
+        WHERE pm.conversation_id = c.id AND pmm.deleted_at IS NULL AND (pmm.expires_at IS NULL OR pmm.expires_at > NOW())
+    ) as pinned_count,
+    c.message_ttl_seconds
 FROM conversations c
 LEFT JOIN participants op ON op.conversation_id = c.id
     AND op.user_id <> $2
@@ -314,6 +683,46 @@ SELECT EXISTS(
     WHERE conversation_id = $1 AND user_id = $2 AND deleted_at IS NULL
 );
 
+-- name: IsMessageHidden :one
+SELECT EXISTS(
+    SELECT 1 FROM hidden_messages
+    WHERE message_id = $1 AND user_id = $2
+);
+
+-- name: GetMemberRole :one
+SELECT p.role
+FROM participants p
+JOIN conversations c ON c.id = p.conversation_id
+WHERE p.conversation_id = $1 AND p.user_id = $2
+  AND p.deleted_at IS NULL AND c.deleted_at IS NULL;
+
+-- name: UpdateParticipantRole :execrows
+UPDATE participants
+SET role = $3, updated_at = NOW()
+WHERE conversation_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: UpdateParticipantNotificationPreferences :execrows
+UPDATE participants
+SET notification_level = $3, muted_until = $4, updated_at = NOW()
+WHERE conversation_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: GetNotificationPreferencesByUserID :many
+SELECT conversation_id, notification_level, muted_until
+FROM participants
+WHERE user_id = $1 AND deleted_at IS NULL;
+
+-- name: GetGroupVisibility :one
+SELECT gc.visibility
+FROM group_conversations gc
+JOIN conversations c ON c.id = gc.conversation_id
+WHERE gc.conversation_id = $1
+  AND gc.deleted_at IS NULL
+  AND c.deleted_at IS NULL;
+
 -- name: IsMemberOwner :one
 SELECT EXISTS(
     SELECT 1 FROM group_conversations gc
@@ -323,16 +732,29 @@ SELECT EXISTS(
       AND gc.deleted_at IS NULL AND c.deleted_at IS NULL AND p.deleted_at IS NULL
 );
 
+-- name: GetLongestStandingMember :one
+SELECT user_id
+FROM participants
+WHERE conversation_id = $1
+  AND user_id <> $2
+  AND deleted_at IS NULL
+ORDER BY created_at ASC, id ASC
+LIMIT 1;
+
 -- name: StoreMessageAndReturn :one
 WITH new_message AS (
-    INSERT INTO messages (id, conversation_id, user_id, content, type, created_at)
-    VALUES ($1, $2, $3, $4, $5, NOW())
-    RETURNING id, type, created_at, conversation_id, content, user_id
+    INSERT INTO messages (id, conversation_id, user_id, content, type, parent_id, created_at, expires_at)
+    VALUES (
+        $1, $2, $3, $4, $5, $6, NOW(),
+        (SELECT NOW() + make_interval(secs => c.message_ttl_seconds) FROM conversations c WHERE c.id = $2)
+    )
+    RETURNING id, type, created_at, conversation_id, content, user_id, parent_id
 )
 SELECT
     nm.id, nm.type, nm.created_at, nm.conversation_id,
     nm.content as formatted_text,
-    u.id as user_id, u.name as user_name, u.avatar as user_avatar
+    u.id as user_id, u.name as user_name, u.avatar as user_avatar,
+    nm.parent_id
 FROM new_message nm
 JOIN users u ON u.id = nm.user_id
 WHERE u.deleted_at IS NULL;
@@ -345,3 +767,303 @@ WHERE conversation_id = $1
   AND EXISTS (
     SELECT 1 FROM conversations c WHERE c.id = conversation_id AND c.deleted_at IS NULL
   );
+
+-- name: GetActiveInviteLinks :many
+SELECT
+    il.id, il.conversation_id, il.max_uses, il.use_count, il.expires_at, il.created_at,
+    u.id as creator_id, u.name as creator_name, u.avatar as creator_avatar
+FROM invite_links il
+JOIN users u ON u.id = il.creator_id
+WHERE il.conversation_id = $1
+  AND il.revoked_at IS NULL
+  AND (il.expires_at IS NULL OR il.expires_at > NOW())
+  AND (il.max_uses IS NULL OR il.use_count < il.max_uses)
+ORDER BY il.created_at DESC;
+
+-- name: GetPublicGroups :many
+SELECT
+    gc.conversation_id,
+    gc.name,
+    gc.avatar,
+    gc.created_at,
+    (
+        SELECT COUNT(*) FROM participants p
+        WHERE p.conversation_id = gc.conversation_id AND p.deleted_at IS NULL
+    ) as participants_count,
+    EXISTS(
+        SELECT 1 FROM participants p
+        WHERE p.conversation_id = gc.conversation_id AND p.user_id = sqlc.arg(user_id) AND p.deleted_at IS NULL
+    ) as has_joined
+FROM group_conversations gc
+JOIN conversations c ON c.id = gc.conversation_id
+WHERE gc.visibility = 'public'
+  AND gc.deleted_at IS NULL
+  AND c.deleted_at IS NULL
+  AND (sqlc.arg(search)::text = '' OR gc.name ILIKE '%' || sqlc.arg(search)::text || '%' ESCAPE '\')
+ORDER BY gc.created_at DESC, gc.conversation_id
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- JoinRequest queries
+
+-- name: StoreJoinRequest :execrows
+INSERT INTO join_requests (id, conversation_id, user_id, status)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (conversation_id, user_id) WHERE status = 'pending' DO NOTHING;
+
+-- name: GetJoinRequestByID :one
+SELECT id, conversation_id, user_id, status, decided_by, created_at
+FROM join_requests
+WHERE id = $1;
+
+-- name: DecideJoinRequest :execrows
+UPDATE join_requests
+SET status = $2, decided_by = $3, decided_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'pending';
+
+-- name: GetPendingJoinRequests :many
+SELECT
+    jr.id, jr.conversation_id, jr.created_at,
+    u.id as user_id, u.name as user_name, u.avatar as user_avatar
+FROM join_requests jr
+JOIN users u ON u.id = jr.user_id
+WHERE jr.conversation_id = $1
+  AND jr.status = 'pending'
+  AND u.deleted_at IS NULL
+ORDER BY jr.created_at;
+
+-- name: GetMemberIDsByRoles :many
+SELECT p.user_id
+FROM participants p
+WHERE p.conversation_id = sqlc.arg(conversation_id)
+  AND p.role = ANY(sqlc.arg(roles)::text[])
+  AND p.deleted_at IS NULL;
+
+-- UserBlock queries
+
+-- name: BlockUser :exec
+INSERT INTO user_blocks (blocker_id, blocked_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: UnblockUser :execrows
+DELETE FROM user_blocks
+WHERE blocker_id = $1 AND blocked_id = $2;
+
+-- name: HasBlocked :one
+SELECT EXISTS(
+    SELECT 1 FROM user_blocks
+    WHERE blocker_id = $1 AND blocked_id = $2
+);
+
+-- name: IsBlockedInDirectConversation :one
+SELECT EXISTS(
+    SELECT 1 FROM conversations c
+    JOIN participants p ON p.conversation_id = c.id AND p.user_id <> sqlc.arg(user_id) AND p.deleted_at IS NULL
+    JOIN user_blocks b ON b.blocker_id = p.user_id AND b.blocked_id = sqlc.arg(user_id)
+    WHERE c.id = sqlc.arg(conversation_id) AND c.type = 1 AND c.deleted_at IS NULL
+);
+
+-- name: GetBlockedUsers :many
+SELECT u.id, u.name, u.avatar, u.last_seen_at
+FROM user_blocks b
+JOIN users u ON u.id = b.blocked_id
+WHERE b.blocker_id = $1
+  AND u.deleted_at IS NULL
+ORDER BY b.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- PinnedMessage queries
+
+-- name: StorePinnedMessage :execrows
+INSERT INTO pinned_messages (message_id, conversation_id, pinned_by, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT DO NOTHING;
+
+-- name: LockConversation :one
+SELECT id FROM conversations
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: DeletePinnedMessage :execrows
+DELETE FROM pinned_messages
+WHERE conversation_id = $1 AND message_id = $2;
+
+-- name: CountPinnedMessages :one
+SELECT COUNT(*)
+FROM pinned_messages pm
+JOIN messages m ON m.id = pm.message_id
+WHERE pm.conversation_id = $1
+  AND m.deleted_at IS NULL
+  AND (m.expires_at IS NULL OR m.expires_at > NOW());
+
+-- name: GetPinnedMessagesRaw :many
+SELECT
+    m.id,
+    m.type,
+    m.created_at,
+    m.conversation_id,
+    m.content,
+    u.id as user_id,
+    u.name as user_name,
+    u.avatar as user_avatar,
+    m.edited_at,
+    m.parent_id,
+    pm.created_at as pinned_at,
+    pb.id as pinned_by_id,
+    pb.name as pinned_by_name,
+    pb.avatar as pinned_by_avatar
+FROM pinned_messages pm
+JOIN messages m ON m.id = pm.message_id
+JOIN users u ON u.id = m.user_id
+JOIN users pb ON pb.id = pm.pinned_by
+WHERE pm.conversation_id = $1
+  AND m.deleted_at IS NULL
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+  AND NOT EXISTS (
+    SELECT 1 FROM hidden_messages hm
+    WHERE hm.message_id = m.id AND hm.user_id = $4
+  )
+ORDER BY pm.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- MessageForward queries
+
+-- name: StoreMessageForward :exec
+INSERT INTO message_forwards (message_id, original_message_id, original_user_id, original_conversation_id)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetMessageForward :one
+SELECT original_message_id, original_user_id, original_conversation_id
+FROM message_forwards
+WHERE message_id = $1;
+
+-- name: GetForwardsByMessageIDs :many
+SELECT
+    mf.message_id,
+    mf.original_message_id,
+    mf.original_conversation_id,
+    u.id as user_id,
+    u.name as user_name,
+    u.avatar as user_avatar
+FROM message_forwards mf
+JOIN users u ON u.id = mf.original_user_id
+WHERE mf.message_id = ANY($1::uuid[]);
+
+-- ScheduledMessage queries
+
+-- name: StoreScheduledMessage :exec
+INSERT INTO scheduled_messages (id, conversation_id, user_id, content, scheduled_at, next_attempt_at)
+VALUES ($1, $2, $3, $4, $5, $5);
+
+-- name: UpdateScheduledMessage :execrows
+UPDATE scheduled_messages
+SET content = $2,
+    scheduled_at = $3,
+    next_attempt_at = $3,
+    attempts = 0,
+    failed_at = NULL,
+    last_error = NULL,
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: DeleteScheduledMessage :execrows
+DELETE FROM scheduled_messages
+WHERE id = $1;
+
+-- name: GetScheduledMessageByID :one
+SELECT * FROM scheduled_messages
+WHERE id = $1
+LIMIT 1;
+
+-- name: ClaimDueScheduledMessages :many
+UPDATE scheduled_messages
+SET attempts = attempts + 1,
+    next_attempt_at = sqlc.arg(now)::timestamptz + make_interval(secs => LEAST(sqlc.arg(retry_base_seconds)::float8 * power(2, attempts), sqlc.arg(retry_max_seconds)::float8))
+WHERE id IN (
+    SELECT id FROM scheduled_messages
+    WHERE next_attempt_at <= sqlc.arg(now)::timestamptz
+      AND failed_at IS NULL
+    ORDER BY next_attempt_at ASC
+    LIMIT sqlc.arg(claim_limit)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: RecordScheduledMessageFailure :exec
+UPDATE scheduled_messages
+SET last_error = $2, failed_at = $3, updated_at = NOW()
+WHERE id = $1;
+
+-- name: GetScheduledMessages :many
+SELECT * FROM scheduled_messages
+WHERE user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(conversation_id)::uuid IS NULL OR conversation_id = sqlc.narg(conversation_id)::uuid)
+ORDER BY scheduled_at ASC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- Poll queries
+
+-- name: StorePoll :exec
+INSERT INTO polls (id, message_id, conversation_id, user_id, question, multiple_choice, anonymous, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW());
+
+-- name: StorePollOption :exec
+INSERT INTO poll_options (id, poll_id, position, text)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetPollByMessageID :one
+SELECT * FROM polls
+WHERE message_id = $1
+LIMIT 1;
+
+-- name: GetPollOptions :many
+SELECT * FROM poll_options
+WHERE poll_id = $1
+ORDER BY position ASC;
+
+-- name: LockOpenPoll :one
+SELECT id FROM polls
+WHERE id = $1 AND closed_at IS NULL
+FOR UPDATE;
+
+-- name: DeletePollVotes :exec
+DELETE FROM poll_votes
+WHERE poll_id = $1 AND user_id = $2;
+
+-- name: StorePollVote :exec
+INSERT INTO poll_votes (poll_id, option_id, user_id, created_at)
+VALUES ($1, $2, $3, NOW());
+
+-- name: ClosePoll :execrows
+UPDATE polls
+SET closed_at = $2
+WHERE id = $1 AND closed_at IS NULL;
+
+-- name: GetPollsByMessageIDs :many
+SELECT
+    p.id,
+    p.message_id,
+    p.question,
+    p.multiple_choice,
+    p.anonymous,
+    p.closed_at,
+    (SELECT COUNT(DISTINCT v.user_id) FROM poll_votes v WHERE v.poll_id = p.id) as total_voters
+FROM polls p
+WHERE p.message_id = ANY($1::uuid[]);
+
+-- name: GetPollTalliesByPollIDs :many
+SELECT
+    o.id,
+    o.poll_id,
+    o.text,
+    COUNT(v.user_id) as vote_count,
+    COALESCE(
+        array_agg(v.user_id ORDER BY v.created_at) FILTER (WHERE v.user_id IS NOT NULL AND NOT p.anonymous),
+        '{}'
+    )::uuid[] as user_ids
+FROM poll_options o
+JOIN polls p ON p.id = o.poll_id
+LEFT JOIN poll_votes v ON v.option_id = o.id
+WHERE o.poll_id = ANY($1::uuid[])
+GROUP BY o.id, p.anonymous
+ORDER BY o.poll_id, o.position;
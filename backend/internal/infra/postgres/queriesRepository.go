@@ -2,6 +2,9 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"strings"
 	"time"
 
 	"GitHub/go-chat/backend/internal/domain"
@@ -10,10 +13,13 @@ import (
 	"GitHub/go-chat/backend/internal/readModel"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type queriesRepository struct {
 	pool    *pgxpool.Pool
 	queries *db.Queries
@@ -62,9 +68,10 @@ func (r *queriesRepository) GetContacts(userID uuid.UUID, paginationInfo readMod
 	usersDTO := make([]readModel.ContactDTO, len(users))
 	for i, user := range users {
 		usersDTO[i] = readModel.ContactDTO{
-			ID:     pgtypeToUUID(user.ID),
-			Name:   user.Name,
-			Avatar: user.Avatar.String,
+			ID:         pgtypeToUUID(user.ID),
+			Name:       user.Name,
+			Avatar:     user.Avatar.String,
+			LastSeenAt: pgtypeToTimePtr(user.LastSeenAt),
 		}
 	}
 
@@ -87,22 +94,74 @@ func (r *queriesRepository) GetParticipants(conversationID uuid.UUID, userID uui
 	usersDTO := make([]readModel.ContactDTO, len(participants))
 	for i, participant := range participants {
 		usersDTO[i] = readModel.ContactDTO{
-			ID:     pgtypeToUUID(participant.ID),
-			Name:   participant.Name,
-			Avatar: participant.Avatar.String,
+			ID:         pgtypeToUUID(participant.ID),
+			Name:       participant.Name,
+			Avatar:     participant.Avatar.String,
+			LastSeenAt: pgtypeToTimePtr(participant.LastSeenAt),
+			Role:       participant.Role,
 		}
 	}
 
 	return usersDTO, nil
 }
 
-func (r *queriesRepository) GetPotentialInvitees(conversationID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error) {
+func (r *queriesRepository) GetActiveInviteLinks(conversationID uuid.UUID) ([]readModel.InviteLinkDTO, error) {
+	links, err := r.queries.GetActiveInviteLinks(context.Background(), uuidToPgtype(conversationID))
+	if err != nil {
+		return nil, err
+	}
+
+	linksDTO := make([]readModel.InviteLinkDTO, len(links))
+	for i, link := range links {
+		linksDTO[i] = readModel.InviteLinkDTO{
+			ID:             pgtypeToUUID(link.ID),
+			ConversationID: pgtypeToUUID(link.ConversationID),
+			Creator: readModel.UserDTO{
+				ID:     pgtypeToUUID(link.CreatorID),
+				Name:   link.CreatorName,
+				Avatar: link.CreatorAvatar.String,
+			},
+			MaxUses:   pgtypeToIntPtr(link.MaxUses),
+			UseCount:  int(link.UseCount),
+			ExpiresAt: pgtypeToTimePtr(link.ExpiresAt),
+			CreatedAt: link.CreatedAt.Time,
+		}
+	}
+
+	return linksDTO, nil
+}
+
+func (r *queriesRepository) GetPendingJoinRequests(conversationID uuid.UUID) ([]readModel.JoinRequestDTO, error) {
+	requests, err := r.queries.GetPendingJoinRequests(context.Background(), uuidToPgtype(conversationID))
+	if err != nil {
+		return nil, err
+	}
+
+	requestsDTO := make([]readModel.JoinRequestDTO, len(requests))
+	for i, request := range requests {
+		requestsDTO[i] = readModel.JoinRequestDTO{
+			ID:             pgtypeToUUID(request.ID),
+			ConversationID: pgtypeToUUID(request.ConversationID),
+			User: readModel.UserDTO{
+				ID:     pgtypeToUUID(request.UserID),
+				Name:   request.UserName,
+				Avatar: request.UserAvatar.String,
+			},
+			CreatedAt: request.CreatedAt.Time,
+		}
+	}
+
+	return requestsDTO, nil
+}
+
+func (r *queriesRepository) GetPotentialInvitees(conversationID uuid.UUID, userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error) {
 	limit, offset := r.paginate(paginationInfo)
 
 	users, err := r.queries.GetPotentialInvitees(context.Background(), db.GetPotentialInviteesParams{
 		ConversationID: uuidToPgtype(conversationID),
 		Limit:          limit,
 		Offset:         offset,
+		UserID:         uuidToPgtype(userID),
 	})
 
 	if err != nil {
@@ -112,9 +171,36 @@ func (r *queriesRepository) GetPotentialInvitees(conversationID uuid.UUID, pagin
 	usersDTO := make([]readModel.ContactDTO, len(users))
 	for i, user := range users {
 		usersDTO[i] = readModel.ContactDTO{
-			ID:     pgtypeToUUID(user.ID),
-			Name:   user.Name,
-			Avatar: user.Avatar.String,
+			ID:         pgtypeToUUID(user.ID),
+			Name:       user.Name,
+			Avatar:     user.Avatar.String,
+			LastSeenAt: pgtypeToTimePtr(user.LastSeenAt),
+		}
+	}
+
+	return usersDTO, nil
+}
+
+func (r *queriesRepository) GetBlockedUsers(userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error) {
+	limit, offset := r.paginate(paginationInfo)
+
+	users, err := r.queries.GetBlockedUsers(context.Background(), db.GetBlockedUsersParams{
+		BlockerID: uuidToPgtype(userID),
+		Limit:     limit,
+		Offset:    offset,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	usersDTO := make([]readModel.ContactDTO, len(users))
+	for i, user := range users {
+		usersDTO[i] = readModel.ContactDTO{
+			ID:         pgtypeToUUID(user.ID),
+			Name:       user.Name,
+			Avatar:     user.Avatar.String,
+			LastSeenAt: pgtypeToTimePtr(user.LastSeenAt),
 		}
 	}
 
@@ -128,9 +214,10 @@ func (r *queriesRepository) GetUserByID(id uuid.UUID) (readModel.UserDTO, error)
 	}
 
 	return readModel.UserDTO{
-		ID:     pgtypeToUUID(user.ID),
-		Name:   user.Name,
-		Avatar: user.Avatar.String,
+		ID:         pgtypeToUUID(user.ID),
+		Name:       user.Name,
+		Avatar:     user.Avatar.String,
+		LastSeenAt: pgtypeToTimePtr(user.LastSeenAt),
 	}, nil
 }
 
@@ -152,36 +239,158 @@ func (r *queriesRepository) GetUsersByIDs(ids []uuid.UUID) ([]readModel.UserDTO,
 	usersDTO := make([]readModel.UserDTO, len(users))
 	for i, user := range users {
 		usersDTO[i] = readModel.UserDTO{
-			ID:     pgtypeToUUID(user.ID),
-			Name:   user.Name,
-			Avatar: user.Avatar.String,
+			ID:         pgtypeToUUID(user.ID),
+			Name:       user.Name,
+			Avatar:     user.Avatar.String,
+			LastSeenAt: pgtypeToTimePtr(user.LastSeenAt),
 		}
 	}
 
 	return usersDTO, nil
 }
 
-func (r *queriesRepository) GetConversationMessages(conversationID uuid.UUID, cursor *readModel.MessageCursor, limit int) (readModel.MessagePageDTO, error) {
-	pageLimit := limit
-	if pageLimit <= 0 {
-		pageLimit = 50
-	} else if pageLimit > 100 {
-		pageLimit = 100
+func (r *queriesRepository) GetConversationMessages(conversationID uuid.UUID, userID uuid.UUID, cursor *readModel.MessageCursor, limit int) (readModel.MessagePageDTO, error) {
+	pageLimit := messagePageLimit(limit)
+	cursorCreatedAt, cursorID := messageCursorToPgtype(cursor)
+
+	messages, err := r.queries.GetConversationMessagesRaw(context.Background(), db.GetConversationMessagesRawParams{
+		ConversationID:  uuidToPgtype(conversationID),
+		UserID:          uuidToPgtype(userID),
+		CursorCreatedAt: cursorCreatedAt,
+		CursorID:        cursorID,
+		PageLimit:       int32(pageLimit + 1),
+	})
+
+	if err != nil {
+		return readModel.MessagePageDTO{}, err
 	}
 
-	queryLimit := pageLimit + 1
-	var cursorCreatedAt pgtype.Timestamptz
-	var cursorID pgtype.UUID
-	if cursor != nil {
-		cursorCreatedAt = pgtype.Timestamptz{Time: cursor.CreatedAt, Valid: true}
-		cursorID = uuidToPgtype(cursor.ID)
+	hasMore := false
+	if len(messages) > pageLimit {
+		hasMore = true
+		messages = messages[:len(messages)-1]
 	}
 
-	messages, err := r.queries.GetConversationMessagesRaw(context.Background(), db.GetConversationMessagesRawParams{
-		ConversationID:  uuidToPgtype(conversationID),
+	formatter := presentation.NewMessageFormatter()
+	messageDTOs := make([]readModel.MessageDTO, 0, len(messages))
+
+	for i := len(messages) - 1; i >= 0; i-- {
+		msg := messages[i]
+		rawMessage := readModel.RawMessageDTO{
+			ID:             pgtypeToUUID(msg.ID),
+			Type:           uint8(msg.Type),
+			CreatedAt:      msg.CreatedAt.Time,
+			ConversationID: pgtypeToUUID(msg.ConversationID),
+			Content:        msg.Content,
+			UserID:         pgtypeToUUID(msg.UserID),
+			EditedAt:       pgtypeToTimePtr(msg.EditedAt),
+			RevisionCount:  msg.RevisionCount,
+			DeletedAt:      pgtypeToTimePtr(msg.DeletedAt),
+			ReplyCount:     msg.ReplyCount,
+			LastReplyAt:    pgtypeToTimePtr(msg.LastReplyAt),
+		}
+
+		messageDTOs = append(messageDTOs, formatter.FormatMessageDTO(rawMessage))
+	}
+
+	if err := r.attachReactions(messageDTOs); err != nil {
+		return readModel.MessagePageDTO{}, err
+	}
+
+	if err := r.attachAttachments(messageDTOs); err != nil {
+		return readModel.MessagePageDTO{}, err
+	}
+
+	if err := r.attachMentions(messageDTOs); err != nil {
+		return readModel.MessagePageDTO{}, err
+	}
+
+	if err := r.attachForwards(messageDTOs); err != nil {
+		return readModel.MessagePageDTO{}, err
+	}
+
+	if err := r.attachPolls(messageDTOs); err != nil {
+		return readModel.MessagePageDTO{}, err
+	}
+
+	return newMessagePage(messageDTOs, hasMore), nil
+}
+
+func (r *queriesRepository) GetThreadMessages(threadID uuid.UUID, userID uuid.UUID, cursor *readModel.MessageCursor, limit int) (readModel.MessagePageDTO, error) {
+	pageLimit := messagePageLimit(limit)
+	cursorCreatedAt, cursorID := messageCursorToPgtype(cursor)
+
+	messages, err := r.queries.GetThreadMessagesRaw(context.Background(), db.GetThreadMessagesRawParams{
+		UserID:          uuidToPgtype(userID),
+		ParentID:        uuidToPgtype(threadID),
+		CursorCreatedAt: cursorCreatedAt,
+		CursorID:        cursorID,
+		PageLimit:       int32(pageLimit + 1),
+	})
+
+	if err != nil {
+		return readModel.MessagePageDTO{}, err
+	}
+
+	hasMore := false
+	if len(messages) > pageLimit {
+		hasMore = true
+		messages = messages[:len(messages)-1]
+	}
+
+	formatter := presentation.NewMessageFormatter()
+	messageDTOs := make([]readModel.MessageDTO, 0, len(messages))
+
+	for i := len(messages) - 1; i >= 0; i-- {
+		msg := messages[i]
+		rawMessage := readModel.RawMessageDTO{
+			ID:             pgtypeToUUID(msg.ID),
+			Type:           uint8(msg.Type),
+			CreatedAt:      msg.CreatedAt.Time,
+			ConversationID: pgtypeToUUID(msg.ConversationID),
+			Content:        msg.Content,
+			UserID:         pgtypeToUUID(msg.UserID),
+			EditedAt:       pgtypeToTimePtr(msg.EditedAt),
+			RevisionCount:  msg.RevisionCount,
+			DeletedAt:      pgtypeToTimePtr(msg.DeletedAt),
+			ParentID:       pgtypeToUUIDPtr(msg.ParentID),
+		}
+
+		messageDTOs = append(messageDTOs, formatter.FormatMessageDTO(rawMessage))
+	}
+
+	if err := r.attachReactions(messageDTOs); err != nil {
+		return readModel.MessagePageDTO{}, err
+	}
+
+	if err := r.attachAttachments(messageDTOs); err != nil {
+		return readModel.MessagePageDTO{}, err
+	}
+
+	if err := r.attachMentions(messageDTOs); err != nil {
+		return readModel.MessagePageDTO{}, err
+	}
+
+	if err := r.attachForwards(messageDTOs); err != nil {
+		return readModel.MessagePageDTO{}, err
+	}
+
+	if err := r.attachPolls(messageDTOs); err != nil {
+		return readModel.MessagePageDTO{}, err
+	}
+
+	return newMessagePage(messageDTOs, hasMore), nil
+}
+
+func (r *queriesRepository) GetMentions(userID uuid.UUID, cursor *readModel.MessageCursor, limit int) (readModel.MessagePageDTO, error) {
+	pageLimit := messagePageLimit(limit)
+	cursorCreatedAt, cursorID := messageCursorToPgtype(cursor)
+
+	messages, err := r.queries.GetMentionsRaw(context.Background(), db.GetMentionsRawParams{
+		UserID:          uuidToPgtype(userID),
 		CursorCreatedAt: cursorCreatedAt,
 		CursorID:        cursorID,
-		PageLimit:       int32(queryLimit),
+		PageLimit:       int32(pageLimit + 1),
 	})
 
 	if err != nil {
@@ -206,22 +415,416 @@ func (r *queriesRepository) GetConversationMessages(conversationID uuid.UUID, cu
 			ConversationID: pgtypeToUUID(msg.ConversationID),
 			Content:        msg.Content,
 			UserID:         pgtypeToUUID(msg.UserID),
+			UserName:       msg.UserName,
+			UserAvatar:     msg.UserAvatar.String,
+			EditedAt:       pgtypeToTimePtr(msg.EditedAt),
+			ParentID:       pgtypeToUUIDPtr(msg.ParentID),
 		}
 
 		messageDTOs = append(messageDTOs, formatter.FormatMessageDTO(rawMessage))
 	}
 
-	response := readModel.MessagePageDTO{
-		Messages: messageDTOs,
+	if err := r.attachAttachments(messageDTOs); err != nil {
+		return readModel.MessagePageDTO{}, err
+	}
+
+	if err := r.attachMentions(messageDTOs); err != nil {
+		return readModel.MessagePageDTO{}, err
+	}
+
+	if err := r.attachForwards(messageDTOs); err != nil {
+		return readModel.MessagePageDTO{}, err
+	}
+
+	if err := r.attachPolls(messageDTOs); err != nil {
+		return readModel.MessagePageDTO{}, err
+	}
+
+	return newMessagePage(messageDTOs, hasMore), nil
+}
+
+func (r *queriesRepository) GetScheduledMessages(userID uuid.UUID, conversationID *uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ScheduledMessageDTO, error) {
+	limit, offset := r.paginate(paginationInfo)
+
+	messages, err := r.queries.GetScheduledMessages(context.Background(), db.GetScheduledMessagesParams{
+		UserID:         uuidToPgtype(userID),
+		ConversationID: uuidPtrToPgtype(conversationID),
+		PageLimit:      limit,
+		PageOffset:     offset,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	messagesDTO := make([]readModel.ScheduledMessageDTO, len(messages))
+	for i, message := range messages {
+		messagesDTO[i] = readModel.ScheduledMessageDTO{
+			ID:             pgtypeToUUID(message.ID),
+			ConversationID: pgtypeToUUID(message.ConversationID),
+			Text:           message.Content,
+			ScheduledAt:    message.ScheduledAt.Time,
+			CreatedAt:      message.CreatedAt.Time,
+			UpdatedAt:      message.UpdatedAt.Time,
+			FailedAt:       pgtypeToTimePtr(message.FailedAt),
+			LastError:      message.LastError.String,
+		}
+	}
+
+	return messagesDTO, nil
+}
+
+func (r *queriesRepository) SearchMessages(userID uuid.UUID, conversationID *uuid.UUID, query string, cursor *readModel.MessageSearchCursor, limit int) (readModel.MessageSearchPageDTO, error) {
+	pageLimit := messagePageLimit(limit)
+
+	params := db.SearchMessagesRawParams{
+		Query:     query,
+		UserID:    uuidToPgtype(userID),
+		PageLimit: int32(pageLimit + 1),
+	}
+	if conversationID != nil {
+		params.ConversationID = uuidToPgtype(*conversationID)
+	}
+	if cursor != nil {
+		params.CursorRank = pgtype.Float4{Float32: cursor.Rank, Valid: true}
+		params.CursorCreatedAt = pgtype.Timestamptz{Time: cursor.CreatedAt, Valid: true}
+		params.CursorID = uuidToPgtype(cursor.ID)
+	}
+
+	rows, err := r.queries.SearchMessagesRaw(context.Background(), params)
+	if err != nil {
+		return readModel.MessageSearchPageDTO{}, err
+	}
+
+	hasMore := false
+	if len(rows) > pageLimit {
+		hasMore = true
+		rows = rows[:pageLimit]
+	}
+
+	results := make([]readModel.MessageSearchResultDTO, len(rows))
+	for i, row := range rows {
+		results[i] = readModel.MessageSearchResultDTO{
+			ID:             pgtypeToUUID(row.ID),
+			ConversationID: pgtypeToUUID(row.ConversationID),
+			UserID:         pgtypeToUUID(row.UserID),
+			ParentID:       pgtypeToUUIDPtr(row.ParentID),
+			CreatedAt:      row.CreatedAt.Time,
+			Snippet:        row.Snippet,
+			Rank:           row.Rank,
+		}
+	}
+
+	page := readModel.MessageSearchPageDTO{
+		Results: results,
+		HasMore: hasMore,
+	}
+
+	if hasMore && len(results) > 0 {
+		last := results[len(results)-1]
+		page.NextCursor = strconv.FormatFloat(float64(last.Rank), 'g', -1, 32) + "|" + last.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + last.ID.String()
+	}
+
+	return page, nil
+}
+
+func (r *queriesRepository) GetMessageReactions(messageID uuid.UUID) ([]readModel.ReactionDTO, error) {
+	reactions, err := r.getReactionsByMessageIDs([]uuid.UUID{messageID})
+	if err != nil {
+		return nil, err
+	}
+
+	if reactions[messageID] == nil {
+		return []readModel.ReactionDTO{}, nil
+	}
+
+	return reactions[messageID], nil
+}
+
+// liveMessageIDs returns the IDs of messages that are not deleted. Tombstones
+// keep only their metadata, so nothing is attached to them.
+func liveMessageIDs(messages []readModel.MessageDTO) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(messages))
+	for _, message := range messages {
+		if message.DeletedAt != nil {
+			continue
+		}
+		ids = append(ids, message.ID)
+	}
+
+	return ids
+}
+
+func (r *queriesRepository) attachReactions(messages []readModel.MessageDTO) error {
+	ids := liveMessageIDs(messages)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	reactions, err := r.getReactionsByMessageIDs(ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range messages {
+		messages[i].Reactions = reactions[messages[i].ID]
+	}
+
+	return nil
+}
+
+func (r *queriesRepository) attachAttachments(messages []readModel.MessageDTO) error {
+	liveIDs := liveMessageIDs(messages)
+	if len(liveIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]pgtype.UUID, len(liveIDs))
+	for i, id := range liveIDs {
+		ids[i] = uuidToPgtype(id)
+	}
+
+	rows, err := r.queries.GetAttachmentsByMessageIDs(context.Background(), ids)
+	if err != nil {
+		return err
+	}
+
+	attachments := make(map[uuid.UUID]*readModel.AttachmentDTO, len(rows))
+	for _, row := range rows {
+		attachments[pgtypeToUUID(row.MessageID)] = presentation.FormatAttachmentDTO(toAttachmentDomain(row))
+	}
+
+	for i := range messages {
+		messages[i].Attachment = attachments[messages[i].ID]
+	}
+
+	return nil
+}
+
+func (r *queriesRepository) attachMentions(messages []readModel.MessageDTO) error {
+	liveIDs := liveMessageIDs(messages)
+	if len(liveIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]pgtype.UUID, len(liveIDs))
+	for i, id := range liveIDs {
+		ids[i] = uuidToPgtype(id)
+	}
+
+	rows, err := r.queries.GetMentionsByMessageIDs(context.Background(), ids)
+	if err != nil {
+		return err
+	}
+
+	mentions := make(map[uuid.UUID][]uuid.UUID, len(rows))
+	for _, row := range rows {
+		messageID := pgtypeToUUID(row.MessageID)
+		mentions[messageID] = append(mentions[messageID], pgtypeToUUID(row.UserID))
+	}
+
+	for i := range messages {
+		messages[i].Mentions = mentions[messages[i].ID]
+	}
+
+	return nil
+}
+
+func (r *queriesRepository) attachForwards(messages []readModel.MessageDTO) error {
+	ids := liveMessageIDs(messages)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	forwards, err := getForwardsByMessageIDs(context.Background(), r.queries, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range messages {
+		messages[i].ForwardedFrom = forwards[messages[i].ID]
+	}
+
+	return nil
+}
+
+func (r *queriesRepository) attachPolls(messages []readModel.MessageDTO) error {
+	ids := liveMessageIDs(messages)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	polls, err := getPollsByMessageIDs(context.Background(), r.queries, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range messages {
+		messages[i].Poll = polls[messages[i].ID]
+	}
+
+	return nil
+}
+
+func (r *queriesRepository) GetPoll(messageID uuid.UUID) (readModel.PollDTO, error) {
+	polls, err := getPollsByMessageIDs(context.Background(), r.queries, []uuid.UUID{messageID})
+	if err != nil {
+		return readModel.PollDTO{}, err
+	}
+
+	poll, ok := polls[messageID]
+	if !ok {
+		return readModel.PollDTO{}, domain.ErrorPollNotFound
+	}
+
+	return *poll, nil
+}
+
+func getPollsByMessageIDs(ctx context.Context, queries *db.Queries, ids []uuid.UUID) (map[uuid.UUID]*readModel.PollDTO, error) {
+	pgIDs := make([]pgtype.UUID, len(ids))
+	for i, id := range ids {
+		pgIDs[i] = uuidToPgtype(id)
+	}
+
+	rows, err := queries.GetPollsByMessageIDs(ctx, pgIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return map[uuid.UUID]*readModel.PollDTO{}, nil
+	}
+
+	pollIDs := make([]pgtype.UUID, len(rows))
+	byPollID := make(map[uuid.UUID]*readModel.PollDTO, len(rows))
+	polls := make(map[uuid.UUID]*readModel.PollDTO, len(rows))
+	for i, row := range rows {
+		pollIDs[i] = row.ID
+		poll := &readModel.PollDTO{
+			ID:             pgtypeToUUID(row.ID),
+			MessageID:      pgtypeToUUID(row.MessageID),
+			Question:       row.Question,
+			MultipleChoice: row.MultipleChoice,
+			Anonymous:      row.Anonymous,
+			ClosedAt:       pgtypeToTimePtr(row.ClosedAt),
+			TotalVoters:    row.TotalVoters,
+			Options:        []readModel.PollOptionDTO{},
+		}
+		byPollID[poll.ID] = poll
+		polls[poll.MessageID] = poll
+	}
+
+	tallies, err := queries.GetPollTalliesByPollIDs(ctx, pollIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tally := range tallies {
+		poll, ok := byPollID[pgtypeToUUID(tally.PollID)]
+		if !ok {
+			continue
+		}
+
+		option := readModel.PollOptionDTO{
+			ID:        pgtypeToUUID(tally.ID),
+			Text:      tally.Text,
+			VoteCount: tally.VoteCount,
+		}
+		for _, userID := range tally.UserIds {
+			option.UserIDs = append(option.UserIDs, pgtypeToUUID(userID))
+		}
+
+		poll.Options = append(poll.Options, option)
+	}
+
+	return polls, nil
+}
+
+func getForwardsByMessageIDs(ctx context.Context, queries *db.Queries, ids []uuid.UUID) (map[uuid.UUID]*readModel.ForwardedFromDTO, error) {
+	pgIDs := make([]pgtype.UUID, len(ids))
+	for i, id := range ids {
+		pgIDs[i] = uuidToPgtype(id)
+	}
+
+	rows, err := queries.GetForwardsByMessageIDs(ctx, pgIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	forwards := make(map[uuid.UUID]*readModel.ForwardedFromDTO, len(rows))
+	for _, row := range rows {
+		forwards[pgtypeToUUID(row.MessageID)] = &readModel.ForwardedFromDTO{
+			MessageID:      pgtypeToUUIDPtr(row.OriginalMessageID),
+			ConversationID: pgtypeToUUIDPtr(row.OriginalConversationID),
+			User: readModel.UserDTO{
+				ID:     pgtypeToUUID(row.UserID),
+				Name:   row.UserName,
+				Avatar: row.UserAvatar.String,
+			},
+		}
+	}
+
+	return forwards, nil
+}
+
+func (r *queriesRepository) getReactionsByMessageIDs(ids []uuid.UUID) (map[uuid.UUID][]readModel.ReactionDTO, error) {
+	pgIDs := make([]pgtype.UUID, len(ids))
+	for i, id := range ids {
+		pgIDs[i] = uuidToPgtype(id)
+	}
+
+	rows, err := r.queries.GetReactionsByMessageIDs(context.Background(), pgIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	reactions := make(map[uuid.UUID][]readModel.ReactionDTO, len(ids))
+	for _, row := range rows {
+		userIDs := make([]uuid.UUID, len(row.UserIds))
+		for i, userID := range row.UserIds {
+			userIDs[i] = pgtypeToUUID(userID)
+		}
+
+		messageID := pgtypeToUUID(row.MessageID)
+		reactions[messageID] = append(reactions[messageID], readModel.ReactionDTO{
+			Emoji:   row.Emoji,
+			Count:   row.Count,
+			UserIDs: userIDs,
+		})
+	}
+
+	return reactions, nil
+}
+
+func messagePageLimit(limit int) int {
+	if limit <= 0 {
+		return 50
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
+
+func messageCursorToPgtype(cursor *readModel.MessageCursor) (pgtype.Timestamptz, pgtype.UUID) {
+	if cursor == nil {
+		return pgtype.Timestamptz{}, pgtype.UUID{}
+	}
+	return pgtype.Timestamptz{Time: cursor.CreatedAt, Valid: true}, uuidToPgtype(cursor.ID)
+}
+
+func newMessagePage(messages []readModel.MessageDTO, hasMore bool) readModel.MessagePageDTO {
+	page := readModel.MessagePageDTO{
+		Messages: messages,
 		HasMore:  hasMore,
 	}
 
-	if hasMore && len(messageDTOs) > 0 {
-		oldest := messageDTOs[0]
-		response.NextCursor = oldest.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + oldest.ID.String()
+	if hasMore && len(messages) > 0 {
+		oldest := messages[0]
+		page.NextCursor = oldest.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + oldest.ID.String()
 	}
 
-	return response, nil
+	return page
 }
 
 func (r *queriesRepository) GetNotificationMessage(messageID uuid.UUID) (readModel.MessageDTO, error) {
@@ -240,11 +843,36 @@ func (r *queriesRepository) GetNotificationMessage(messageID uuid.UUID) (readMod
 		UserID:         pgtypeToUUID(msg.UserID),
 		UserName:       msg.UserName.String,
 		UserAvatar:     msg.UserAvatar.String,
+		EditedAt:       pgtypeToTimePtr(msg.EditedAt),
+		RevisionCount:  msg.RevisionCount,
 	}
 
 	return formatter.FormatMessageDTO(rawMessage), nil
 }
 
+func (r *queriesRepository) GetMessageRevisions(messageID uuid.UUID, userID uuid.UUID) ([]readModel.MessageRevisionDTO, error) {
+	revisions, err := r.queries.GetMessageRevisions(context.Background(), db.GetMessageRevisionsParams{
+		MessageID: uuidToPgtype(messageID),
+		UserID:    uuidToPgtype(userID),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	revisionDTOs := make([]readModel.MessageRevisionDTO, len(revisions))
+	for i, revision := range revisions {
+		revisionDTOs[i] = readModel.MessageRevisionDTO{
+			ID:        pgtypeToUUID(revision.ID),
+			MessageID: pgtypeToUUID(revision.MessageID),
+			Text:      revision.Content,
+			CreatedAt: revision.CreatedAt.Time,
+		}
+	}
+
+	return revisionDTOs, nil
+}
+
 func (r *queriesRepository) StoreMessageAndReturn(id uuid.UUID, conversationID uuid.UUID, userID uuid.UUID, content string, messageType int32) (readModel.MessageDTO, error) {
 	msg, err := r.queries.StoreMessageAndReturn(context.Background(), db.StoreMessageAndReturnParams{
 		ID:             uuidToPgtype(id),
@@ -290,8 +918,9 @@ func (r *queriesRepository) GetUserConversations(userID uuid.UUID, paginationInf
 
 	for i, result := range queryResults {
 		conversationDTO := readModel.ConversationDTO{
-			ID:   pgtypeToUUID(result.ConversationID),
-			Type: conversationTypesMap[uint8(result.Type)].String(),
+			ID:          pgtypeToUUID(result.ConversationID),
+			Type:        conversationTypesMap[uint8(result.Type)].String(),
+			UnreadCount: result.UnreadCount,
 		}
 
 		if result.MessageID.Valid {
@@ -357,13 +986,148 @@ func (r *queriesRepository) GetConversation(id uuid.UUID, userID uuid.UUID) (rea
 		if result.GroupOwnerID.Valid {
 			conversationDTO.IsOwner = pgtypeToUUID(result.GroupOwnerID) == userID
 		}
+		conversationDTO.Visibility = result.GroupVisibility.String
 		conversationDTO.ParticipantsCount = result.ParticipantsCount
 		conversationDTO.HasJoined = result.UserParticipantID.Valid
+		if role, err := domain.NewParticipantRole(result.UserRole.String); err == nil {
+			conversationDTO.Role = role.String()
+			conversationDTO.Permissions = role.Permissions()
+		}
+	}
+
+	if result.UserParticipantID.Valid {
+		conversationDTO.NotificationLevel = result.UserNotificationLevel.String
+		conversationDTO.MutedUntil = pgtypeToTimePtr(result.UserMutedUntil)
+	}
+
+	if result.MessageTtlSeconds.Valid {
+		conversationDTO.MessageTTL = result.MessageTtlSeconds.Int32
+	}
+
+	conversationDTO.PinnedCount = result.PinnedCount
+	if result.PinnedCount > 0 {
+		pins, err := r.getPinnedMessages(id, userID, 1, 0)
+		if err != nil {
+			return readModel.ConversationFullDTO{}, err
+		}
+
+		if len(pins) > 0 {
+			conversationDTO.LatestPin = &pins[0]
+		}
 	}
 
 	return conversationDTO, nil
 }
 
+func (r *queriesRepository) GetPinnedMessages(conversationID uuid.UUID, userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.PinnedMessageDTO, error) {
+	limit, offset := r.paginate(paginationInfo)
+
+	return r.getPinnedMessages(conversationID, userID, limit, offset)
+}
+
+func (r *queriesRepository) getPinnedMessages(conversationID uuid.UUID, userID uuid.UUID, limit int32, offset int32) ([]readModel.PinnedMessageDTO, error) {
+	pins, err := r.queries.GetPinnedMessagesRaw(context.Background(), db.GetPinnedMessagesRawParams{
+		ConversationID: uuidToPgtype(conversationID),
+		Limit:          limit,
+		Offset:         offset,
+		UserID:         uuidToPgtype(userID),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	formatter := presentation.NewMessageFormatter()
+	messageDTOs := make([]readModel.MessageDTO, len(pins))
+	for i, pin := range pins {
+		messageDTOs[i] = formatter.FormatMessageDTO(readModel.RawMessageDTO{
+			ID:             pgtypeToUUID(pin.ID),
+			Type:           uint8(pin.Type),
+			CreatedAt:      pin.CreatedAt.Time,
+			ConversationID: pgtypeToUUID(pin.ConversationID),
+			Content:        pin.Content,
+			UserID:         pgtypeToUUID(pin.UserID),
+			UserName:       pin.UserName,
+			UserAvatar:     pin.UserAvatar.String,
+			EditedAt:       pgtypeToTimePtr(pin.EditedAt),
+			ParentID:       pgtypeToUUIDPtr(pin.ParentID),
+		})
+	}
+
+	if err := r.attachAttachments(messageDTOs); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachMentions(messageDTOs); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachForwards(messageDTOs); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachPolls(messageDTOs); err != nil {
+		return nil, err
+	}
+
+	pinsDTO := make([]readModel.PinnedMessageDTO, len(pins))
+	for i, pin := range pins {
+		pinsDTO[i] = readModel.PinnedMessageDTO{
+			Message: messageDTOs[i],
+			PinnedBy: readModel.UserDTO{
+				ID:     pgtypeToUUID(pin.PinnedByID),
+				Name:   pin.PinnedByName,
+				Avatar: pin.PinnedByAvatar.String,
+			},
+			PinnedAt: pin.PinnedAt.Time,
+		}
+	}
+
+	return pinsDTO, nil
+}
+
+func (r *queriesRepository) GetPublicGroups(userID uuid.UUID, search string, paginationInfo readModel.PaginationInfo) ([]readModel.PublicGroupDTO, error) {
+	limit, offset := r.paginate(paginationInfo)
+
+	groups, err := r.queries.GetPublicGroups(context.Background(), db.GetPublicGroupsParams{
+		UserID:     uuidToPgtype(userID),
+		Search:     likeEscaper.Replace(strings.TrimSpace(search)),
+		PageLimit:  limit,
+		PageOffset: offset,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	groupsDTO := make([]readModel.PublicGroupDTO, len(groups))
+	for i, group := range groups {
+		groupsDTO[i] = readModel.PublicGroupDTO{
+			ID:                pgtypeToUUID(group.ConversationID),
+			Name:              group.Name,
+			Avatar:            group.Avatar.String,
+			CreatedAt:         group.CreatedAt.Time,
+			ParticipantsCount: group.ParticipantsCount,
+			HasJoined:         group.HasJoined,
+		}
+	}
+
+	return groupsDTO, nil
+}
+
+func (r *queriesRepository) GetGroupVisibility(conversationID uuid.UUID) (string, error) {
+	visibility, err := r.queries.GetGroupVisibility(context.Background(), uuidToPgtype(conversationID))
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", domain.ErrorConversationNotFound
+		}
+		return "", err
+	}
+
+	return visibility, nil
+}
+
 func (r *queriesRepository) IsMember(conversationID uuid.UUID, userID uuid.UUID) (bool, error) {
 	return r.queries.IsMember(context.Background(), db.IsMemberParams{
 		ConversationID: uuidToPgtype(conversationID),
@@ -371,6 +1135,13 @@ func (r *queriesRepository) IsMember(conversationID uuid.UUID, userID uuid.UUID)
 	})
 }
 
+func (r *queriesRepository) IsMessageHidden(messageID uuid.UUID, userID uuid.UUID) (bool, error) {
+	return r.queries.IsMessageHidden(context.Background(), db.IsMessageHiddenParams{
+		MessageID: uuidToPgtype(messageID),
+		UserID:    uuidToPgtype(userID),
+	})
+}
+
 func (r *queriesRepository) IsMemberOwner(conversationID uuid.UUID, userID uuid.UUID) (bool, error) {
 	return r.queries.IsMemberOwner(context.Background(), db.IsMemberOwnerParams{
 		ConversationID: uuidToPgtype(conversationID),
@@ -378,6 +1149,92 @@ func (r *queriesRepository) IsMemberOwner(conversationID uuid.UUID, userID uuid.
 	})
 }
 
+func (r *queriesRepository) GetMemberIDsByRoles(conversationID uuid.UUID, roles []string) ([]uuid.UUID, error) {
+	ids, err := r.queries.GetMemberIDsByRoles(context.Background(), db.GetMemberIDsByRolesParams{
+		ConversationID: uuidToPgtype(conversationID),
+		Roles:          roles,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uuid.UUID, len(ids))
+	for i, id := range ids {
+		userIDs[i] = pgtypeToUUID(id)
+	}
+
+	return userIDs, nil
+}
+
+func (r *queriesRepository) HasBlocked(blockerID uuid.UUID, blockedID uuid.UUID) (bool, error) {
+	return r.queries.HasBlocked(context.Background(), db.HasBlockedParams{
+		BlockerID: uuidToPgtype(blockerID),
+		BlockedID: uuidToPgtype(blockedID),
+	})
+}
+
+func (r *queriesRepository) IsBlockedInDirectConversation(conversationID uuid.UUID, userID uuid.UUID) (bool, error) {
+	return r.queries.IsBlockedInDirectConversation(context.Background(), db.IsBlockedInDirectConversationParams{
+		UserID:         uuidToPgtype(userID),
+		ConversationID: uuidToPgtype(conversationID),
+	})
+}
+
+func (r *queriesRepository) GetParticipantIDsByNames(conversationID uuid.UUID, names []string) ([]uuid.UUID, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.queries.GetParticipantIDsByNames(context.Background(), db.GetParticipantIDsByNamesParams{
+		ConversationID: uuidToPgtype(conversationID),
+		Names:          names,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = pgtypeToUUID(row)
+	}
+
+	return ids, nil
+}
+
+func (r *queriesRepository) GetMemberRole(conversationID uuid.UUID, userID uuid.UUID) (string, error) {
+	role, err := r.queries.GetMemberRole(context.Background(), db.GetMemberRoleParams{
+		ConversationID: uuidToPgtype(conversationID),
+		UserID:         uuidToPgtype(userID),
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", domain.ErrorUserNotInConversation
+		}
+		return "", err
+	}
+
+	return role, nil
+}
+
+func (r *queriesRepository) GetLongestStandingMember(conversationID uuid.UUID, excludeUserID uuid.UUID) (uuid.UUID, error) {
+	userID, err := r.queries.GetLongestStandingMember(context.Background(), db.GetLongestStandingMemberParams{
+		ConversationID: uuidToPgtype(conversationID),
+		UserID:         uuidToPgtype(excludeUserID),
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, domain.ErrorNoSuccessor
+		}
+		return uuid.Nil, err
+	}
+
+	return pgtypeToUUID(userID), nil
+}
+
 func (r *queriesRepository) RenameConversationAndReturn(conversationID uuid.UUID, name string) error {
 	rowsAffected, err := r.queries.RenameConversationAndReturn(context.Background(), db.RenameConversationAndReturnParams{
 		ConversationID: uuidToPgtype(conversationID),
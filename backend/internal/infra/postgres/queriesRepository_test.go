@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"GitHub/go-chat/backend/internal/readModel"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiveMessageIDs(t *testing.T) {
+	deletedAt := time.Now()
+	live := readModel.MessageDTO{ID: uuid.New()}
+	tombstone := readModel.MessageDTO{ID: uuid.New(), DeletedAt: &deletedAt}
+
+	t.Run("skips tombstones", func(t *testing.T) {
+		assert.Equal(t, []uuid.UUID{live.ID}, liveMessageIDs([]readModel.MessageDTO{tombstone, live}))
+	})
+
+	t.Run("only tombstones", func(t *testing.T) {
+		assert.Empty(t, liveMessageIDs([]readModel.MessageDTO{tombstone}))
+	})
+}
+
+func TestLikeEscaper(t *testing.T) {
+	assert.Equal(t, `100\% \_real\_ a\\b`, likeEscaper.Replace(`100% _real_ a\b`))
+}
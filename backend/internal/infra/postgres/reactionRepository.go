@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/infra/postgres/db"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type reactionRepository struct {
+	*repository
+}
+
+func NewReactionRepository(pool *pgxpool.Pool) *reactionRepository {
+	return &reactionRepository{
+		repository: newRepository(pool, db.New(pool)),
+	}
+}
+
+func (r *reactionRepository) Add(ctx context.Context, reaction *domain.Reaction) error {
+	params := db.AddMessageReactionParams{
+		MessageID: uuidToPgtype(reaction.MessageID),
+		UserID:    uuidToPgtype(reaction.UserID),
+		Emoji:     reaction.Emoji,
+	}
+
+	if err := r.queries.AddMessageReaction(ctx, params); err != nil {
+		return fmt.Errorf("add reaction error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reactionRepository) Remove(ctx context.Context, reaction *domain.Reaction) error {
+	params := db.RemoveMessageReactionParams{
+		MessageID: uuidToPgtype(reaction.MessageID),
+		UserID:    uuidToPgtype(reaction.UserID),
+		Emoji:     reaction.Emoji,
+	}
+
+	if err := r.queries.RemoveMessageReaction(ctx, params); err != nil {
+		return fmt.Errorf("remove reaction error: %w", err)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"GitHub/go-chat/backend/internal/domain"
 	"GitHub/go-chat/backend/internal/infra/postgres/db"
@@ -13,6 +14,9 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// uniqueViolationCode is the Postgres error code for a unique constraint violation.
+const uniqueViolationCode = "23505"
+
 type repository struct {
 	pool    *pgxpool.Pool
 	queries *db.Queries
@@ -53,6 +57,58 @@ func pgtypeToUUID(u pgtype.UUID) uuid.UUID {
 	return uuid.UUID(u.Bytes)
 }
 
+func uuidPtrToPgtype(u *uuid.UUID) pgtype.UUID {
+	if u == nil {
+		return pgtype.UUID{}
+	}
+	return uuidToPgtype(*u)
+}
+
+func pgtypeToUUIDPtr(u pgtype.UUID) *uuid.UUID {
+	if !u.Valid {
+		return nil
+	}
+	id := uuid.UUID(u.Bytes)
+	return &id
+}
+
+func intToPgtype(value int) pgtype.Int4 {
+	return pgtype.Int4{Int32: int32(value), Valid: value != 0}
+}
+
+func intPtrToPgtype(value *int) pgtype.Int4 {
+	if value == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: int32(*value), Valid: true}
+}
+
+func pgtypeToIntPtr(value pgtype.Int4) *int {
+	if !value.Valid {
+		return nil
+	}
+	result := int(value.Int32)
+	return &result
+}
+
+func stringToPgtype(value string) pgtype.Text {
+	return pgtype.Text{String: value, Valid: value != ""}
+}
+
+func timePtrToPgtype(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
+
+func pgtypeToTimePtr(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
 var conversationTypesMap = map[uint8]domain.ConversationType{
 	0: domain.ConversationTypeGroup,
 	1: domain.ConversationTypeDirect,
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/infra/postgres/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type scheduledMessageRepository struct {
+	*repository
+}
+
+func NewScheduledMessageRepository(pool *pgxpool.Pool) *scheduledMessageRepository {
+	return &scheduledMessageRepository{
+		repository: newRepository(pool, db.New(pool)),
+	}
+}
+
+func (r *scheduledMessageRepository) Store(ctx context.Context, message *domain.ScheduledMessage) error {
+	if err := r.queries.StoreScheduledMessage(ctx, db.StoreScheduledMessageParams{
+		ID:             uuidToPgtype(message.ID),
+		ConversationID: uuidToPgtype(message.ConversationID),
+		UserID:         uuidToPgtype(message.UserID),
+		Content:        message.Content.String(),
+		ScheduledAt:    pgtype.Timestamptz{Time: message.ScheduledAt, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("store scheduled message error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *scheduledMessageRepository) Update(ctx context.Context, message *domain.ScheduledMessage) error {
+	rowsAffected, err := r.queries.UpdateScheduledMessage(ctx, db.UpdateScheduledMessageParams{
+		ID:          uuidToPgtype(message.ID),
+		Content:     message.Content.String(),
+		ScheduledAt: pgtype.Timestamptz{Time: message.ScheduledAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("update scheduled message error: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrorScheduledMessageNotFound
+	}
+
+	return nil
+}
+
+func (r *scheduledMessageRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	rowsAffected, err := r.queries.DeleteScheduledMessage(ctx, uuidToPgtype(id))
+	if err != nil {
+		return fmt.Errorf("delete scheduled message error: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrorScheduledMessageNotFound
+	}
+
+	return nil
+}
+
+func (r *scheduledMessageRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ScheduledMessage, error) {
+	message, err := r.queries.GetScheduledMessageByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrorScheduledMessageNotFound
+		}
+		return nil, fmt.Errorf("get scheduled message error: %w", err)
+	}
+
+	return toScheduledMessageDomain(message)
+}
+
+func (r *scheduledMessageRepository) ClaimDue(ctx context.Context, now time.Time, limit int, retryBase time.Duration, retryMax time.Duration) ([]*domain.ScheduledMessage, error) {
+	rows, err := r.queries.ClaimDueScheduledMessages(ctx, db.ClaimDueScheduledMessagesParams{
+		Now:              pgtype.Timestamptz{Time: now, Valid: true},
+		RetryBaseSeconds: retryBase.Seconds(),
+		RetryMaxSeconds:  retryMax.Seconds(),
+		ClaimLimit:       int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("claim scheduled messages error: %w", err)
+	}
+
+	messages := make([]*domain.ScheduledMessage, 0, len(rows))
+	for _, row := range rows {
+		message, err := toScheduledMessageDomain(row)
+		if err != nil {
+			if err := r.RecordFailure(ctx, pgtypeToUUID(row.ID), err.Error(), &now); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+func (r *scheduledMessageRepository) RecordFailure(ctx context.Context, id uuid.UUID, reason string, failedAt *time.Time) error {
+	if err := r.queries.RecordScheduledMessageFailure(ctx, db.RecordScheduledMessageFailureParams{
+		ID:        uuidToPgtype(id),
+		LastError: stringToPgtype(reason),
+		FailedAt:  timePtrToPgtype(failedAt),
+	}); err != nil {
+		return fmt.Errorf("record scheduled message failure error: %w", err)
+	}
+
+	return nil
+}
+
+func toScheduledMessageDomain(message db.ScheduledMessage) (*domain.ScheduledMessage, error) {
+	return &domain.ScheduledMessage{
+		ID:             pgtypeToUUID(message.ID),
+		ConversationID: pgtypeToUUID(message.ConversationID),
+		UserID:         pgtypeToUUID(message.UserID),
+		Content:        domain.RestoreTextMessageContent(message.Content),
+		ScheduledAt:    message.ScheduledAt.Time,
+		Attempts:       int(message.Attempts),
+	}, nil
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"GitHub/go-chat/backend/internal/domain"
 	"GitHub/go-chat/backend/internal/infra/postgres/db"
@@ -96,3 +97,16 @@ func (r *userRepository) UpdateRefreshToken(ctx context.Context, id uuid.UUID, r
 
 	return nil
 }
+
+func (r *userRepository) UpdateLastSeen(ctx context.Context, id uuid.UUID, lastSeenAt time.Time) error {
+	params := db.UpdateUserLastSeenParams{
+		ID:         uuidToPgtype(id),
+		LastSeenAt: pgtype.Timestamptz{Time: lastSeenAt, Valid: true},
+	}
+
+	if err := r.queries.UpdateUserLastSeen(ctx, params); err != nil {
+		return fmt.Errorf("update last seen error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type Message struct {
+	Channel string
+	Payload string
+}
+
+type Subscription interface {
+	Messages() <-chan Message
+	Close() error
+}
+
+type broker struct {
+	client *redis.Client
+}
+
+func NewBroker(client *redis.Client) *broker {
+	return &broker{
+		client: client,
+	}
+}
+
+func (b *broker) Publish(ctx context.Context, channel string, payload []byte) error {
+	if err := b.client.Publish(ctx, channel, payload).Err(); err != nil {
+		return fmt.Errorf("redis publish error: %w", err)
+	}
+
+	return nil
+}
+
+func (b *broker) Subscribe(ctx context.Context, channels ...string) (Subscription, error) {
+	pubsub := b.client.Subscribe(ctx, channels...)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, fmt.Errorf("redis subscribe error: %w", err)
+	}
+
+	subscription := &subscription{
+		pubsub:   pubsub,
+		messages: make(chan Message, 100),
+	}
+
+	go subscription.forward(ctx)
+
+	return subscription, nil
+}
+
+type subscription struct {
+	pubsub   *redis.PubSub
+	messages chan Message
+}
+
+func (s *subscription) forward(ctx context.Context) {
+	defer close(s.messages)
+
+	incoming := s.pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-incoming:
+			if !ok {
+				return
+			}
+
+			select {
+			case s.messages <- Message{Channel: msg.Channel, Payload: msg.Payload}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func (s *subscription) Messages() <-chan Message {
+	return s.messages
+}
+
+func (s *subscription) Close() error {
+	return s.pubsub.Close()
+}
@@ -0,0 +1,105 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ws "GitHub/go-chat/backend/internal/websocket"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+const EventLogKeyPrefix = "events"
+
+var appendEventScript = redis.NewScript(`
+local seq = redis.call('INCR', KEYS[1])
+redis.call('XADD', KEYS[2], 'MAXLEN', '~', ARGV[1], seq .. '-0', 'event', ARGV[2])
+redis.call('PEXPIRE', KEYS[2], ARGV[3])
+return seq
+`)
+
+type eventLog struct {
+	client    *redis.Client
+	maxLength int64
+	ttl       time.Duration
+}
+
+func NewEventLog(client *redis.Client, maxLength int64, ttl time.Duration) *eventLog {
+	return &eventLog{
+		client:    client,
+		maxLength: maxLength,
+		ttl:       ttl,
+	}
+}
+
+func EventSeqKey(conversationID uuid.UUID) string {
+	return fmt.Sprintf("%s:%s:seq", EventLogKeyPrefix, conversationID)
+}
+
+func EventStreamKey(conversationID uuid.UUID) string {
+	return fmt.Sprintf("%s:%s", EventLogKeyPrefix, conversationID)
+}
+
+func (l *eventLog) Append(ctx context.Context, conversationID uuid.UUID, notification ws.OutgoingNotification) (ws.OutgoingNotification, error) {
+	notification.ConversationID = nil
+	notification.Seq = 0
+
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return notification, fmt.Errorf("json marshal error: %w", err)
+	}
+
+	keys := []string{EventSeqKey(conversationID), EventStreamKey(conversationID)}
+	seq, err := appendEventScript.Run(ctx, l.client, keys, l.maxLength, data, l.ttl.Milliseconds()).Int64()
+	if err != nil {
+		return notification, fmt.Errorf("redis append event error: %w", err)
+	}
+
+	notification.ConversationID = &conversationID
+	notification.Seq = seq
+
+	return notification, nil
+}
+
+func (l *eventLog) ReadAfter(ctx context.Context, conversationID uuid.UUID, afterSeq int64, limit int) ([]ws.OutgoingNotification, int64, error) {
+	pipe := l.client.Pipeline()
+	lastSeqCmd := pipe.Get(ctx, EventSeqKey(conversationID))
+	rangeCmd := pipe.XRangeN(ctx, EventStreamKey(conversationID), fmt.Sprintf("%d-0", afterSeq+1), "+", int64(limit))
+
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, 0, fmt.Errorf("redis read events error: %w", err)
+	}
+
+	lastSeq, err := lastSeqCmd.Int64()
+	if err != nil && err != redis.Nil {
+		return nil, 0, fmt.Errorf("parse last seq error: %w", err)
+	}
+
+	entries := rangeCmd.Val()
+	notifications := make([]ws.OutgoingNotification, 0, len(entries))
+	for _, entry := range entries {
+		seqPart, _, _ := strings.Cut(entry.ID, "-")
+		seq, err := strconv.ParseInt(seqPart, 10, 64)
+		if err != nil {
+			return nil, 0, fmt.Errorf("parse event id error: %w", err)
+		}
+
+		payload, _ := entry.Values["event"].(string)
+
+		var notification ws.OutgoingNotification
+		if err := json.Unmarshal([]byte(payload), &notification); err != nil {
+			return nil, 0, fmt.Errorf("json unmarshal error: %w", err)
+		}
+
+		notification.ConversationID = &conversationID
+		notification.Seq = seq
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, lastSeq, nil
+}
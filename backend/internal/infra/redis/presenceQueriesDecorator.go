@@ -0,0 +1,107 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+
+	"GitHub/go-chat/backend/internal/readModel"
+	"GitHub/go-chat/backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type PresenceQueriesDecorator struct {
+	readModel.QueriesRepository
+	presence repository.PresenceRepository
+}
+
+func NewPresenceQueriesDecorator(queries readModel.QueriesRepository, presence repository.PresenceRepository) *PresenceQueriesDecorator {
+	return &PresenceQueriesDecorator{
+		QueriesRepository: queries,
+		presence:          presence,
+	}
+}
+
+func (d *PresenceQueriesDecorator) GetContacts(userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error) {
+	contacts, err := d.QueriesRepository.GetContacts(userID, paginationInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.attachContactsPresence(contacts)
+}
+
+func (d *PresenceQueriesDecorator) GetPotentialInvitees(conversationID uuid.UUID, userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error) {
+	contacts, err := d.QueriesRepository.GetPotentialInvitees(conversationID, userID, paginationInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.attachContactsPresence(contacts)
+}
+
+func (d *PresenceQueriesDecorator) GetParticipants(conversationID uuid.UUID, userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error) {
+	contacts, err := d.QueriesRepository.GetParticipants(conversationID, userID, paginationInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.attachContactsPresence(contacts)
+}
+
+func (d *PresenceQueriesDecorator) GetUserByID(userID uuid.UUID) (readModel.UserDTO, error) {
+	user, err := d.QueriesRepository.GetUserByID(userID)
+	if err != nil {
+		return readModel.UserDTO{}, err
+	}
+
+	statuses, err := d.presence.GetStatuses(context.Background(), []uuid.UUID{user.ID})
+	if err != nil {
+		return readModel.UserDTO{}, fmt.Errorf("get presence error: %w", err)
+	}
+
+	user.Presence = statuses[user.ID].String()
+
+	return user, nil
+}
+
+func (d *PresenceQueriesDecorator) GetUsersByIDs(userIDs []uuid.UUID) ([]readModel.UserDTO, error) {
+	users, err := d.QueriesRepository.GetUsersByIDs(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+
+	statuses, err := d.presence.GetStatuses(context.Background(), ids)
+	if err != nil {
+		return nil, fmt.Errorf("get presence error: %w", err)
+	}
+
+	for i := range users {
+		users[i].Presence = statuses[users[i].ID].String()
+	}
+
+	return users, nil
+}
+
+func (d *PresenceQueriesDecorator) attachContactsPresence(contacts []readModel.ContactDTO) ([]readModel.ContactDTO, error) {
+	ids := make([]uuid.UUID, len(contacts))
+	for i, contact := range contacts {
+		ids[i] = contact.ID
+	}
+
+	statuses, err := d.presence.GetStatuses(context.Background(), ids)
+	if err != nil {
+		return nil, fmt.Errorf("get presence error: %w", err)
+	}
+
+	for i := range contacts {
+		contacts[i].Presence = statuses[contacts[i].ID].String()
+	}
+
+	return contacts, nil
+}
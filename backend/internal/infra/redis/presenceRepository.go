@@ -0,0 +1,124 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+const PresenceKeyPrefix = "presence"
+
+type presenceRepository struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewPresenceRepository(client *redis.Client, ttl time.Duration) *presenceRepository {
+	return &presenceRepository{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+func PresenceKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s:%s", PresenceKeyPrefix, userID)
+}
+
+func PresenceExpiredKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s:expired:%s", PresenceKeyPrefix, userID)
+}
+
+func (r *presenceRepository) SetStatus(ctx context.Context, userID uuid.UUID, serverID string, status domain.PresenceStatus) error {
+	key := PresenceKey(userID)
+	value := fmt.Sprintf("%s|%d", status, time.Now().Add(r.ttl).Unix())
+
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, serverID, value)
+	pipe.Expire(ctx, key, r.ttl)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis set presence error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *presenceRepository) Remove(ctx context.Context, userID uuid.UUID, serverID string) error {
+	if err := r.client.HDel(ctx, PresenceKey(userID), serverID).Err(); err != nil {
+		return fmt.Errorf("redis remove presence error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *presenceRepository) GetStatuses(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]domain.PresenceStatus, error) {
+	statuses := make(map[uuid.UUID]domain.PresenceStatus, len(userIDs))
+	if len(userIDs) == 0 {
+		return statuses, nil
+	}
+
+	pipe := r.client.Pipeline()
+	commands := make([]*redis.StringStringMapCmd, len(userIDs))
+	for i, userID := range userIDs {
+		commands[i] = pipe.HGetAll(ctx, PresenceKey(userID))
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("redis get presence error: %w", err)
+	}
+
+	now := time.Now().Unix()
+	for i, userID := range userIDs {
+		servers := commands[i].Val()
+
+		nodeStatuses := make([]domain.PresenceStatus, 0, len(servers))
+		for _, value := range servers {
+			status, expiresAt, err := parsePresenceValue(value)
+			if err != nil || expiresAt < now {
+				continue
+			}
+			nodeStatuses = append(nodeStatuses, status)
+		}
+
+		statuses[userID] = domain.AggregatePresence(nodeStatuses)
+	}
+
+	return statuses, nil
+}
+
+// ClaimExpired lets exactly one server announce that a user's presence entries
+// expired. The claim lasts one TTL, so a later expiry can be claimed again.
+func (r *presenceRepository) ClaimExpired(ctx context.Context, userID uuid.UUID, serverID string) (bool, error) {
+	claimed, err := r.client.SetNX(ctx, PresenceExpiredKey(userID), serverID, r.ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis claim expired presence error: %w", err)
+	}
+
+	return claimed, nil
+}
+
+func parsePresenceValue(value string) (domain.PresenceStatus, int64, error) {
+	slug, expiresAt, found := strings.Cut(value, "|")
+	if !found {
+		return domain.PresenceStatus{}, 0, fmt.Errorf("malformed presence value %q", value)
+	}
+
+	status, err := domain.NewPresenceStatus(slug)
+	if err != nil {
+		return domain.PresenceStatus{}, 0, err
+	}
+
+	expiresAtUnix, err := strconv.ParseInt(expiresAt, 10, 64)
+	if err != nil {
+		return domain.PresenceStatus{}, 0, fmt.Errorf("parse presence expiry error: %w", err)
+	}
+
+	return status, expiresAtUnix, nil
+}
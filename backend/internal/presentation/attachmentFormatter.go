@@ -0,0 +1,36 @@
+package presentation
+
+import (
+	"fmt"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+)
+
+const attachmentDownloadPath = "/api/downloadAttachment"
+
+func FormatAttachmentDTO(attachment *domain.Attachment) *readModel.AttachmentDTO {
+	if attachment == nil {
+		return nil
+	}
+
+	dto := &readModel.AttachmentDTO{
+		ID:          attachment.ID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Status:      attachment.Status.String(),
+		URL:         fmt.Sprintf("%s?attachment_id=%s", attachmentDownloadPath, attachment.ID),
+		Width:       attachment.Width,
+		Height:      attachment.Height,
+		Blurhash:    attachment.Blurhash,
+	}
+
+	if attachment.Thumbnail != nil {
+		dto.ThumbnailURL = fmt.Sprintf("%s?attachment_id=%s&variant=thumbnail", attachmentDownloadPath, attachment.ID)
+		dto.ThumbnailWidth = attachment.Thumbnail.Width
+		dto.ThumbnailHeight = attachment.Thumbnail.Height
+	}
+
+	return dto
+}
@@ -52,6 +52,12 @@ func (f *MessageFormatter) FormatMessageDTO(rawMessage readModel.RawMessageDTO)
 			Avatar: rawMessage.UserAvatar,
 			Name:   rawMessage.UserName,
 		},
+		EditedAt:      rawMessage.EditedAt,
+		RevisionCount: rawMessage.RevisionCount,
+		DeletedAt:     rawMessage.DeletedAt,
+		ParentID:      rawMessage.ParentID,
+		ReplyCount:    rawMessage.ReplyCount,
+		LastReplyAt:   rawMessage.LastReplyAt,
 	}
 
 	messageDTO.Text = f.FormatMessageText(messageType, rawMessage.Content, rawMessage.UserName)
@@ -12,39 +12,180 @@ type ConversationDTO struct {
 	Avatar      string     `json:"avatar"`
 	Type        string     `json:"type"`
 	LastMessage MessageDTO `json:"last_message"`
+	UnreadCount int64      `json:"unread_count"`
 }
 
 type ConversationFullDTO struct {
+	ID                uuid.UUID         `json:"id"`
+	Name              string            `json:"name"`
+	Avatar            string            `json:"avatar"`
+	CreatedAt         time.Time         `json:"created_at"`
+	Type              string            `json:"type"`
+	HasJoined         bool              `json:"joined,omitempty"`
+	ParticipantsCount int64             `json:"participants_count,omitempty"`
+	IsOwner           bool              `json:"is_owner,omitempty"`
+	Role              string            `json:"role,omitempty"`
+	Permissions       []string          `json:"permissions,omitempty"`
+	Visibility        string            `json:"visibility,omitempty"`
+	NotificationLevel string            `json:"notification_level,omitempty"`
+	MutedUntil        *time.Time        `json:"muted_until,omitempty"`
+	PinnedCount       int64             `json:"pinned_count,omitempty"`
+	LatestPin         *PinnedMessageDTO `json:"latest_pin,omitempty"`
+	MessageTTL        int32             `json:"message_ttl,omitempty"`
+}
+
+// ConversationUpdatedDTO is the conversation_updated payload. It holds only the
+// fields every member sees the same way, so it is safe to broadcast.
+type ConversationUpdatedDTO struct {
 	ID                uuid.UUID `json:"id"`
 	Name              string    `json:"name"`
 	Avatar            string    `json:"avatar"`
 	CreatedAt         time.Time `json:"created_at"`
 	Type              string    `json:"type"`
-	HasJoined         bool      `json:"joined,omitempty"`
 	ParticipantsCount int64     `json:"participants_count,omitempty"`
-	IsOwner           bool      `json:"is_owner,omitempty"`
+	Visibility        string    `json:"visibility,omitempty"`
+	PinnedCount       int64     `json:"pinned_count,omitempty"`
+	MessageTTL        int32     `json:"message_ttl,omitempty"`
+}
+
+type PinnedMessageDTO struct {
+	Message  MessageDTO `json:"message"`
+	PinnedBy UserDTO    `json:"pinned_by"`
+	PinnedAt time.Time  `json:"pinned_at"`
+}
+
+type NotificationSettingsDTO struct {
+	ConversationID    uuid.UUID  `json:"conversation_id"`
+	NotificationLevel string     `json:"notification_level"`
+	MutedUntil        *time.Time `json:"muted_until"`
+}
+
+type PublicGroupDTO struct {
+	ID                uuid.UUID `json:"id"`
+	Name              string    `json:"name"`
+	Avatar            string    `json:"avatar"`
+	CreatedAt         time.Time `json:"created_at"`
+	ParticipantsCount int64     `json:"participants_count"`
+	HasJoined         bool      `json:"joined"`
 }
 
 type UserDTO struct {
-	ID     uuid.UUID `json:"id"`
-	Avatar string    `json:"avatar"`
-	Name   string    `json:"name"`
+	ID         uuid.UUID  `json:"id"`
+	Avatar     string     `json:"avatar"`
+	Name       string     `json:"name"`
+	Presence   string     `json:"presence,omitempty"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
 }
 
 type ContactDTO struct {
-	ID     uuid.UUID `json:"id"`
-	Avatar string    `json:"avatar"`
-	Name   string    `json:"name"`
+	ID         uuid.UUID  `json:"id"`
+	Avatar     string     `json:"avatar"`
+	Name       string     `json:"name"`
+	Presence   string     `json:"presence,omitempty"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+	Role       string     `json:"role,omitempty"`
 }
 
-type MessageDTO struct {
+type InviteLinkDTO struct {
+	ID             uuid.UUID  `json:"id"`
+	ConversationID uuid.UUID  `json:"conversation_id"`
+	Token          string     `json:"token"`
+	Creator        UserDTO    `json:"creator"`
+	MaxUses        *int       `json:"max_uses,omitempty"`
+	UseCount       int        `json:"use_count"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type JoinRequestDTO struct {
 	ID             uuid.UUID `json:"id"`
+	ConversationID uuid.UUID `json:"conversation_id"`
+	User           UserDTO   `json:"user"`
 	CreatedAt      time.Time `json:"created_at"`
-	Text           string    `json:"text,omitempty"`
-	Type           string    `json:"type"`
-	UserID         uuid.UUID `json:"user_id"`
-	User           *UserDTO  `json:"user,omitempty"`
-	ConversationId uuid.UUID `json:"conversation_id"`
+}
+
+type MessageDTO struct {
+	ID             uuid.UUID         `json:"id"`
+	CreatedAt      time.Time         `json:"created_at"`
+	Text           string            `json:"text,omitempty"`
+	Type           string            `json:"type"`
+	UserID         uuid.UUID         `json:"user_id"`
+	User           *UserDTO          `json:"user,omitempty"`
+	ConversationId uuid.UUID         `json:"conversation_id"`
+	EditedAt       *time.Time        `json:"edited_at,omitempty"`
+	RevisionCount  int64             `json:"revision_count,omitempty"`
+	DeletedAt      *time.Time        `json:"deleted_at,omitempty"`
+	ParentID       *uuid.UUID        `json:"parent_id,omitempty"`
+	ReplyCount     int64             `json:"reply_count,omitempty"`
+	LastReplyAt    *time.Time        `json:"last_reply_at,omitempty"`
+	Reactions      []ReactionDTO     `json:"reactions,omitempty"`
+	Attachment     *AttachmentDTO    `json:"attachment,omitempty"`
+	Mentions       []uuid.UUID       `json:"mentions,omitempty"`
+	ForwardedFrom  *ForwardedFromDTO `json:"forwarded_from,omitempty"`
+	Poll           *PollDTO          `json:"poll,omitempty"`
+}
+
+type ForwardedFromDTO struct {
+	MessageID      *uuid.UUID `json:"message_id,omitempty"`
+	ConversationID *uuid.UUID `json:"conversation_id,omitempty"`
+	User           UserDTO    `json:"user"`
+}
+
+type ScheduledMessageDTO struct {
+	ID             uuid.UUID  `json:"id"`
+	ConversationID uuid.UUID  `json:"conversation_id"`
+	Text           string     `json:"text"`
+	ScheduledAt    time.Time  `json:"scheduled_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	FailedAt       *time.Time `json:"failed_at,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+}
+
+type AttachmentDTO struct {
+	ID              uuid.UUID `json:"id"`
+	FileName        string    `json:"file_name"`
+	ContentType     string    `json:"content_type"`
+	Size            int64     `json:"size"`
+	Status          string    `json:"status"`
+	URL             string    `json:"url"`
+	Width           int       `json:"width,omitempty"`
+	Height          int       `json:"height,omitempty"`
+	Blurhash        string    `json:"blurhash,omitempty"`
+	ThumbnailURL    string    `json:"thumbnail_url,omitempty"`
+	ThumbnailWidth  int       `json:"thumbnail_width,omitempty"`
+	ThumbnailHeight int       `json:"thumbnail_height,omitempty"`
+}
+
+type PollDTO struct {
+	ID             uuid.UUID       `json:"id"`
+	MessageID      uuid.UUID       `json:"message_id"`
+	Question       string          `json:"question"`
+	MultipleChoice bool            `json:"multiple_choice"`
+	Anonymous      bool            `json:"anonymous"`
+	ClosedAt       *time.Time      `json:"closed_at,omitempty"`
+	TotalVoters    int64           `json:"total_voters"`
+	Options        []PollOptionDTO `json:"options"`
+}
+
+type PollOptionDTO struct {
+	ID        uuid.UUID   `json:"id"`
+	Text      string      `json:"text"`
+	VoteCount int64       `json:"vote_count"`
+	UserIDs   []uuid.UUID `json:"user_ids,omitempty"`
+}
+
+type ReactionDTO struct {
+	Emoji   string      `json:"emoji"`
+	Count   int64       `json:"count"`
+	UserIDs []uuid.UUID `json:"user_ids"`
+}
+
+type MessageRevisionDTO struct {
+	ID        uuid.UUID `json:"id"`
+	MessageID uuid.UUID `json:"message_id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type ConversationUsersResponse struct {
@@ -60,6 +201,12 @@ type RawMessageDTO struct {
 	UserID         uuid.UUID
 	UserName       string
 	UserAvatar     string
+	EditedAt       *time.Time
+	RevisionCount  int64
+	DeletedAt      *time.Time
+	ParentID       *uuid.UUID
+	ReplyCount     int64
+	LastReplyAt    *time.Time
 }
 
 type RawLastMessageDTO struct {
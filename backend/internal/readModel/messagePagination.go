@@ -16,3 +16,25 @@ type MessagePageDTO struct {
 	NextCursor string       `json:"next_cursor,omitempty"`
 	HasMore    bool         `json:"has_more"`
 }
+
+type MessageSearchCursor struct {
+	Rank      float32
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+type MessageSearchResultDTO struct {
+	ID             uuid.UUID  `json:"id"`
+	ConversationID uuid.UUID  `json:"conversation_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	ParentID       *uuid.UUID `json:"parent_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Snippet        string     `json:"snippet"`
+	Rank           float32    `json:"rank"`
+}
+
+type MessageSearchPageDTO struct {
+	Results    []MessageSearchResultDTO `json:"results"`
+	NextCursor string                   `json:"next_cursor,omitempty"`
+	HasMore    bool                     `json:"has_more"`
+}
@@ -11,7 +11,8 @@ type PaginationInfo interface {
 
 type userQueryRepository interface {
 	GetContacts(userID uuid.UUID, paginationInfo PaginationInfo) ([]ContactDTO, error)
-	GetPotentialInvitees(conversationID uuid.UUID, paginationInfo PaginationInfo) ([]ContactDTO, error)
+	GetPotentialInvitees(conversationID uuid.UUID, userID uuid.UUID, paginationInfo PaginationInfo) ([]ContactDTO, error)
+	GetBlockedUsers(userID uuid.UUID, paginationInfo PaginationInfo) ([]ContactDTO, error)
 	GetParticipants(conversationID uuid.UUID, userID uuid.UUID, paginationInfo PaginationInfo) ([]ContactDTO, error)
 	GetUserByID(userID uuid.UUID) (UserDTO, error)
 	GetUsersByIDs(userIDs []uuid.UUID) ([]UserDTO, error)
@@ -20,18 +21,37 @@ type userQueryRepository interface {
 type conversationQueryRepository interface {
 	GetConversation(id uuid.UUID, userID uuid.UUID) (ConversationFullDTO, error)
 	GetUserConversations(userID uuid.UUID, paginationInfo PaginationInfo) ([]ConversationDTO, error)
+	GetPublicGroups(userID uuid.UUID, search string, paginationInfo PaginationInfo) ([]PublicGroupDTO, error)
 	RenameConversationAndReturn(conversationID uuid.UUID, name string) error
+	GetActiveInviteLinks(conversationID uuid.UUID) ([]InviteLinkDTO, error)
+	GetPendingJoinRequests(conversationID uuid.UUID) ([]JoinRequestDTO, error)
+	GetPinnedMessages(conversationID uuid.UUID, userID uuid.UUID, paginationInfo PaginationInfo) ([]PinnedMessageDTO, error)
 }
 
 type messageQueryRepository interface {
-	GetConversationMessages(conversationID uuid.UUID, cursor *MessageCursor, limit int) (MessagePageDTO, error)
+	GetConversationMessages(conversationID uuid.UUID, userID uuid.UUID, cursor *MessageCursor, limit int) (MessagePageDTO, error)
+	GetThreadMessages(threadID uuid.UUID, userID uuid.UUID, cursor *MessageCursor, limit int) (MessagePageDTO, error)
+	GetMentions(userID uuid.UUID, cursor *MessageCursor, limit int) (MessagePageDTO, error)
+	GetScheduledMessages(userID uuid.UUID, conversationID *uuid.UUID, paginationInfo PaginationInfo) ([]ScheduledMessageDTO, error)
 	GetNotificationMessage(messageID uuid.UUID) (MessageDTO, error)
+	GetMessageRevisions(messageID uuid.UUID, userID uuid.UUID) ([]MessageRevisionDTO, error)
+	SearchMessages(userID uuid.UUID, conversationID *uuid.UUID, query string, cursor *MessageSearchCursor, limit int) (MessageSearchPageDTO, error)
+	GetMessageReactions(messageID uuid.UUID) ([]ReactionDTO, error)
+	GetPoll(messageID uuid.UUID) (PollDTO, error)
 	StoreMessageAndReturn(id uuid.UUID, conversationID uuid.UUID, userID uuid.UUID, content string, messageType int32) (MessageDTO, error)
 }
 
 type authorizationQueryRepository interface {
 	IsMember(conversationID uuid.UUID, userID uuid.UUID) (bool, error)
 	IsMemberOwner(conversationID uuid.UUID, userID uuid.UUID) (bool, error)
+	GetMemberRole(conversationID uuid.UUID, userID uuid.UUID) (string, error)
+	GetGroupVisibility(conversationID uuid.UUID) (string, error)
+	HasBlocked(blockerID uuid.UUID, blockedID uuid.UUID) (bool, error)
+	IsBlockedInDirectConversation(conversationID uuid.UUID, userID uuid.UUID) (bool, error)
+	IsMessageHidden(messageID uuid.UUID, userID uuid.UUID) (bool, error)
+	GetMemberIDsByRoles(conversationID uuid.UUID, roles []string) ([]uuid.UUID, error)
+	GetParticipantIDsByNames(conversationID uuid.UUID, names []string) ([]uuid.UUID, error)
+	GetLongestStandingMember(conversationID uuid.UUID, excludeUserID uuid.UUID) (uuid.UUID, error)
 	InviteToConversationAtomic(conversationID uuid.UUID, inviteeID uuid.UUID, participantID uuid.UUID) (uuid.UUID, error)
 	LeaveConversationAtomic(conversationID uuid.UUID, userID uuid.UUID) (int64, error)
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"GitHub/go-chat/backend/internal/domain"
 	"GitHub/go-chat/backend/internal/readModel"
@@ -14,10 +15,58 @@ type UserRepository interface {
 	Update(ctx context.Context, user *domain.User) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
 	FindByUsername(ctx context.Context, username string) (*domain.User, error)
+	UpdateLastSeen(ctx context.Context, id uuid.UUID, lastSeenAt time.Time) error
+}
+
+type BlockRepository interface {
+	Store(ctx context.Context, block *domain.Block) error
+	Delete(ctx context.Context, blockerID uuid.UUID, blockedID uuid.UUID) error
 }
 
 type MessageRepository interface {
 	Send(ctx context.Context, message *domain.Message) (readModel.MessageDTO, error)
+	Edit(ctx context.Context, message *domain.Message) (readModel.MessageDTO, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Message, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Hide(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) error
+	DeleteExpired(ctx context.Context, limit int) ([]domain.ExpiredMessage, error)
+}
+
+type PinRepository interface {
+	Store(ctx context.Context, pin *domain.Pin, limit int) error
+	Delete(ctx context.Context, conversationID uuid.UUID, messageID uuid.UUID) error
+}
+
+type PollRepository interface {
+	Vote(ctx context.Context, vote *domain.PollVote) error
+	Close(ctx context.Context, poll *domain.Poll) error
+}
+
+type ScheduledMessageRepository interface {
+	Store(ctx context.Context, message *domain.ScheduledMessage) error
+	Update(ctx context.Context, message *domain.ScheduledMessage) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ScheduledMessage, error)
+	ClaimDue(ctx context.Context, now time.Time, limit int, retryBase time.Duration, retryMax time.Duration) ([]*domain.ScheduledMessage, error)
+	RecordFailure(ctx context.Context, id uuid.UUID, reason string, failedAt *time.Time) error
+}
+
+type AttachmentRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Attachment, error)
+	UpdateProcessing(ctx context.Context, attachment *domain.Attachment) error
+	GetProcessingIDs(ctx context.Context, createdBefore time.Time, limit int) ([]uuid.UUID, error)
+}
+
+type ReactionRepository interface {
+	Add(ctx context.Context, reaction *domain.Reaction) error
+	Remove(ctx context.Context, reaction *domain.Reaction) error
+}
+
+type PresenceRepository interface {
+	SetStatus(ctx context.Context, userID uuid.UUID, serverID string, status domain.PresenceStatus) error
+	Remove(ctx context.Context, userID uuid.UUID, serverID string) error
+	GetStatuses(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]domain.PresenceStatus, error)
+	ClaimExpired(ctx context.Context, userID uuid.UUID, serverID string) (bool, error)
 }
 
 type ParticipantRepository interface {
@@ -26,6 +75,24 @@ type ParticipantRepository interface {
 	GetByConversationIDAndUserID(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) (*domain.Participant, error)
 	GetIDsByConversationID(ctx context.Context, conversationID uuid.UUID) ([]uuid.UUID, error)
 	GetConversationIDsByUserID(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	MarkRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, messageID uuid.UUID) (bool, error)
+	UpdateRole(ctx context.Context, participant *domain.Participant) error
+	UpdateNotificationPreferences(ctx context.Context, participant *domain.Participant) error
+	GetNotificationPreferencesByUserID(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]domain.NotificationPreferences, error)
+}
+
+type InviteLinkRepository interface {
+	Store(ctx context.Context, link *domain.InviteLink) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.InviteLink, error)
+	Revoke(ctx context.Context, conversationID uuid.UUID, id uuid.UUID) error
+	Redeem(ctx context.Context, id uuid.UUID, participant *domain.Participant) error
+}
+
+type JoinRequestRepository interface {
+	Store(ctx context.Context, request *domain.JoinRequest) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.JoinRequest, error)
+	Approve(ctx context.Context, request *domain.JoinRequest, participant *domain.Participant) error
+	Reject(ctx context.Context, request *domain.JoinRequest) error
 }
 
 type DirectConversationRepository interface {
@@ -38,6 +105,10 @@ type GroupConversationRepository interface {
 	Store(ctx context.Context, conversation *domain.GroupConversation) error
 	Update(ctx context.Context, conversation *domain.GroupConversation) error
 	Rename(ctx context.Context, id uuid.UUID, name string) error
+	SetVisibility(ctx context.Context, id uuid.UUID, visibility domain.ConversationVisibility) error
+	SetMessageRetention(ctx context.Context, id uuid.UUID, retention domain.MessageRetention) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	TransferOwnership(ctx context.Context, previousOwner *domain.Participant, newOwner *domain.Participant) error
+	TransferOwnershipAndLeave(ctx context.Context, previousOwner *domain.Participant, newOwner *domain.Participant) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.GroupConversation, error)
 }
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/services"
+
+	"github.com/google/uuid"
+)
+
+func (s *Server) handleUploadAttachment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	if err := r.ParseMultipartForm(MaxMultipartMemory); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+	defer func() {
+		_ = r.MultipartForm.RemoveAll()
+	}()
+
+	conversationID, err := uuid.Parse(r.FormValue("conversation_id"))
+	if err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	message, err := s.attachment.Upload(r.Context(), conversationID, userID, services.AttachmentUpload{
+		FileName: header.Filename,
+		Caption:  r.FormValue("caption"),
+		Size:     header.Size,
+		Content:  file,
+	})
+
+	if err != nil {
+		returnError(w, attachmentErrorStatus(err), err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(message); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleDownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	attachmentID, err := uuid.Parse(r.URL.Query().Get("attachment_id"))
+	if err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	thumbnail := r.URL.Query().Get("variant") == "thumbnail"
+
+	attachment, content, err := s.attachment.Download(r.Context(), attachmentID, userID, thumbnail)
+	if err != nil {
+		returnError(w, attachmentErrorStatus(err), err)
+		return
+	}
+	defer func() {
+		_ = content.Close()
+	}()
+
+	disposition := "attachment"
+	if attachment.IsImage() {
+		disposition = "inline"
+	}
+
+	contentDisposition := mime.FormatMediaType(disposition, map[string]string{"filename": attachment.FileName})
+	if contentDisposition == "" {
+		contentDisposition = disposition
+	}
+
+	if thumbnail {
+		w.Header().Set("Content-Type", attachment.Thumbnail.ContentType)
+	} else {
+		w.Header().Set("Content-Type", attachment.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	}
+	w.Header().Set("Content-Disposition", contentDisposition)
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; sandbox")
+	w.Header().Set("Cache-Control", "private, max-age="+strconv.Itoa(AttachmentCacheMaxAgeSeconds))
+
+	if _, err := io.Copy(w, content); err != nil {
+		log.Printf("Error streaming attachment %s: %v", attachment.ID, err)
+	}
+}
+
+func attachmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorUserNotInConversation), errors.Is(err, domain.ErrorUserBlocked):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorAttachmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrorAttachmentNotReady):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrorAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, domain.ErrorAttachmentTypeNotAllowed):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, domain.ErrorAttachmentEmpty), errors.Is(err, domain.ErrorCaptionTooLong):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"GitHub/go-chat/backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+func (s *Server) handleBlockUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		UserId uuid.UUID `json:"user_id"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := s.block.Block(r.Context(), userID, request.UserId)
+
+	if err != nil {
+		returnError(w, blockErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleUnblockUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		UserId uuid.UUID `json:"user_id"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := s.block.Unblock(r.Context(), userID, request.UserId)
+
+	if err != nil {
+		returnError(w, blockErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleGetBlockedUsers(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	paginationInfo, ok := r.Context().Value(paginationKey).(pagination)
+
+	if !ok {
+		http.Error(w, "pagination info not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	users, err := s.block.GetBlockedUsers(userID, paginationInfo)
+
+	if err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(users); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func blockErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorUserBlocked):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorBlockNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrorCannotBlockSelf):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -17,12 +17,18 @@ func (s *Server) handleOpenWSConnection() http.HandlerFunc {
 			return
 		}
 
+		resume, err := parseResumeCursors(r.URL.Query().Get("resume"))
+		if err != nil {
+			returnError(w, http.StatusBadRequest, err)
+			return
+		}
+
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
 			returnError(w, http.StatusInternalServerError, err)
 			return
 		}
 
-		s.notificationCommands.RegisterClient(r.Context(), conn, userID)
+		s.notificationCommands.RegisterClient(r.Context(), conn, userID, resume)
 	}
 }
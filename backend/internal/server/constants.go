@@ -1,6 +1,13 @@
 package server
 
+import "GitHub/go-chat/backend/internal/domain"
+
 const (
-	HSTSMaxAgeSeconds  = 31536000
-	MaxRequestBodySize = 1 << 20
+	HSTSMaxAgeSeconds            = 31536000
+	MaxRequestBodySize           = 1 << 20
+	MaxResumeCursors             = 500
+	MaxSearchQueryLength         = 256
+	MaxMultipartMemory           = 1 << 20
+	MaxAttachmentRequestSize     = domain.MaxFileAttachmentSize + MaxRequestBodySize
+	AttachmentCacheMaxAgeSeconds = 86400
 )
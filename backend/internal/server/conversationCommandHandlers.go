@@ -1,8 +1,13 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
 
 	"github.com/google/uuid"
 )
@@ -27,7 +32,7 @@ func (s *Server) handleStartDirectConversation(w http.ResponseWriter, r *http.Re
 	conversationID, err := s.directConversation.StartDirectConversation(r.Context(), userID, request.ToUserID)
 
 	if err != nil {
-		returnError(w, http.StatusInternalServerError, err)
+		returnError(w, blockErrorStatus(err), err)
 		return
 	}
 
@@ -47,6 +52,7 @@ func (s *Server) handleCreateGroupConversation(w http.ResponseWriter, r *http.Re
 	request := struct {
 		ConversationName string    `json:"conversation_name"`
 		ConversationId   uuid.UUID `json:"conversation_id"`
+		Visibility       string    `json:"visibility"`
 	}{}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
@@ -61,10 +67,14 @@ func (s *Server) handleCreateGroupConversation(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	err := s.groupConversation.CreateGroupConversation(r.Context(), request.ConversationId, request.ConversationName, userID)
+	if request.Visibility == "" {
+		request.Visibility = domain.ConversationVisibilityPublic.String()
+	}
+
+	err := s.groupConversation.CreateGroupConversation(r.Context(), request.ConversationId, request.ConversationName, userID, request.Visibility)
 
 	if err != nil {
-		returnError(w, http.StatusInternalServerError, err)
+		returnError(w, visibilityErrorStatus(err), err)
 		return
 	}
 
@@ -107,6 +117,7 @@ func (s *Server) handleDeleteConversation(w http.ResponseWriter, r *http.Request
 func (s *Server) handleJoin(w http.ResponseWriter, r *http.Request) {
 	request := struct {
 		ConversationId uuid.UUID `json:"conversation_id"`
+		InviteToken    string    `json:"invite_token"`
 	}{}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
@@ -120,10 +131,10 @@ func (s *Server) handleJoin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := s.membership.Join(r.Context(), request.ConversationId, userID)
+	err := s.membership.Join(r.Context(), request.ConversationId, userID, request.InviteToken)
 
 	if err != nil {
-		returnError(w, http.StatusInternalServerError, err)
+		returnError(w, inviteLinkErrorStatus(err), err)
 		return
 	}
 
@@ -142,7 +153,8 @@ func (s *Server) handleLeave(w http.ResponseWriter, r *http.Request) {
 	}
 
 	request := struct {
-		ConversationId uuid.UUID `json:"conversation_id"`
+		ConversationId   uuid.UUID `json:"conversation_id"`
+		PromoteSuccessor bool      `json:"promote_successor"`
 	}{}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
@@ -150,10 +162,10 @@ func (s *Server) handleLeave(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := s.membership.Leave(r.Context(), request.ConversationId, userID)
+	err := s.membership.Leave(r.Context(), request.ConversationId, userID, request.PromoteSuccessor)
 
 	if err != nil {
-		returnError(w, http.StatusInternalServerError, err)
+		returnError(w, ownershipErrorStatus(err), err)
 		return
 	}
 
@@ -194,6 +206,146 @@ func (s *Server) handleRename(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (s *Server) handleSetVisibility(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		ConversationId uuid.UUID `json:"conversation_id"`
+		Visibility     string    `json:"visibility"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := s.groupConversation.SetVisibility(r.Context(), request.ConversationId, userID, request.Visibility)
+
+	if err != nil {
+		returnError(w, visibilityErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func visibilityErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorUserNotInConversation),
+		errors.Is(err, domain.ErrorInsufficientPermissions),
+		errors.Is(err, domain.ErrorConversationIsPrivate):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorConversationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrorUnknownVisibility):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (s *Server) handleSetMessageRetention(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		ConversationId uuid.UUID `json:"conversation_id"`
+		TTLSeconds     int64     `json:"ttl_seconds"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if request.TTLSeconds < 0 || request.TTLSeconds > int64(domain.MaxMessageRetention/time.Second) {
+		returnError(w, http.StatusBadRequest, domain.ErrorInvalidMessageRetention)
+		return
+	}
+
+	err := s.groupConversation.SetMessageRetention(r.Context(), request.ConversationId, userID, time.Duration(request.TTLSeconds)*time.Second)
+
+	if err != nil {
+		returnError(w, retentionErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func retentionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorUserNotInConversation),
+		errors.Is(err, domain.ErrorInsufficientPermissions):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorConversationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrorInvalidMessageRetention):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (s *Server) handleTransferOwnership(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		ConversationId uuid.UUID `json:"conversation_id"`
+		NewOwnerId     uuid.UUID `json:"user_id"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := s.groupConversation.TransferOwnership(r.Context(), request.ConversationId, userID, request.NewOwnerId)
+
+	if err != nil {
+		returnError(w, ownershipErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func ownershipErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorUserNotInConversation), errors.Is(err, domain.ErrorUserNotOwner):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorOwnerCannotLeave),
+		errors.Is(err, domain.ErrorNoSuccessor),
+		errors.Is(err, domain.ErrorCannotTransferToSelf):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func (s *Server) handleInvite(w http.ResponseWriter, r *http.Request) {
 	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
 
@@ -215,7 +367,7 @@ func (s *Server) handleInvite(w http.ResponseWriter, r *http.Request) {
 	err := s.membership.Invite(r.Context(), request.ConversationId, userID, request.InviteeId)
 
 	if err != nil {
-		returnError(w, http.StatusInternalServerError, err)
+		returnError(w, blockErrorStatus(err), err)
 		return
 	}
 
@@ -255,3 +407,90 @@ func (s *Server) handleKick(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (s *Server) handlePromote(w http.ResponseWriter, r *http.Request) {
+	s.handleChangeRole(w, r, s.membership.Promote)
+}
+
+func (s *Server) handleDemote(w http.ResponseWriter, r *http.Request) {
+	s.handleChangeRole(w, r, s.membership.Demote)
+}
+
+func (s *Server) handleChangeRole(
+	w http.ResponseWriter,
+	r *http.Request,
+	change func(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, targetID uuid.UUID, role string) error,
+) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		ConversationId uuid.UUID `json:"conversation_id"`
+		TargetId       uuid.UUID `json:"user_id"`
+		Role           string    `json:"role"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := change(r.Context(), request.ConversationId, userID, request.TargetId, request.Role)
+
+	if err != nil {
+		returnError(w, roleErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func roleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorUserNotInConversation), errors.Is(err, domain.ErrorInsufficientPermissions):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorUnknownParticipantRole),
+		errors.Is(err, domain.ErrorCannotAssignRole),
+		errors.Is(err, domain.ErrorCannotChangeOwnRole):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (s *Server) handleMarkAsRead(w http.ResponseWriter, r *http.Request) {
+	request := struct {
+		ConversationId uuid.UUID `json:"conversation_id"`
+		MessageId      uuid.UUID `json:"message_id"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	err := s.membership.MarkAsRead(r.Context(), request.ConversationId, userID, request.MessageId)
+
+	if err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var errInvalidResumeCursor = errors.New("invalid resume cursor")
+
+func parseResumeCursors(rawResume string) (map[uuid.UUID]int64, error) {
+	if rawResume == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(rawResume, ",")
+	if len(parts) > MaxResumeCursors {
+		return nil, errInvalidResumeCursor
+	}
+
+	cursors := make(map[uuid.UUID]int64, len(parts))
+	for _, part := range parts {
+		idPart, seqPart, found := strings.Cut(part, ":")
+		if !found {
+			return nil, errInvalidResumeCursor
+		}
+
+		conversationID, err := uuid.Parse(idPart)
+		if err != nil {
+			return nil, errInvalidResumeCursor
+		}
+
+		seq, err := strconv.ParseInt(seqPart, 10, 64)
+		if err != nil || seq < 0 {
+			return nil, errInvalidResumeCursor
+		}
+
+		cursors[conversationID] = seq
+	}
+
+	return cursors, nil
+}
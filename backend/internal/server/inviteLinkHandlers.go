@@ -0,0 +1,123 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+func (s *Server) handleCreateInviteLink(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		ConversationId uuid.UUID  `json:"conversation_id"`
+		MaxUses        *int       `json:"max_uses"`
+		ExpiresAt      *time.Time `json:"expires_at"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	link, err := s.inviteLink.CreateInviteLink(r.Context(), request.ConversationId, userID, request.MaxUses, request.ExpiresAt)
+
+	if err != nil {
+		returnError(w, inviteLinkErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(link); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleRevokeInviteLink(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		ConversationId uuid.UUID `json:"conversation_id"`
+		LinkId         uuid.UUID `json:"link_id"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := s.inviteLink.RevokeInviteLink(r.Context(), request.ConversationId, userID, request.LinkId)
+
+	if err != nil {
+		returnError(w, inviteLinkErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleGetInviteLinks(w http.ResponseWriter, r *http.Request) {
+	conversationID, err := uuid.Parse(r.URL.Query().Get("conversation_id"))
+
+	if err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	links, err := s.inviteLink.GetInviteLinks(conversationID, userID)
+
+	if err != nil {
+		returnError(w, inviteLinkErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(links); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func inviteLinkErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorUserNotInConversation),
+		errors.Is(err, domain.ErrorInsufficientPermissions),
+		errors.Is(err, domain.ErrorInvalidInviteToken),
+		errors.Is(err, domain.ErrorConversationIsPrivate):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorInviteLinkNotFound), errors.Is(err, domain.ErrorConversationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrorInviteLinkExpired),
+		errors.Is(err, domain.ErrorInviteLinkRevoked),
+		errors.Is(err, domain.ErrorInviteLinkExhausted):
+		return http.StatusGone
+	case errors.Is(err, domain.ErrorInvalidInviteLinkMaxUses), errors.Is(err, domain.ErrorInvalidInviteLinkExpiry):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"GitHub/go-chat/backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+func (s *Server) handleRequestToJoin(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		ConversationId uuid.UUID `json:"conversation_id"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := s.membership.RequestToJoin(r.Context(), request.ConversationId, userID)
+
+	if err != nil {
+		returnError(w, joinRequestErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleApproveJoinRequest(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		ConversationId uuid.UUID `json:"conversation_id"`
+		RequestId      uuid.UUID `json:"request_id"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := s.membership.ApproveJoinRequest(r.Context(), request.ConversationId, userID, request.RequestId)
+
+	if err != nil {
+		returnError(w, joinRequestErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleRejectJoinRequest(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		ConversationId uuid.UUID `json:"conversation_id"`
+		RequestId      uuid.UUID `json:"request_id"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := s.membership.RejectJoinRequest(r.Context(), request.ConversationId, userID, request.RequestId)
+
+	if err != nil {
+		returnError(w, joinRequestErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleGetJoinRequests(w http.ResponseWriter, r *http.Request) {
+	conversationID, err := uuid.Parse(r.URL.Query().Get("conversation_id"))
+
+	if err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	requests, err := s.membership.GetJoinRequests(conversationID, userID)
+
+	if err != nil {
+		returnError(w, joinRequestErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(requests); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func joinRequestErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorUserNotInConversation), errors.Is(err, domain.ErrorInsufficientPermissions):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorJoinRequestNotFound), errors.Is(err, domain.ErrorConversationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrorJoinRequestPending),
+		errors.Is(err, domain.ErrorJoinRequestAlreadyDecided),
+		errors.Is(err, domain.ErrorJoinRequestNotNeeded),
+		errors.Is(err, domain.ErrorAlreadyInConversation):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -37,7 +38,7 @@ func (s *Server) handleSendMessage(w http.ResponseWriter, r *http.Request) {
 	_, err = s.message.Send(r.Context(), message)
 
 	if err != nil {
-		returnError(w, http.StatusInternalServerError, err)
+		returnError(w, blockErrorStatus(err), err)
 		return
 	}
 
@@ -54,3 +55,251 @@ func (s *Server) handleSendMessage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (s *Server) handleReplyToMessage(w http.ResponseWriter, r *http.Request) {
+	request := struct {
+		ParentId uuid.UUID `json:"parent_id"`
+		Content  string    `json:"content"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	message, err := s.message.Reply(r.Context(), request.ParentId, userID, request.Content)
+
+	if err != nil {
+		returnError(w, blockErrorStatus(err), err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(message); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleForwardMessage(w http.ResponseWriter, r *http.Request) {
+	request := struct {
+		MessageId      uuid.UUID `json:"message_id"`
+		ConversationId uuid.UUID `json:"conversation_id"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	message, err := s.message.Forward(r.Context(), request.MessageId, request.ConversationId, userID)
+
+	if err != nil {
+		returnError(w, forwardErrorStatus(err), err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(message); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func forwardErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorUserNotInConversation), errors.Is(err, domain.ErrorUserBlocked):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorMessageNotForwardable), errors.Is(err, domain.ErrorAttachmentNotReady):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrorMessageNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func editErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorTextEmpty), errors.Is(err, domain.ErrorTextTooLong), errors.Is(err, domain.ErrorCaptionTooLong):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrorUserNotAuthor), errors.Is(err, domain.ErrorUserNotInConversation):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorMessageNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrorMessageNotEditable):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (s *Server) handleEditMessage(w http.ResponseWriter, r *http.Request) {
+	request := struct {
+		MessageId uuid.UUID `json:"message_id"`
+		Content   string    `json:"content"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	message, err := s.message.Edit(r.Context(), request.MessageId, userID, request.Content)
+
+	if err != nil {
+		returnError(w, editErrorStatus(err), err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(message); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func deleteErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorUnknownDeletionScope):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrorUserNotAuthor), errors.Is(err, domain.ErrorUserNotInConversation):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorMessageNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrorMessageNotDeletable):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (s *Server) handleDeleteMessage(w http.ResponseWriter, r *http.Request) {
+	request := struct {
+		MessageId uuid.UUID `json:"message_id"`
+		Scope     string    `json:"scope"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	scope, err := domain.NewMessageDeletionScope(request.Scope)
+	if err != nil {
+		returnError(w, deleteErrorStatus(err), err)
+		return
+	}
+
+	err = s.message.Delete(r.Context(), request.MessageId, userID, scope)
+
+	if err != nil {
+		returnError(w, deleteErrorStatus(err), err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func reactionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorInvalidEmoji):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrorUserNotInConversation):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorMessageNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (s *Server) handleAddReaction(w http.ResponseWriter, r *http.Request) {
+	request := struct {
+		MessageId uuid.UUID `json:"message_id"`
+		Emoji     string    `json:"emoji"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	err := s.reaction.AddReaction(r.Context(), request.MessageId, userID, request.Emoji)
+
+	if err != nil {
+		returnError(w, reactionErrorStatus(err), err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleRemoveReaction(w http.ResponseWriter, r *http.Request) {
+	request := struct {
+		MessageId uuid.UUID `json:"message_id"`
+		Emoji     string    `json:"emoji"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	err := s.reaction.RemoveReaction(r.Context(), request.MessageId, userID, request.Emoji)
+
+	if err != nil {
+		returnError(w, reactionErrorStatus(err), err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
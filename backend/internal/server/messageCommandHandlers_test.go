@@ -0,0 +1,75 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"GitHub/go-chat/backend/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"empty text", fmt.Errorf("edit message error: %w", domain.ErrorTextEmpty), http.StatusBadRequest},
+		{"text too long", fmt.Errorf("edit message error: %w", domain.ErrorTextTooLong), http.StatusBadRequest},
+		{"caption too long", fmt.Errorf("edit message error: %w", domain.ErrorCaptionTooLong), http.StatusBadRequest},
+		{"not author", fmt.Errorf("edit message error: %w", domain.ErrorUserNotAuthor), http.StatusForbidden},
+		{"not member", fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation), http.StatusForbidden},
+		{"missing message", fmt.Errorf("get message error: %w", domain.ErrorMessageNotFound), http.StatusNotFound},
+		{"not editable", fmt.Errorf("edit message error: %w", domain.ErrorMessageNotEditable), http.StatusConflict},
+		{"unexpected", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, editErrorStatus(tt.err))
+		})
+	}
+}
+
+func TestDeleteErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"unknown scope", domain.ErrorUnknownDeletionScope, http.StatusBadRequest},
+		{"no permission", fmt.Errorf("delete message error: %w", domain.ErrorUserNotAuthor), http.StatusForbidden},
+		{"not member", fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation), http.StatusForbidden},
+		{"missing message", fmt.Errorf("get message error: %w", domain.ErrorMessageNotFound), http.StatusNotFound},
+		{"not deletable", fmt.Errorf("delete message error: %w", domain.ErrorMessageNotDeletable), http.StatusConflict},
+		{"unexpected", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, deleteErrorStatus(tt.err))
+		})
+	}
+}
+
+func TestReactionErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"invalid emoji", fmt.Errorf("new reaction error: %w", domain.ErrorInvalidEmoji), http.StatusBadRequest},
+		{"not member", fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation), http.StatusForbidden},
+		{"missing message", fmt.Errorf("get message error: %w", domain.ErrorMessageNotFound), http.StatusNotFound},
+		{"unexpected", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, reactionErrorStatus(tt.err))
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"GitHub/go-chat/backend/internal/readModel"
+
+	"github.com/google/uuid"
+)
+
+var errInvalidMessageCursor = errors.New("invalid message cursor")
+
+func parseMessageCursor(rawCursor string) (*readModel.MessageCursor, error) {
+	if rawCursor == "" {
+		return nil, nil
+	}
+
+	createdAtPart, idPart, found := strings.Cut(rawCursor, "|")
+	if !found {
+		return nil, errInvalidMessageCursor
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtPart)
+	if err != nil {
+		return nil, errInvalidMessageCursor
+	}
+
+	id, err := uuid.Parse(idPart)
+	if err != nil {
+		return nil, errInvalidMessageCursor
+	}
+
+	return &readModel.MessageCursor{
+		CreatedAt: createdAt,
+		ID:        id,
+	}, nil
+}
+
+func parseMessageSearchCursor(rawCursor string) (*readModel.MessageSearchCursor, error) {
+	if rawCursor == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(rawCursor, "|")
+	if len(parts) != 3 {
+		return nil, errInvalidMessageCursor
+	}
+
+	rank, err := strconv.ParseFloat(parts[0], 32)
+	if err != nil {
+		return nil, errInvalidMessageCursor
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return nil, errInvalidMessageCursor
+	}
+
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return nil, errInvalidMessageCursor
+	}
+
+	return &readModel.MessageSearchCursor{
+		Rank:      float32(rank),
+		CreatedAt: createdAt,
+		ID:        id,
+	}, nil
+}
+
+func parseMessageLimit(query url.Values) int {
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil {
+		return 0
+	}
+
+	return limit
+}
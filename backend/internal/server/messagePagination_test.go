@@ -0,0 +1,74 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"GitHub/go-chat/backend/internal/readModel"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMessageSearchCursor(t *testing.T) {
+	id := uuid.New()
+	createdAt := time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.UTC)
+	rawCreatedAt := createdAt.Format(time.RFC3339Nano)
+
+	testCases := []struct {
+		name        string
+		rawCursor   string
+		expected    *readModel.MessageSearchCursor
+		expectedErr error
+	}{
+		{
+			name:      "empty cursor",
+			rawCursor: "",
+		}, {
+			name:      "valid cursor",
+			rawCursor: "0.075|" + rawCreatedAt + "|" + id.String(),
+			expected:  &readModel.MessageSearchCursor{Rank: 0.075, CreatedAt: createdAt, ID: id},
+		}, {
+			name:        "missing rank",
+			rawCursor:   rawCreatedAt + "|" + id.String(),
+			expectedErr: errInvalidMessageCursor,
+		}, {
+			name:        "empty rank",
+			rawCursor:   "|" + rawCreatedAt + "|" + id.String(),
+			expectedErr: errInvalidMessageCursor,
+		}, {
+			name:        "missing id",
+			rawCursor:   "0.075|" + rawCreatedAt,
+			expectedErr: errInvalidMessageCursor,
+		}, {
+			name:        "empty id",
+			rawCursor:   "0.075|" + rawCreatedAt + "|",
+			expectedErr: errInvalidMessageCursor,
+		}, {
+			name:        "rank is not a number",
+			rawCursor:   "high|" + rawCreatedAt + "|" + id.String(),
+			expectedErr: errInvalidMessageCursor,
+		}, {
+			name:        "malformed created at",
+			rawCursor:   "0.075|yesterday|" + id.String(),
+			expectedErr: errInvalidMessageCursor,
+		}, {
+			name:        "malformed id",
+			rawCursor:   "0.075|" + rawCreatedAt + "|not-a-uuid",
+			expectedErr: errInvalidMessageCursor,
+		}, {
+			name:        "too many parts",
+			rawCursor:   "0.075|" + rawCreatedAt + "|" + id.String() + "|extra",
+			expectedErr: errInvalidMessageCursor,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cursor, err := parseMessageSearchCursor(tc.rawCursor)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, cursor)
+		})
+	}
+}
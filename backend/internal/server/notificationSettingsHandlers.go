@@ -0,0 +1,87 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+func (s *Server) handleGetNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	conversationID, err := uuid.Parse(r.URL.Query().Get("conversation_id"))
+
+	if err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	settings, err := s.membership.GetNotificationSettings(conversationID, userID)
+
+	if err != nil {
+		returnError(w, notificationSettingsErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(settings); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleUpdateNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		ConversationId    uuid.UUID  `json:"conversation_id"`
+		NotificationLevel string     `json:"notification_level"`
+		MutedUntil        *time.Time `json:"muted_until"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if request.NotificationLevel == "" {
+		request.NotificationLevel = domain.NotificationLevelAll.String()
+	}
+
+	settings, err := s.membership.UpdateNotificationSettings(r.Context(), request.ConversationId, userID, request.NotificationLevel, request.MutedUntil)
+
+	if err != nil {
+		returnError(w, notificationSettingsErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(settings); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func notificationSettingsErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorUserNotInConversation):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorUnknownNotificationLevel):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"GitHub/go-chat/backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+func (s *Server) handlePinMessage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		MessageId uuid.UUID `json:"message_id"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := s.pin.Pin(r.Context(), request.MessageId, userID)
+
+	if err != nil {
+		returnError(w, pinErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleUnpinMessage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		MessageId uuid.UUID `json:"message_id"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := s.pin.Unpin(r.Context(), request.MessageId, userID)
+
+	if err != nil {
+		returnError(w, pinErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleGetPinnedMessages(w http.ResponseWriter, r *http.Request) {
+	conversationID, err := uuid.Parse(r.URL.Query().Get("conversation_id"))
+
+	if err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	paginationInfo, ok := r.Context().Value(paginationKey).(pagination)
+
+	if !ok {
+		http.Error(w, "pagination info not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	pins, err := s.pin.GetPinnedMessages(conversationID, userID, paginationInfo)
+
+	if err != nil {
+		returnError(w, pinErrorStatus(err), err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(pins); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func pinErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorUserNotInConversation), errors.Is(err, domain.ErrorInsufficientPermissions):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorMessageNotPinned):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrorMessageAlreadyPinned), errors.Is(err, domain.ErrorPinLimitReached):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrorMessageNotPinnable):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
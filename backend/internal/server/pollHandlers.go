@@ -0,0 +1,133 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/services"
+
+	"github.com/google/uuid"
+)
+
+func (s *Server) handleCreatePoll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		ConversationId uuid.UUID `json:"conversation_id"`
+		Question       string    `json:"question"`
+		Options        []string  `json:"options"`
+		MultipleChoice bool      `json:"multiple_choice"`
+		Anonymous      bool      `json:"anonymous"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	message, err := s.poll.Create(r.Context(), request.ConversationId, userID, services.PollInput{
+		Question:       request.Question,
+		Options:        request.Options,
+		MultipleChoice: request.MultipleChoice,
+		Anonymous:      request.Anonymous,
+	})
+
+	if err != nil {
+		returnError(w, pollErrorStatus(err), err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(message); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleVotePoll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		MessageId uuid.UUID   `json:"message_id"`
+		OptionIds []uuid.UUID `json:"option_ids"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	poll, err := s.poll.Vote(r.Context(), request.MessageId, userID, request.OptionIds)
+
+	if err != nil {
+		returnError(w, pollErrorStatus(err), err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(poll); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleClosePoll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	request := struct {
+		MessageId uuid.UUID `json:"message_id"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	poll, err := s.poll.Close(r.Context(), request.MessageId, userID)
+
+	if err != nil {
+		returnError(w, pollErrorStatus(err), err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(poll); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func pollErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorUserNotInConversation), errors.Is(err, domain.ErrorUserBlocked), errors.Is(err, domain.ErrorUserNotAuthor):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorPollNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrorPollClosed):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrorPollQuestionEmpty),
+		errors.Is(err, domain.ErrorPollQuestionTooLong),
+		errors.Is(err, domain.ErrorPollOptionsCount),
+		errors.Is(err, domain.ErrorPollOptionInvalid),
+		errors.Is(err, domain.ErrorPollOptionDuplicate),
+		errors.Is(err, domain.ErrorPollInvalidChoice),
+		errors.Is(err, domain.ErrorPollMultipleChoiceOff):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -39,6 +40,36 @@ func (s *Server) handleGetContacts(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (s *Server) handleGetPublicGroups(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	paginationInfo, ok := r.Context().Value(paginationKey).(pagination)
+
+	if !ok {
+		http.Error(w, "pagination info not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	groups, err := s.queries.GetPublicGroups(userID, r.URL.Query().Get("search"), paginationInfo)
+
+	if err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(groups)
+
+	if err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
 func (s *Server) handleGetPotentialInvitees(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
 
@@ -50,6 +81,13 @@ func (s *Server) handleGetPotentialInvitees(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
 	paginationInfo, ok := r.Context().Value(paginationKey).(pagination)
 
 	if !ok {
@@ -57,7 +95,7 @@ func (s *Server) handleGetPotentialInvitees(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	contacts, err := s.queries.GetPotentialInvitees(conversationID, paginationInfo)
+	contacts, err := s.queries.GetPotentialInvitees(conversationID, userID, paginationInfo)
 
 	if err != nil {
 		returnError(w, http.StatusInternalServerError, err)
@@ -103,6 +141,13 @@ func (s *Server) handleGetConversations(w http.ResponseWriter, r *http.Request)
 }
 
 func (s *Server) handleGetConversationsMessages(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
 	query := r.URL.Query()
 
 	conversationIDQuery := query.Get("conversation_id")
@@ -121,7 +166,7 @@ func (s *Server) handleGetConversationsMessages(w http.ResponseWriter, r *http.R
 
 	limit := parseMessageLimit(query)
 
-	page, err := s.queries.GetConversationMessages(conversationID, cursor, limit)
+	page, err := s.queries.GetConversationMessages(conversationID, userID, cursor, limit)
 
 	if err != nil {
 		returnError(w, http.StatusInternalServerError, err)
@@ -140,6 +185,136 @@ func (s *Server) handleGetConversationsMessages(w http.ResponseWriter, r *http.R
 	}
 }
 
+func (s *Server) handleGetThreadMessages(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+
+	threadID, err := uuid.Parse(query.Get("thread_id"))
+
+	if err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	cursor, err := parseMessageCursor(query.Get("cursor"))
+	if err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	limit := parseMessageLimit(query)
+
+	page, err := s.queries.GetThreadMessages(threadID, userID, cursor, limit)
+
+	if err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	for i := range page.Messages {
+		page.Messages[i].User = nil
+	}
+
+	err = json.NewEncoder(w).Encode(page)
+
+	if err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleGetMentions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+
+	cursor, err := parseMessageCursor(query.Get("cursor"))
+	if err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	limit := parseMessageLimit(query)
+
+	page, err := s.queries.GetMentions(userID, cursor, limit)
+
+	if err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(page)
+
+	if err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleSearchMessages(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+
+	searchQuery := strings.TrimSpace(query.Get("q"))
+	if searchQuery == "" {
+		returnError(w, http.StatusBadRequest, errors.New("search query is empty"))
+		return
+	}
+	if len(searchQuery) > MaxSearchQueryLength {
+		returnError(w, http.StatusBadRequest, errors.New("search query is too long"))
+		return
+	}
+
+	var conversationID *uuid.UUID
+	if rawConversationID := query.Get("conversation_id"); rawConversationID != "" {
+		id, err := uuid.Parse(rawConversationID)
+		if err != nil {
+			returnError(w, http.StatusBadRequest, err)
+			return
+		}
+		conversationID = &id
+	}
+
+	cursor, err := parseMessageSearchCursor(query.Get("cursor"))
+	if err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	limit := parseMessageLimit(query)
+
+	page, err := s.queries.SearchMessages(userID, conversationID, searchQuery, cursor, limit)
+
+	if err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(page)
+
+	if err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
 func (s *Server) handleGetConversationUsers(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
 
@@ -295,3 +470,36 @@ func (s *Server) handleGetUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (s *Server) handleGetMessageRevisions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+
+	messageIDQuery := query.Get("message_id")
+	messageID, err := uuid.Parse(messageIDQuery)
+
+	if err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	revisions, err := s.queries.GetMessageRevisions(messageID, userID)
+
+	if err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(revisions)
+
+	if err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
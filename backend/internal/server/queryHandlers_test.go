@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"GitHub/go-chat/backend/internal/readModel"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSearchQueries only implements SearchMessages, any other call panics on
+// the nil embedded repository.
+type MockSearchQueries struct {
+	readModel.QueriesRepository
+	mock.Mock
+}
+
+func (m *MockSearchQueries) SearchMessages(userID uuid.UUID, conversationID *uuid.UUID, query string, cursor *readModel.MessageSearchCursor, limit int) (readModel.MessageSearchPageDTO, error) {
+	args := m.Called(userID, conversationID, query, cursor, limit)
+	return args.Get(0).(readModel.MessageSearchPageDTO), args.Error(1)
+}
+
+func searchRequest(userID uuid.UUID, params url.Values) *http.Request {
+	req := httptest.NewRequest("GET", "/messages/search?"+params.Encode(), nil)
+	return req.WithContext(context.WithValue(req.Context(), userIDKey, userID))
+}
+
+func TestHandleSearchMessages(t *testing.T) {
+	userID := uuid.New()
+	conversationID := uuid.New()
+	resultID := uuid.New()
+
+	t.Run("global search", func(t *testing.T) {
+		mockQueries := new(MockSearchQueries)
+		server := &Server{queries: mockQueries}
+		page := readModel.MessageSearchPageDTO{
+			Results: []readModel.MessageSearchResultDTO{{ID: resultID, ConversationID: conversationID, Snippet: "<b>hello</b>"}},
+		}
+
+		mockQueries.On("SearchMessages", userID, (*uuid.UUID)(nil), "hello", (*readModel.MessageSearchCursor)(nil), 20).Return(page, nil)
+
+		w := httptest.NewRecorder()
+		server.handleSearchMessages(w, searchRequest(userID, url.Values{"q": {" hello "}, "limit": {"20"}}))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body readModel.MessageSearchPageDTO
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+		assert.Equal(t, page.Results[0].ID, body.Results[0].ID)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("conversation scoped search with cursor", func(t *testing.T) {
+		mockQueries := new(MockSearchQueries)
+		server := &Server{queries: mockQueries}
+		createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		cursor := &readModel.MessageSearchCursor{Rank: 0.5, CreatedAt: createdAt, ID: resultID}
+
+		mockQueries.On("SearchMessages", userID, &conversationID, "hello", cursor, 0).Return(readModel.MessageSearchPageDTO{}, nil)
+
+		w := httptest.NewRecorder()
+		server.handleSearchMessages(w, searchRequest(userID, url.Values{
+			"q":               {"hello"},
+			"conversation_id": {conversationID.String()},
+			"cursor":          {"0.5|" + createdAt.Format(time.RFC3339Nano) + "|" + resultID.String()},
+		}))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("rejects bad input", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			params url.Values
+		}{
+			{name: "empty query", params: url.Values{"q": {"  "}}},
+			{name: "malformed conversation id", params: url.Values{"q": {"hello"}, "conversation_id": {"nope"}}},
+			{name: "malformed cursor", params: url.Values{"q": {"hello"}, "cursor": {"0.5|" + resultID.String()}}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mockQueries := new(MockSearchQueries)
+				server := &Server{queries: mockQueries}
+
+				w := httptest.NewRecorder()
+				server.handleSearchMessages(w, searchRequest(userID, tc.params))
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				mockQueries.AssertNotCalled(t, "SearchMessages", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+}
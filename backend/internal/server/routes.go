@@ -22,21 +22,62 @@ func (s *Server) initRoutes() http.Handler {
 
 	mux.HandleFunc("POST /api/createConversation", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleCreateGroupConversation))))
 	mux.HandleFunc("POST /api/sendMessage", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleSendMessage))))
+	mux.HandleFunc("POST /api/replyToMessage", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleReplyToMessage))))
+	mux.HandleFunc("POST /api/forwardMessage", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleForwardMessage))))
+	mux.HandleFunc("POST /api/scheduleMessage", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleScheduleMessage))))
+	mux.HandleFunc("POST /api/editScheduledMessage", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleEditScheduledMessage))))
+	mux.HandleFunc("POST /api/cancelScheduledMessage", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleCancelScheduledMessage))))
+	mux.HandleFunc("POST /api/editMessage", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleEditMessage))))
+	mux.HandleFunc("POST /api/deleteMessage", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleDeleteMessage))))
+	mux.HandleFunc("POST /api/uploadAttachment", s.securityHeaders(s.limitRequestBodySize(MaxAttachmentRequestSize, s.private(s.handleUploadAttachment))))
+	mux.HandleFunc("POST /api/addReaction", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleAddReaction))))
+	mux.HandleFunc("POST /api/removeReaction", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleRemoveReaction))))
 	mux.HandleFunc("POST /api/startDirectConversation", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleStartDirectConversation))))
 	mux.HandleFunc("POST /api/deleteConversation", s.securityHeaders(s.private(s.handleDeleteConversation)))
 	mux.HandleFunc("POST /api/joinConversation", s.securityHeaders(s.private(s.handleJoin)))
+	mux.HandleFunc("POST /api/requestToJoin", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleRequestToJoin))))
+	mux.HandleFunc("POST /api/approveJoinRequest", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleApproveJoinRequest))))
+	mux.HandleFunc("POST /api/rejectJoinRequest", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleRejectJoinRequest))))
+	mux.HandleFunc("POST /api/createInviteLink", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleCreateInviteLink))))
+	mux.HandleFunc("POST /api/revokeInviteLink", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleRevokeInviteLink))))
 	mux.HandleFunc("POST /api/inviteUserToConversation", s.securityHeaders(s.private(s.handleInvite)))
 	mux.HandleFunc("POST /api/kick", s.securityHeaders(s.private(s.handleKick)))
+	mux.HandleFunc("POST /api/promoteParticipant", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handlePromote))))
+	mux.HandleFunc("POST /api/demoteParticipant", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleDemote))))
+	mux.HandleFunc("POST /api/setConversationVisibility", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleSetVisibility))))
+	mux.HandleFunc("POST /api/setMessageRetention", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleSetMessageRetention))))
+	mux.HandleFunc("POST /api/transferOwnership", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleTransferOwnership))))
 	mux.HandleFunc("POST /api/leaveConversation", s.securityHeaders(s.private(s.handleLeave)))
 	mux.HandleFunc("POST /api/renameConversation", s.securityHeaders(s.private(s.handleRename)))
+	mux.HandleFunc("POST /api/blockUser", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleBlockUser))))
+	mux.HandleFunc("POST /api/unblockUser", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleUnblockUser))))
+	mux.HandleFunc("POST /api/pinMessage", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handlePinMessage))))
+	mux.HandleFunc("POST /api/unpinMessage", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleUnpinMessage))))
+	mux.HandleFunc("POST /api/createPoll", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleCreatePoll))))
+	mux.HandleFunc("POST /api/votePoll", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleVotePoll))))
+	mux.HandleFunc("POST /api/closePoll", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleClosePoll))))
+	mux.HandleFunc("POST /api/updateNotificationSettings", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleUpdateNotificationSettings))))
+	mux.HandleFunc("POST /api/markAsRead", s.securityHeaders(s.limitRequestBodySize(MaxRequestBodySize, s.private(s.handleMarkAsRead))))
 
 	mux.HandleFunc("GET /api/getUser", s.securityHeaders(s.private(s.handleGetUser)))
 	mux.HandleFunc("GET /api/getConversations", s.securityHeaders(s.private(withPagination(s.handleGetConversations))))
 	mux.HandleFunc("GET /api/getContacts", s.securityHeaders(s.private(withPagination(s.handleGetContacts))))
+	mux.HandleFunc("GET /api/getBlockedUsers", s.securityHeaders(s.private(withPagination(s.handleGetBlockedUsers))))
+	mux.HandleFunc("GET /api/getPublicGroups", s.securityHeaders(s.private(withPagination(s.handleGetPublicGroups))))
 	mux.HandleFunc("GET /api/getPotentialInvitees", s.securityHeaders(s.private(withPagination(s.handleGetPotentialInvitees))))
 	mux.HandleFunc("GET /api/getConversation", s.securityHeaders(s.private(s.handleGetConversation)))
 	mux.HandleFunc("GET /api/getConversationsMessages", s.securityHeaders(s.private(s.handleGetConversationsMessages)))
+	mux.HandleFunc("GET /api/getThreadMessages", s.securityHeaders(s.private(s.handleGetThreadMessages)))
+	mux.HandleFunc("GET /api/getMentions", s.securityHeaders(s.private(s.handleGetMentions)))
+	mux.HandleFunc("GET /api/getScheduledMessages", s.securityHeaders(s.private(withPagination(s.handleGetScheduledMessages))))
+	mux.HandleFunc("GET /api/searchMessages", s.securityHeaders(s.private(s.handleSearchMessages)))
+	mux.HandleFunc("GET /api/downloadAttachment", s.securityHeaders(s.private(s.handleDownloadAttachment)))
+	mux.HandleFunc("GET /api/getMessageRevisions", s.securityHeaders(s.private(s.handleGetMessageRevisions)))
 	mux.HandleFunc("GET /api/getConversationUsers", s.securityHeaders(s.private(s.handleGetConversationUsers)))
+	mux.HandleFunc("GET /api/getJoinRequests", s.securityHeaders(s.private(s.handleGetJoinRequests)))
+	mux.HandleFunc("GET /api/getNotificationSettings", s.securityHeaders(s.private(s.handleGetNotificationSettings)))
+	mux.HandleFunc("GET /api/getPinnedMessages", s.securityHeaders(s.private(withPagination(s.handleGetPinnedMessages))))
+	mux.HandleFunc("GET /api/getInviteLinks", s.securityHeaders(s.private(s.handleGetInviteLinks)))
 	mux.HandleFunc("GET /api/getParticipants", s.securityHeaders(s.private(withPagination(s.handleGetParticipants))))
 
 	return mux
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+func (s *Server) handleScheduleMessage(w http.ResponseWriter, r *http.Request) {
+	request := struct {
+		ConversationId uuid.UUID `json:"conversation_id"`
+		Content        string    `json:"content"`
+		ScheduledAt    time.Time `json:"scheduled_at"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := domain.NewTextMessageContent(request.Content); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := s.scheduledMessage.Schedule(r.Context(), request.ConversationId, userID, request.Content, request.ScheduledAt)
+
+	if err != nil {
+		returnError(w, scheduledMessageErrorStatus(err), err)
+		return
+	}
+
+	response := struct {
+		ScheduledMessageId string `json:"scheduled_message_id"`
+	}{
+		ScheduledMessageId: id.String(),
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleEditScheduledMessage(w http.ResponseWriter, r *http.Request) {
+	request := struct {
+		ScheduledMessageId uuid.UUID `json:"scheduled_message_id"`
+		Content            string    `json:"content"`
+		ScheduledAt        time.Time `json:"scheduled_at"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := domain.NewTextMessageContent(request.Content); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := s.scheduledMessage.Edit(r.Context(), request.ScheduledMessageId, userID, request.Content, request.ScheduledAt)
+
+	if err != nil {
+		returnError(w, scheduledMessageErrorStatus(err), err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleCancelScheduledMessage(w http.ResponseWriter, r *http.Request) {
+	request := struct {
+		ScheduledMessageId uuid.UUID `json:"scheduled_message_id"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		returnError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	err := s.scheduledMessage.Cancel(r.Context(), request.ScheduledMessageId, userID)
+
+	if err != nil {
+		returnError(w, scheduledMessageErrorStatus(err), err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode("OK"); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (s *Server) handleGetScheduledMessages(w http.ResponseWriter, r *http.Request) {
+	var conversationID *uuid.UUID
+
+	if value := r.URL.Query().Get("conversation_id"); value != "" {
+		id, err := uuid.Parse(value)
+
+		if err != nil {
+			returnError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		conversationID = &id
+	}
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "userID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	paginationInfo, ok := r.Context().Value(paginationKey).(pagination)
+
+	if !ok {
+		http.Error(w, "pagination info not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	messages, err := s.scheduledMessage.GetScheduledMessages(userID, conversationID, paginationInfo)
+
+	if err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(messages); err != nil {
+		returnError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func scheduledMessageErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrorUserNotInConversation), errors.Is(err, domain.ErrorUserNotAuthor):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrorScheduledMessageNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrorScheduledTimeInPast):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
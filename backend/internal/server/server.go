@@ -26,7 +26,14 @@ type Server struct {
 	groupConversation    services.GroupConversationService
 	directConversation   services.DirectConversationService
 	membership           services.MembershipService
+	inviteLink           services.InviteLinkService
+	block                services.BlockService
+	pin                  services.PinService
+	poll                 services.PollService
 	message              services.MessageService
+	scheduledMessage     services.ScheduledMessageService
+	reaction             services.ReactionService
+	attachment           services.AttachmentService
 	notificationCommands services.NotificationService
 	queries              readModel.QueriesRepository
 	ipRateLimiter        ratelimit.RateLimiter
@@ -40,7 +47,14 @@ func NewServer(
 	groupConversation services.GroupConversationService,
 	directConversation services.DirectConversationService,
 	membership services.MembershipService,
+	inviteLink services.InviteLinkService,
+	block services.BlockService,
+	pin services.PinService,
+	poll services.PollService,
 	message services.MessageService,
+	scheduledMessage services.ScheduledMessageService,
+	reaction services.ReactionService,
+	attachment services.AttachmentService,
 	notificationCommands services.NotificationService,
 	queries readModel.QueriesRepository,
 	ipRateLimiter ratelimit.RateLimiter,
@@ -53,7 +67,14 @@ func NewServer(
 		groupConversation:    groupConversation,
 		directConversation:   directConversation,
 		membership:           membership,
+		inviteLink:           inviteLink,
+		block:                block,
+		pin:                  pin,
+		poll:                 poll,
 		message:              message,
+		scheduledMessage:     scheduledMessage,
+		reaction:             reaction,
+		attachment:           attachment,
 		notificationCommands: notificationCommands,
 		queries:              queries,
 		ipRateLimiter:        ipRateLimiter,
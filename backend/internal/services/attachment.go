@@ -0,0 +1,164 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+	"GitHub/go-chat/backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+const contentSniffLength = 512
+
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type AttachmentUpload struct {
+	FileName string
+	Caption  string
+	Size     int64
+	Content  io.Reader
+}
+
+type AttachmentService interface {
+	Upload(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, upload AttachmentUpload) (readModel.MessageDTO, error)
+	Download(ctx context.Context, attachmentID uuid.UUID, userID uuid.UUID, thumbnail bool) (*domain.Attachment, io.ReadCloser, error)
+}
+
+type attachmentService struct {
+	attachments repository.AttachmentRepository
+	messages    MessageService
+	queries     readModel.QueriesRepository
+	blobs       BlobStore
+	processor   AttachmentProcessor
+}
+
+func NewAttachmentService(
+	attachments repository.AttachmentRepository,
+	messages MessageService,
+	queries readModel.QueriesRepository,
+	blobs BlobStore,
+	processor AttachmentProcessor,
+) AttachmentService {
+	return &attachmentService{
+		attachments: attachments,
+		messages:    messages,
+		queries:     queries,
+		blobs:       blobs,
+		processor:   processor,
+	}
+}
+
+func (s *attachmentService) Upload(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, upload AttachmentUpload) (readModel.MessageDTO, error) {
+	isMember, err := s.queries.IsMember(conversationID, userID)
+	if err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("is member error: %w", err)
+	}
+	if !isMember {
+		return readModel.MessageDTO{}, fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation)
+	}
+
+	blocked, err := s.queries.IsBlockedInDirectConversation(conversationID, userID)
+	if err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("is blocked error: %w", err)
+	}
+	if blocked {
+		return readModel.MessageDTO{}, domain.ErrorUserBlocked
+	}
+
+	head := make([]byte, contentSniffLength)
+	n, err := io.ReadFull(upload.Content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return readModel.MessageDTO{}, fmt.Errorf("read attachment error: %w", err)
+	}
+	head = head[:n]
+
+	attachment, err := domain.NewAttachment(conversationID, userID, upload.FileName, http.DetectContentType(head), upload.Size)
+	if err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("new attachment error: %w", err)
+	}
+
+	message, err := domain.NewAttachmentMessage(attachment, upload.Caption)
+	if err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("new attachment message error: %w", err)
+	}
+
+	content := io.MultiReader(bytes.NewReader(head), upload.Content)
+	if err := s.blobs.Put(ctx, attachment.StorageKey, content, attachment.Size, attachment.ContentType); err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("store blob error: %w", err)
+	}
+
+	// Send resolves caption mentions and broadcasts like any other message. An
+	// error with a stored message means only the notifications failed, so the
+	// blob is kept and still processed.
+	dto, err := s.messages.Send(ctx, message)
+	if err != nil && dto.ID == uuid.Nil {
+		if err := s.blobs.Delete(ctx, attachment.StorageKey); err != nil {
+			log.Printf("Error deleting orphaned blob %s: %v", attachment.StorageKey, err)
+		}
+		return dto, fmt.Errorf("store attachment message error: %w", err)
+	}
+
+	if attachment.Status == domain.AttachmentStatusProcessing {
+		s.processor.Enqueue(attachment.ID)
+	}
+
+	if err != nil {
+		return dto, fmt.Errorf("notify error: %w", err)
+	}
+
+	return dto, nil
+}
+
+func (s *attachmentService) Download(ctx context.Context, attachmentID uuid.UUID, userID uuid.UUID, thumbnail bool) (*domain.Attachment, io.ReadCloser, error) {
+	attachment, err := s.attachments.GetByID(ctx, attachmentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get attachment error: %w", err)
+	}
+
+	isMember, err := s.queries.IsMember(attachment.ConversationID, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("is member error: %w", err)
+	}
+	if !isMember {
+		return nil, nil, fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation)
+	}
+
+	// Attachments of messages deleted for everyone are never returned by the
+	// repository; the ones the user deleted for themselves are checked here.
+	hidden, err := s.queries.IsMessageHidden(attachment.MessageID, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("is message hidden error: %w", err)
+	}
+	if hidden {
+		return nil, nil, domain.ErrorAttachmentNotFound
+	}
+
+	key := attachment.StorageKey
+	if thumbnail {
+		if attachment.Thumbnail == nil {
+			return nil, nil, domain.ErrorAttachmentNotFound
+		}
+		key = attachment.Thumbnail.StorageKey
+	} else if attachment.Status != domain.AttachmentStatusReady {
+		return nil, nil, domain.ErrorAttachmentNotReady
+	}
+
+	content, err := s.blobs.Get(ctx, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get blob error: %w", err)
+	}
+
+	return attachment, content, nil
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/infra/media"
+	"GitHub/go-chat/backend/internal/presentation"
+	"GitHub/go-chat/backend/internal/readModel"
+	"GitHub/go-chat/backend/internal/repository"
+	ws "GitHub/go-chat/backend/internal/websocket"
+
+	"github.com/google/uuid"
+)
+
+const AttachmentUpdatedEvent = "attachment_updated"
+
+type ImageProcessor interface {
+	Process(content []byte, contentType string) (media.ProcessedImage, error)
+}
+
+type AttachmentUpdate struct {
+	MessageID      uuid.UUID                `json:"message_id"`
+	ConversationID uuid.UUID                `json:"conversation_id"`
+	Attachment     *readModel.AttachmentDTO `json:"attachment"`
+}
+
+type AttachmentProcessor interface {
+	Enqueue(attachmentID uuid.UUID)
+	Run()
+	Shutdown()
+}
+
+type attachmentProcessor struct {
+	ctx           context.Context
+	cancel        context.CancelFunc
+	attachments   repository.AttachmentRepository
+	blobs         BlobStore
+	images        ImageProcessor
+	notifications NotificationService
+	workers       int
+	queue         chan uuid.UUID
+	sweepInterval time.Duration
+	sweepBatch    int
+}
+
+func NewAttachmentProcessor(
+	ctx context.Context,
+	attachments repository.AttachmentRepository,
+	blobs BlobStore,
+	images ImageProcessor,
+	notifications NotificationService,
+	workers int,
+	sweepInterval time.Duration,
+	sweepBatch int,
+) AttachmentProcessor {
+	processorCtx, cancel := context.WithCancel(ctx)
+
+	return &attachmentProcessor{
+		ctx:           processorCtx,
+		cancel:        cancel,
+		attachments:   attachments,
+		blobs:         blobs,
+		images:        images,
+		notifications: notifications,
+		workers:       workers,
+		queue:         make(chan uuid.UUID, 100),
+		sweepInterval: sweepInterval,
+		sweepBatch:    sweepBatch,
+	}
+}
+
+// Enqueue never blocks the upload path. The queue lives in memory only, so the
+// database status is the source of truth: a job dropped here or lost in a
+// restart stays "processing" and is picked up again by the sweep.
+func (p *attachmentProcessor) Enqueue(attachmentID uuid.UUID) {
+	if !p.tryEnqueue(attachmentID) {
+		log.Printf("Attachment processing queue full, deferring attachment %s to the next sweep", attachmentID)
+	}
+}
+
+func (p *attachmentProcessor) tryEnqueue(attachmentID uuid.UUID) bool {
+	select {
+	case p.queue <- attachmentID:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *attachmentProcessor) Run() {
+	var wg sync.WaitGroup
+
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work()
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.sweepLoop()
+	}()
+
+	wg.Wait()
+}
+
+func (p *attachmentProcessor) Shutdown() {
+	p.cancel()
+}
+
+func (p *attachmentProcessor) work() {
+	for {
+		select {
+		case attachmentID := <-p.queue:
+			p.process(p.ctx, attachmentID)
+
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *attachmentProcessor) sweepLoop() {
+	p.sweep(time.Now())
+
+	ticker := time.NewTicker(p.sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.sweep(time.Now().Add(-p.sweepInterval))
+
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// sweep re-enqueues attachments still processing that were created before the
+// given time. Processing is idempotent, so picking up a job that is already
+// queued only costs a redundant status check.
+func (p *attachmentProcessor) sweep(createdBefore time.Time) {
+	ids, err := p.attachments.GetProcessingIDs(p.ctx, createdBefore, p.sweepBatch)
+	if err != nil {
+		log.Printf("Error sweeping processing attachments: %v", err)
+		return
+	}
+
+	for _, id := range ids {
+		if !p.tryEnqueue(id) {
+			return
+		}
+	}
+}
+
+func (p *attachmentProcessor) process(ctx context.Context, attachmentID uuid.UUID) {
+	attachment, err := p.attachments.GetByID(ctx, attachmentID)
+	if err != nil {
+		log.Printf("Error getting attachment %s: %v", attachmentID, err)
+		return
+	}
+
+	if attachment.Status != domain.AttachmentStatusProcessing {
+		return
+	}
+
+	if err := p.processImage(ctx, attachment); err != nil {
+		log.Printf("Error processing attachment %s: %v", attachment.ID, err)
+		p.fail(ctx, attachment)
+		return
+	}
+
+	p.notify(ctx, attachment)
+}
+
+func (p *attachmentProcessor) processImage(ctx context.Context, attachment *domain.Attachment) error {
+	reader, err := p.blobs.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return fmt.Errorf("get blob error: %w", err)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(reader, attachment.Size))
+	_ = reader.Close()
+	if err != nil {
+		return fmt.Errorf("read blob error: %w", err)
+	}
+
+	processed, err := p.images.Process(content, attachment.ContentType)
+	if err != nil {
+		return fmt.Errorf("process image error: %w", err)
+	}
+
+	thumbnail := domain.Thumbnail{
+		StorageKey:  attachment.ThumbnailKey(),
+		ContentType: processed.ThumbnailContentType,
+		Width:       processed.ThumbnailWidth,
+		Height:      processed.ThumbnailHeight,
+	}
+
+	if err := p.blobs.Put(ctx, thumbnail.StorageKey, bytes.NewReader(processed.Thumbnail), int64(len(processed.Thumbnail)), thumbnail.ContentType); err != nil {
+		return fmt.Errorf("store thumbnail error: %w", err)
+	}
+
+	originalKey := attachment.StorageKey
+	processedKey := attachment.ProcessedKey()
+	if err := p.blobs.Put(ctx, processedKey, bytes.NewReader(processed.Content), int64(len(processed.Content)), attachment.ContentType); err != nil {
+		return fmt.Errorf("store sanitized image error: %w", err)
+	}
+
+	if err := attachment.MarkProcessed(processedKey, int64(len(processed.Content)), processed.Width, processed.Height, processed.Blurhash, thumbnail); err != nil {
+		return fmt.Errorf("mark processed error: %w", err)
+	}
+
+	if err := p.attachments.UpdateProcessing(ctx, attachment); err != nil {
+		return fmt.Errorf("update attachment error: %w", err)
+	}
+
+	if err := p.blobs.Delete(ctx, originalKey); err != nil {
+		log.Printf("Error deleting original blob %s: %v", originalKey, err)
+	}
+
+	return nil
+}
+
+func (p *attachmentProcessor) fail(ctx context.Context, attachment *domain.Attachment) {
+	if err := attachment.MarkFailed(); err != nil {
+		log.Printf("Error marking attachment %s as failed: %v", attachment.ID, err)
+		return
+	}
+
+	if err := p.attachments.UpdateProcessing(ctx, attachment); err != nil {
+		log.Printf("Error updating failed attachment %s: %v", attachment.ID, err)
+		return
+	}
+
+	for _, key := range []string{attachment.StorageKey, attachment.ProcessedKey(), attachment.ThumbnailKey()} {
+		if err := p.blobs.Delete(ctx, key); err != nil {
+			log.Printf("Error deleting blob %s: %v", key, err)
+		}
+	}
+
+	p.notify(ctx, attachment)
+}
+
+func (p *attachmentProcessor) notify(ctx context.Context, attachment *domain.Attachment) {
+	notification := ws.OutgoingNotification{
+		Type:   AttachmentUpdatedEvent,
+		UserID: attachment.UserID,
+		Payload: AttachmentUpdate{
+			MessageID:      attachment.MessageID,
+			ConversationID: attachment.ConversationID,
+			Attachment:     presentation.FormatAttachmentDTO(attachment),
+		},
+	}
+
+	if err := p.notifications.Broadcast(ctx, attachment.ConversationID, notification); err != nil {
+		log.Printf("Error broadcasting attachment %s update: %v", attachment.ID, err)
+	}
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/infra/media"
+	ws "GitHub/go-chat/backend/internal/websocket"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeImageProcessor struct {
+	result media.ProcessedImage
+	err    error
+}
+
+func (p *fakeImageProcessor) Process(content []byte, contentType string) (media.ProcessedImage, error) {
+	return p.result, p.err
+}
+
+func newProcessingAttachment(t *testing.T) *domain.Attachment {
+	attachment, err := domain.NewAttachment(uuid.New(), uuid.New(), "cat.png", "image/png", int64(len(pngHeader)))
+	assert.NoError(t, err)
+	attachment.MessageID = uuid.New()
+	return attachment
+}
+
+func TestAttachmentProcessor_Process(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores thumbnail and sanitized image", func(t *testing.T) {
+		attachment := newProcessingAttachment(t)
+		mockAttachments := new(MockAttachmentRepository)
+		mockNotifications := new(MockNotificationServiceForMessageTest)
+		blobs := newFakeBlobStore()
+		blobs.blobs[attachment.StorageKey] = pngHeader
+
+		images := &fakeImageProcessor{result: media.ProcessedImage{
+			Content:              []byte("sanitized"),
+			Width:                640,
+			Height:               480,
+			Blurhash:             "LEHV6nWB2yk8pyo0adR*.7kCMdnj",
+			Thumbnail:            []byte("thumbnail"),
+			ThumbnailContentType: "image/png",
+			ThumbnailWidth:       320,
+			ThumbnailHeight:      240,
+		}}
+		processor := NewAttachmentProcessor(ctx, mockAttachments, blobs, images, mockNotifications, 1, time.Minute, 10).(*attachmentProcessor)
+
+		mockAttachments.On("GetByID", mock.Anything, attachment.ID).Return(attachment, nil)
+		mockAttachments.On("UpdateProcessing", mock.Anything, attachment).Return(nil)
+		mockNotifications.On("Broadcast", mock.Anything, attachment.ConversationID, mock.MatchedBy(func(notification ws.OutgoingNotification) bool {
+			update, ok := notification.Payload.(AttachmentUpdate)
+			return ok &&
+				notification.Type == AttachmentUpdatedEvent &&
+				update.MessageID == attachment.MessageID &&
+				update.Attachment.Status == domain.AttachmentStatusReady.String() &&
+				update.Attachment.ThumbnailURL != ""
+		})).Return(nil)
+
+		originalKey := attachment.StorageKey
+		thumbnailKey := attachment.ThumbnailKey()
+
+		processor.process(ctx, attachment.ID)
+
+		assert.Equal(t, domain.AttachmentStatusReady, attachment.Status)
+		assert.Equal(t, 640, attachment.Width)
+		assert.Equal(t, 480, attachment.Height)
+		assert.Equal(t, int64(len("sanitized")), attachment.Size)
+		assert.NotEqual(t, originalKey, attachment.StorageKey)
+		assert.NotContains(t, blobs.blobs, originalKey)
+		assert.Equal(t, []byte("sanitized"), blobs.blobs[attachment.StorageKey])
+		assert.Equal(t, []byte("thumbnail"), blobs.blobs[thumbnailKey])
+		mockAttachments.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("marks attachment failed and removes blobs", func(t *testing.T) {
+		attachment := newProcessingAttachment(t)
+		mockAttachments := new(MockAttachmentRepository)
+		mockNotifications := new(MockNotificationServiceForMessageTest)
+		blobs := newFakeBlobStore()
+		blobs.blobs[attachment.StorageKey] = pngHeader
+
+		processor := NewAttachmentProcessor(ctx, mockAttachments, blobs, &fakeImageProcessor{err: media.ErrMalformedImage}, mockNotifications, 1, time.Minute, 10).(*attachmentProcessor)
+
+		mockAttachments.On("GetByID", mock.Anything, attachment.ID).Return(attachment, nil)
+		mockAttachments.On("UpdateProcessing", mock.Anything, attachment).Return(nil)
+		mockNotifications.On("Broadcast", mock.Anything, attachment.ConversationID, mock.MatchedBy(func(notification ws.OutgoingNotification) bool {
+			update, ok := notification.Payload.(AttachmentUpdate)
+			return ok && update.Attachment.Status == domain.AttachmentStatusFailed.String()
+		})).Return(nil)
+
+		processor.process(ctx, attachment.ID)
+
+		assert.Equal(t, domain.AttachmentStatusFailed, attachment.Status)
+		assert.Empty(t, blobs.blobs)
+		mockAttachments.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("skips attachments that are not processing", func(t *testing.T) {
+		attachment := newProcessingAttachment(t)
+		assert.NoError(t, attachment.MarkFailed())
+		mockAttachments := new(MockAttachmentRepository)
+		mockNotifications := new(MockNotificationServiceForMessageTest)
+
+		processor := NewAttachmentProcessor(ctx, mockAttachments, newFakeBlobStore(), &fakeImageProcessor{}, mockNotifications, 1, time.Minute, 10).(*attachmentProcessor)
+
+		mockAttachments.On("GetByID", mock.Anything, attachment.ID).Return(attachment, nil)
+
+		processor.process(ctx, attachment.ID)
+
+		mockAttachments.AssertNotCalled(t, "UpdateProcessing", mock.Anything, mock.Anything)
+		mockNotifications.AssertNotCalled(t, "Broadcast", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAttachmentProcessor_Enqueue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("drops job when queue is full", func(t *testing.T) {
+		mockAttachments := new(MockAttachmentRepository)
+		processor := NewAttachmentProcessor(ctx, mockAttachments, newFakeBlobStore(), &fakeImageProcessor{}, new(MockNotificationServiceForMessageTest), 1, time.Minute, 10).(*attachmentProcessor)
+
+		for i := 0; i < cap(processor.queue); i++ {
+			processor.Enqueue(uuid.New())
+		}
+		processor.Enqueue(uuid.New())
+
+		assert.Len(t, processor.queue, cap(processor.queue))
+		mockAttachments.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+		mockAttachments.AssertNotCalled(t, "UpdateProcessing", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAttachmentProcessor_Sweep(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("re-enqueues attachments still processing", func(t *testing.T) {
+		ids := []uuid.UUID{uuid.New(), uuid.New()}
+		createdBefore := time.Now()
+		mockAttachments := new(MockAttachmentRepository)
+		processor := NewAttachmentProcessor(ctx, mockAttachments, newFakeBlobStore(), &fakeImageProcessor{}, new(MockNotificationServiceForMessageTest), 1, time.Minute, 10).(*attachmentProcessor)
+
+		mockAttachments.On("GetProcessingIDs", mock.Anything, createdBefore, 10).Return(ids, nil)
+
+		processor.sweep(createdBefore)
+
+		assert.Equal(t, ids[0], <-processor.queue)
+		assert.Equal(t, ids[1], <-processor.queue)
+		mockAttachments.AssertExpectations(t)
+	})
+}
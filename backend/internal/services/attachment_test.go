@@ -0,0 +1,376 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+	ws "GitHub/go-chat/backend/internal/websocket"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Attachment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) UpdateProcessing(ctx context.Context, attachment *domain.Attachment) error {
+	args := m.Called(ctx, attachment)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) GetProcessingIDs(ctx context.Context, createdBefore time.Time, limit int) ([]uuid.UUID, error) {
+	args := m.Called(ctx, createdBefore, limit)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+type MockAttachmentProcessor struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentProcessor) Enqueue(attachmentID uuid.UUID) {
+	m.Called(attachmentID)
+}
+
+func (m *MockAttachmentProcessor) Run() {}
+
+func (m *MockAttachmentProcessor) Shutdown() {}
+
+type fakeBlobStore struct {
+	blobs        map[string][]byte
+	contentTypes map[string]string
+}
+
+func newFakeBlobStore() *fakeBlobStore {
+	return &fakeBlobStore{
+		blobs:        make(map[string][]byte),
+		contentTypes: make(map[string]string),
+	}
+}
+
+func (s *fakeBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.blobs[key] = data
+	s.contentTypes[key] = contentType
+	return nil
+}
+
+func (s *fakeBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, assert.AnError
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *fakeBlobStore) Delete(ctx context.Context, key string) error {
+	delete(s.blobs, key)
+	delete(s.contentTypes, key)
+	return nil
+}
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestAttachmentService_Upload(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("stores blob and broadcasts message", func(t *testing.T) {
+		mockMessages := new(MockMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		mockNotifications := new(MockNotificationServiceForMessageTest)
+		mockProcessor := new(MockAttachmentProcessor)
+		blobs := newFakeBlobStore()
+		service := NewAttachmentService(new(MockAttachmentRepository), NewMessageService(mockMessages, mockQueries, mockNotifications), mockQueries, blobs, mockProcessor)
+
+		content := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{1}, 1024)...)
+
+		mockQueries.On("IsMember", conversationID, userID).Return(true, nil)
+		mockQueries.On("IsBlockedInDirectConversation", conversationID, userID).Return(false, nil)
+		mockMessages.On("Send", mock.Anything, mock.MatchedBy(func(message *domain.Message) bool {
+			attachment := message.Attachment()
+			return attachment != nil &&
+				attachment.ContentType == "image/png" &&
+				attachment.FileName == "cat.png" &&
+				attachment.Size == int64(len(content)) &&
+				message.Content.String() == "my cat"
+		})).Return(readModel.MessageDTO{ID: uuid.New()}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.Anything).Return(nil)
+		mockProcessor.On("Enqueue", mock.Anything).Return()
+
+		_, err := service.Upload(ctx, conversationID, userID, AttachmentUpload{
+			FileName: "cat.png",
+			Caption:  "my cat",
+			Size:     int64(len(content)),
+			Content:  bytes.NewReader(content),
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, blobs.blobs, 1)
+		for key, data := range blobs.blobs {
+			assert.True(t, strings.HasPrefix(key, "attachments/"+conversationID.String()+"/"))
+			assert.Equal(t, content, data)
+			assert.Equal(t, "image/png", blobs.contentTypes[key])
+		}
+		mockMessages.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+		mockProcessor.AssertExpectations(t)
+	})
+
+	t.Run("resolves caption mentions", func(t *testing.T) {
+		mockMessages := new(MockMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		mockNotifications := new(MockNotificationServiceForMessageTest)
+		mockProcessor := new(MockAttachmentProcessor)
+		service := NewAttachmentService(new(MockAttachmentRepository), NewMessageService(mockMessages, mockQueries, mockNotifications), mockQueries, newFakeBlobStore(), mockProcessor)
+		aliceID := uuid.New()
+		messageID := uuid.New()
+
+		mockQueries.On("IsMember", conversationID, userID).Return(true, nil)
+		mockQueries.On("IsBlockedInDirectConversation", conversationID, userID).Return(false, nil)
+		mockQueries.On("GetParticipantIDsByNames", conversationID, []string{"alice"}).Return([]uuid.UUID{aliceID}, nil)
+		mockMessages.On("Send", mock.Anything, mock.MatchedBy(func(message *domain.Message) bool {
+			return len(message.Mentions) == 1 && message.Mentions[0] == aliceID
+		})).Return(readModel.MessageDTO{ID: messageID}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "message" && len(n.MentionedUserIDs) == 1
+		})).Return(nil)
+		mockNotifications.On("NotifyUser", mock.Anything, aliceID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "mentioned"
+		})).Return(nil)
+		mockProcessor.On("Enqueue", mock.Anything).Return()
+
+		_, err := service.Upload(ctx, conversationID, userID, AttachmentUpload{
+			FileName: "cat.png",
+			Caption:  "look @alice",
+			Size:     int64(len(pngHeader)),
+			Content:  bytes.NewReader(pngHeader),
+		})
+
+		assert.NoError(t, err)
+		mockMessages.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("does not enqueue non image attachments", func(t *testing.T) {
+		mockMessages := new(MockMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		mockNotifications := new(MockNotificationServiceForMessageTest)
+		mockProcessor := new(MockAttachmentProcessor)
+		service := NewAttachmentService(new(MockAttachmentRepository), NewMessageService(mockMessages, mockQueries, mockNotifications), mockQueries, newFakeBlobStore(), mockProcessor)
+
+		content := []byte("just some notes")
+		mockQueries.On("IsMember", conversationID, userID).Return(true, nil)
+		mockQueries.On("IsBlockedInDirectConversation", conversationID, userID).Return(false, nil)
+		mockMessages.On("Send", mock.Anything, mock.Anything).Return(readModel.MessageDTO{ID: uuid.New()}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.Anything).Return(nil)
+
+		_, err := service.Upload(ctx, conversationID, userID, AttachmentUpload{
+			FileName: "notes.txt",
+			Size:     int64(len(content)),
+			Content:  bytes.NewReader(content),
+		})
+
+		assert.NoError(t, err)
+		mockProcessor.AssertNotCalled(t, "Enqueue", mock.Anything)
+	})
+
+	t.Run("rejects non members", func(t *testing.T) {
+		mockQueries := new(MockQueriesRepository)
+		blobs := newFakeBlobStore()
+		service := NewAttachmentService(new(MockAttachmentRepository), NewMessageService(new(MockMessageRepository), mockQueries, new(MockNotificationServiceForMessageTest)), mockQueries, blobs, new(MockAttachmentProcessor))
+
+		mockQueries.On("IsMember", conversationID, userID).Return(false, nil)
+
+		_, err := service.Upload(ctx, conversationID, userID, AttachmentUpload{
+			FileName: "cat.png",
+			Size:     int64(len(pngHeader)),
+			Content:  bytes.NewReader(pngHeader),
+		})
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotInConversation)
+		assert.Empty(t, blobs.blobs)
+	})
+
+	t.Run("rejects sniffed type that is not allowed", func(t *testing.T) {
+		mockQueries := new(MockQueriesRepository)
+		blobs := newFakeBlobStore()
+		service := NewAttachmentService(new(MockAttachmentRepository), NewMessageService(new(MockMessageRepository), mockQueries, new(MockNotificationServiceForMessageTest)), mockQueries, blobs, new(MockAttachmentProcessor))
+
+		content := []byte("<!DOCTYPE html><html><script>alert(1)</script></html>")
+		mockQueries.On("IsMember", conversationID, userID).Return(true, nil)
+		mockQueries.On("IsBlockedInDirectConversation", conversationID, userID).Return(false, nil)
+
+		_, err := service.Upload(ctx, conversationID, userID, AttachmentUpload{
+			FileName: "cat.png",
+			Size:     int64(len(content)),
+			Content:  bytes.NewReader(content),
+		})
+
+		assert.ErrorIs(t, err, domain.ErrorAttachmentTypeNotAllowed)
+		assert.Empty(t, blobs.blobs)
+	})
+
+	t.Run("removes blob when message cannot be stored", func(t *testing.T) {
+		mockMessages := new(MockMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		blobs := newFakeBlobStore()
+		service := NewAttachmentService(new(MockAttachmentRepository), NewMessageService(mockMessages, mockQueries, new(MockNotificationServiceForMessageTest)), mockQueries, blobs, new(MockAttachmentProcessor))
+
+		mockQueries.On("IsMember", conversationID, userID).Return(true, nil)
+		mockQueries.On("IsBlockedInDirectConversation", conversationID, userID).Return(false, nil)
+		mockMessages.On("Send", mock.Anything, mock.Anything).Return(readModel.MessageDTO{}, assert.AnError)
+
+		_, err := service.Upload(ctx, conversationID, userID, AttachmentUpload{
+			FileName: "cat.png",
+			Size:     int64(len(pngHeader)),
+			Content:  bytes.NewReader(pngHeader),
+		})
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, blobs.blobs)
+	})
+}
+
+func TestAttachmentService_Download(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	attachment, err := domain.NewAttachment(uuid.New(), uuid.New(), "cat.png", "image/png", int64(len(pngHeader)))
+	assert.NoError(t, err)
+
+	processing := *attachment
+
+	assert.NoError(t, attachment.MarkProcessed(attachment.StorageKey, int64(len(pngHeader)), 1, 1, "", domain.Thumbnail{
+		StorageKey:  attachment.ThumbnailKey(),
+		ContentType: "image/png",
+		Width:       1,
+		Height:      1,
+	}))
+
+	thumbnail := []byte("thumbnail")
+	blobs := newFakeBlobStore()
+	blobs.blobs[attachment.StorageKey] = pngHeader
+	blobs.blobs[attachment.ThumbnailKey()] = thumbnail
+
+	t.Run("member can download", func(t *testing.T) {
+		mockAttachments := new(MockAttachmentRepository)
+		mockQueries := new(MockQueriesRepository)
+		service := NewAttachmentService(mockAttachments, new(MockMessageService), mockQueries, blobs, new(MockAttachmentProcessor))
+
+		mockAttachments.On("GetByID", mock.Anything, attachment.ID).Return(attachment, nil)
+		mockQueries.On("IsMember", attachment.ConversationID, userID).Return(true, nil)
+		mockQueries.On("IsMessageHidden", attachment.MessageID, userID).Return(false, nil)
+
+		found, content, err := service.Download(ctx, attachment.ID, userID, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, attachment, found)
+		data, _ := io.ReadAll(content)
+		assert.Equal(t, pngHeader, data)
+	})
+
+	t.Run("member can download thumbnail", func(t *testing.T) {
+		mockAttachments := new(MockAttachmentRepository)
+		mockQueries := new(MockQueriesRepository)
+		service := NewAttachmentService(mockAttachments, new(MockMessageService), mockQueries, blobs, new(MockAttachmentProcessor))
+
+		mockAttachments.On("GetByID", mock.Anything, attachment.ID).Return(attachment, nil)
+		mockQueries.On("IsMember", attachment.ConversationID, userID).Return(true, nil)
+		mockQueries.On("IsMessageHidden", attachment.MessageID, userID).Return(false, nil)
+
+		_, content, err := service.Download(ctx, attachment.ID, userID, true)
+
+		assert.NoError(t, err)
+		data, _ := io.ReadAll(content)
+		assert.Equal(t, thumbnail, data)
+	})
+
+	t.Run("original is not served while processing", func(t *testing.T) {
+		mockAttachments := new(MockAttachmentRepository)
+		mockQueries := new(MockQueriesRepository)
+		service := NewAttachmentService(mockAttachments, new(MockMessageService), mockQueries, blobs, new(MockAttachmentProcessor))
+
+		mockAttachments.On("GetByID", mock.Anything, processing.ID).Return(&processing, nil)
+		mockQueries.On("IsMember", processing.ConversationID, userID).Return(true, nil)
+		mockQueries.On("IsMessageHidden", processing.MessageID, userID).Return(false, nil)
+
+		_, content, err := service.Download(ctx, processing.ID, userID, false)
+
+		assert.ErrorIs(t, err, domain.ErrorAttachmentNotReady)
+		assert.Nil(t, content)
+	})
+
+	t.Run("missing thumbnail", func(t *testing.T) {
+		mockAttachments := new(MockAttachmentRepository)
+		mockQueries := new(MockQueriesRepository)
+		service := NewAttachmentService(mockAttachments, new(MockMessageService), mockQueries, blobs, new(MockAttachmentProcessor))
+
+		mockAttachments.On("GetByID", mock.Anything, processing.ID).Return(&processing, nil)
+		mockQueries.On("IsMember", processing.ConversationID, userID).Return(true, nil)
+		mockQueries.On("IsMessageHidden", processing.MessageID, userID).Return(false, nil)
+
+		_, _, err := service.Download(ctx, processing.ID, userID, true)
+
+		assert.ErrorIs(t, err, domain.ErrorAttachmentNotFound)
+	})
+
+	t.Run("non member is rejected", func(t *testing.T) {
+		mockAttachments := new(MockAttachmentRepository)
+		mockQueries := new(MockQueriesRepository)
+		service := NewAttachmentService(mockAttachments, new(MockMessageService), mockQueries, blobs, new(MockAttachmentProcessor))
+
+		mockAttachments.On("GetByID", mock.Anything, attachment.ID).Return(attachment, nil)
+		mockQueries.On("IsMember", attachment.ConversationID, userID).Return(false, nil)
+
+		_, content, err := service.Download(ctx, attachment.ID, userID, false)
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotInConversation)
+		assert.Nil(t, content)
+	})
+
+	t.Run("message hidden by user is not served", func(t *testing.T) {
+		mockAttachments := new(MockAttachmentRepository)
+		mockQueries := new(MockQueriesRepository)
+		service := NewAttachmentService(mockAttachments, new(MockMessageService), mockQueries, blobs, new(MockAttachmentProcessor))
+
+		mockAttachments.On("GetByID", mock.Anything, attachment.ID).Return(attachment, nil)
+		mockQueries.On("IsMember", attachment.ConversationID, userID).Return(true, nil)
+		mockQueries.On("IsMessageHidden", attachment.MessageID, userID).Return(true, nil)
+
+		_, content, err := service.Download(ctx, attachment.ID, userID, false)
+
+		assert.ErrorIs(t, err, domain.ErrorAttachmentNotFound)
+		assert.Nil(t, content)
+	})
+
+	t.Run("missing attachment", func(t *testing.T) {
+		mockAttachments := new(MockAttachmentRepository)
+		service := NewAttachmentService(mockAttachments, new(MockMessageService), new(MockQueriesRepository), blobs, new(MockAttachmentProcessor))
+
+		mockAttachments.On("GetByID", mock.Anything, attachment.ID).Return(nil, domain.ErrorAttachmentNotFound)
+
+		_, _, err := service.Download(ctx, attachment.ID, userID, false)
+
+		assert.ErrorIs(t, err, domain.ErrorAttachmentNotFound)
+	})
+}
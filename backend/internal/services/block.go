@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+	"GitHub/go-chat/backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type BlockService interface {
+	Block(ctx context.Context, userID uuid.UUID, targetID uuid.UUID) error
+	Unblock(ctx context.Context, userID uuid.UUID, targetID uuid.UUID) error
+	GetBlockedUsers(userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error)
+}
+
+type blockService struct {
+	blocks  repository.BlockRepository
+	queries readModel.QueriesRepository
+}
+
+func NewBlockService(
+	blocks repository.BlockRepository,
+	queries readModel.QueriesRepository,
+) BlockService {
+	return &blockService{
+		blocks:  blocks,
+		queries: queries,
+	}
+}
+
+func (s *blockService) Block(ctx context.Context, userID uuid.UUID, targetID uuid.UUID) error {
+	block, err := domain.NewBlock(userID, targetID)
+	if err != nil {
+		return fmt.Errorf("new block error: %w", err)
+	}
+
+	if err := s.blocks.Store(ctx, block); err != nil {
+		return fmt.Errorf("store block error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *blockService) Unblock(ctx context.Context, userID uuid.UUID, targetID uuid.UUID) error {
+	if err := s.blocks.Delete(ctx, userID, targetID); err != nil {
+		return fmt.Errorf("delete block error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *blockService) GetBlockedUsers(userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error) {
+	users, err := s.queries.GetBlockedUsers(userID, paginationInfo)
+	if err != nil {
+		return nil, fmt.Errorf("get blocked users error: %w", err)
+	}
+
+	return users, nil
+}
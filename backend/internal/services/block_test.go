@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"GitHub/go-chat/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBlockRepository struct {
+	mock.Mock
+}
+
+func (m *MockBlockRepository) Store(ctx context.Context, block *domain.Block) error {
+	args := m.Called(ctx, block)
+	return args.Error(0)
+}
+
+func (m *MockBlockRepository) Delete(ctx context.Context, blockerID uuid.UUID, blockedID uuid.UUID) error {
+	args := m.Called(ctx, blockerID, blockedID)
+	return args.Error(0)
+}
+
+func TestBlockService_Block(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	targetID := uuid.New()
+
+	mockBlocks := new(MockBlockRepository)
+	service := NewBlockService(mockBlocks, new(MockQueriesRepository))
+
+	t.Run("successful block", func(t *testing.T) {
+		mockBlocks.On("Store", mock.Anything, mock.MatchedBy(func(b *domain.Block) bool {
+			return b.BlockerID == userID && b.BlockedID == targetID
+		})).Return(nil)
+
+		err := service.Block(ctx, userID, targetID)
+
+		assert.NoError(t, err)
+		mockBlocks.AssertExpectations(t)
+	})
+
+	t.Run("cannot block self", func(t *testing.T) {
+		err := service.Block(ctx, userID, userID)
+
+		assert.ErrorIs(t, err, domain.ErrorCannotBlockSelf)
+	})
+}
+
+func TestBlockService_Unblock(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	targetID := uuid.New()
+
+	mockBlocks := new(MockBlockRepository)
+	service := NewBlockService(mockBlocks, new(MockQueriesRepository))
+
+	t.Run("not blocked", func(t *testing.T) {
+		mockBlocks.On("Delete", mock.Anything, userID, targetID).Return(domain.ErrorBlockNotFound)
+
+		err := service.Unblock(ctx, userID, targetID)
+
+		assert.ErrorIs(t, err, domain.ErrorBlockNotFound)
+	})
+}
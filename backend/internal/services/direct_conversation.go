@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
 	"GitHub/go-chat/backend/internal/repository"
 
 	"github.com/google/uuid"
@@ -16,23 +17,34 @@ type DirectConversationService interface {
 
 type directConversationService struct {
 	directConversations repository.DirectConversationRepository
+	queries             readModel.QueriesRepository
 	notifications       NotificationService
 	cache               CacheService
 }
 
 func NewDirectConversationService(
 	directConversations repository.DirectConversationRepository,
+	queries readModel.QueriesRepository,
 	notifications NotificationService,
 	cache CacheService,
 ) DirectConversationService {
 	return &directConversationService{
 		directConversations: directConversations,
+		queries:             queries,
 		notifications:       notifications,
 		cache:               cache,
 	}
 }
 
 func (s *directConversationService) StartDirectConversation(ctx context.Context, fromUserID uuid.UUID, toUserID uuid.UUID) (uuid.UUID, error) {
+	blocked, err := s.queries.HasBlocked(toUserID, fromUserID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("has blocked error: %w", err)
+	}
+	if blocked {
+		return uuid.Nil, domain.ErrorUserBlocked
+	}
+
 	existingConversationID, err := s.directConversations.GetID(ctx, fromUserID, toUserID)
 	if err == nil {
 		return existingConversationID, nil
@@ -44,8 +44,8 @@ func (m *MockNotificationServiceForDirect) Broadcast(ctx context.Context, conver
 	return args.Error(0)
 }
 
-func (m *MockNotificationServiceForDirect) RegisterClient(ctx context.Context, conn *websocket.Conn, userID uuid.UUID) uuid.UUID {
-	args := m.Called(ctx, conn, userID)
+func (m *MockNotificationServiceForDirect) RegisterClient(ctx context.Context, conn *websocket.Conn, userID uuid.UUID, resume map[uuid.UUID]int64) uuid.UUID {
+	args := m.Called(ctx, conn, userID, resume)
 	return args.Get(0).(uuid.UUID)
 }
 
@@ -62,6 +62,11 @@ func (m *MockNotificationServiceForDirect) Shutdown() {
 	m.Called()
 }
 
+func (m *MockNotificationServiceForDirect) NotifyUser(ctx context.Context, userID uuid.UUID, notification ws.OutgoingNotification) error {
+	args := m.Called(ctx, userID, notification)
+	return args.Error(0)
+}
+
 type MockCacheServiceForDirect struct {
 	mock.Mock
 }
@@ -88,11 +93,15 @@ func TestDirectConversationService_StartDirectConversation(t *testing.T) {
 	existingConversationID := uuid.New()
 
 	mockDirectConversations := new(MockDirectConversationRepository)
+	mockQueries := new(MockQueriesRepository)
 	mockNotifications := new(MockNotificationServiceForDirect)
 	mockCache := new(MockCacheServiceForDirect)
 
+	mockQueries.On("HasBlocked", toUserID, fromUserID).Return(false, nil)
+
 	service := NewDirectConversationService(
 		mockDirectConversations,
+		mockQueries,
 		mockNotifications,
 		mockCache,
 	)
@@ -167,4 +176,15 @@ func TestDirectConversationService_StartDirectConversation(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, uuid.Nil, conversationID)
 	})
+
+	t.Run("blocked by recipient", func(t *testing.T) {
+		blockerID := uuid.New()
+		mockDirectConversations.ExpectedCalls = nil
+		mockQueries.On("HasBlocked", blockerID, fromUserID).Return(true, nil)
+
+		conversationID, err := service.StartDirectConversation(ctx, fromUserID, blockerID)
+
+		assert.ErrorIs(t, err, domain.ErrorUserBlocked)
+		assert.Equal(t, uuid.Nil, conversationID)
+	})
 }
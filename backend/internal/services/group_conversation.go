@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"GitHub/go-chat/backend/internal/domain"
 	"GitHub/go-chat/backend/internal/readModel"
@@ -13,9 +14,13 @@ import (
 )
 
 type GroupConversationService interface {
-	CreateGroupConversation(ctx context.Context, conversationID uuid.UUID, name string, userID uuid.UUID) error
+	CreateGroupConversation(ctx context.Context, conversationID uuid.UUID, name string, userID uuid.UUID, visibility string) error
 	DeleteGroupConversation(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error
 	Rename(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, name string) error
+	SetVisibility(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, visibility string) error
+	SetMessageRetention(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, ttl time.Duration) error
+	TransferOwnership(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, newOwnerID uuid.UUID) error
+	TransferOwnershipAndLeave(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, newOwnerID uuid.UUID) error
 }
 
 type groupConversationService struct {
@@ -42,12 +47,17 @@ func NewGroupConversationService(
 	}
 }
 
-func (s *groupConversationService) CreateGroupConversation(ctx context.Context, conversationID uuid.UUID, name string, userID uuid.UUID) error {
+func (s *groupConversationService) CreateGroupConversation(ctx context.Context, conversationID uuid.UUID, name string, userID uuid.UUID, visibility string) error {
 	if err := domain.ValidateConversationName(name); err != nil {
 		return fmt.Errorf("validate conversation name error: %w", err)
 	}
 
-	conversation, err := domain.NewGroupConversation(conversationID, name, userID)
+	conversationVisibility, err := domain.NewConversationVisibility(visibility)
+	if err != nil {
+		return fmt.Errorf("conversation visibility error: %w", err)
+	}
+
+	conversation, err := domain.NewGroupConversation(conversationID, name, userID, conversationVisibility)
 	if err != nil {
 		return fmt.Errorf("new group conversation error: %w", err)
 	}
@@ -64,12 +74,13 @@ func (s *groupConversationService) CreateGroupConversation(ctx context.Context,
 }
 
 func (s *groupConversationService) DeleteGroupConversation(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error {
-	isOwner, err := s.queries.IsMemberOwner(conversationID, userID)
+	participant, err := getParticipant(s.queries, conversationID, userID)
 	if err != nil {
-		return fmt.Errorf("is owner error: %w", err)
+		return fmt.Errorf("get participant error: %w", err)
 	}
-	if !isOwner {
-		return fmt.Errorf("user is not owner: %w", domain.ErrorUserNotOwner)
+
+	if err := participant.Authorize(domain.PermissionDeleteConversation); err != nil {
+		return fmt.Errorf("authorize error: %w", err)
 	}
 
 	if err := s.groupConversations.Delete(ctx, conversationID); err != nil {
@@ -92,12 +103,13 @@ func (s *groupConversationService) Rename(ctx context.Context, conversationID uu
 		return fmt.Errorf("validate conversation name error: %w", err)
 	}
 
-	isOwner, err := s.queries.IsMemberOwner(conversationID, userID)
+	participant, err := getParticipant(s.queries, conversationID, userID)
 	if err != nil {
-		return fmt.Errorf("is owner error: %w", err)
+		return fmt.Errorf("get participant error: %w", err)
 	}
-	if !isOwner {
-		return fmt.Errorf("user is not owner: %w", domain.ErrorUserNotOwner)
+
+	if err := participant.Authorize(domain.PermissionRename); err != nil {
+		return fmt.Errorf("authorize error: %w", err)
 	}
 
 	if err := s.groupConversations.Rename(ctx, conversationID, name); err != nil {
@@ -122,9 +134,182 @@ func (s *groupConversationService) Rename(ctx context.Context, conversationID uu
 		return fmt.Errorf("get conversation error: %w", err)
 	}
 
-	if err := s.notifications.Broadcast(ctx, conversationDTO.ID, ws.OutgoingNotification{Type: "conversation_updated", Payload: conversationDTO}); err != nil {
+	if err := s.notifications.Broadcast(ctx, conversationDTO.ID, conversationUpdated(conversationDTO)); err != nil {
+		return fmt.Errorf("notify error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *groupConversationService) SetVisibility(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, visibility string) error {
+	conversationVisibility, err := domain.NewConversationVisibility(visibility)
+	if err != nil {
+		return fmt.Errorf("conversation visibility error: %w", err)
+	}
+
+	participant, err := getParticipant(s.queries, conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("get participant error: %w", err)
+	}
+
+	if err := participant.Authorize(domain.PermissionChangeVisibility); err != nil {
+		return fmt.Errorf("authorize error: %w", err)
+	}
+
+	if err := s.groupConversations.SetVisibility(ctx, conversationID, conversationVisibility); err != nil {
+		return fmt.Errorf("set visibility error: %w", err)
+	}
+
+	visibilityMessage, err := domain.NewMessage(conversationID, userID, domain.MessageTypeSystem, fmt.Sprintf("made the conversation %s", conversationVisibility))
+	if err != nil {
+		return fmt.Errorf("create visibility message error: %w", err)
+	}
+
+	if _, err := s.messages.Send(ctx, visibilityMessage); err != nil {
+		return fmt.Errorf("store visibility message error: %w", err)
+	}
+
+	if err := s.cache.InvalidateConversation(ctx, conversationID); err != nil {
+		return fmt.Errorf("invalidate cache error: %w", err)
+	}
+
+	conversationDTO, err := s.queries.GetConversation(conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("get conversation error: %w", err)
+	}
+
+	if err := s.notifications.Broadcast(ctx, conversationDTO.ID, conversationUpdated(conversationDTO)); err != nil {
+		return fmt.Errorf("notify error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *groupConversationService) SetMessageRetention(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, ttl time.Duration) error {
+	retention, err := domain.NewMessageRetention(ttl)
+	if err != nil {
+		return fmt.Errorf("message retention error: %w", err)
+	}
+
+	participant, err := getParticipant(s.queries, conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("get participant error: %w", err)
+	}
+
+	if err := participant.Authorize(domain.PermissionSetRetention); err != nil {
+		return fmt.Errorf("authorize error: %w", err)
+	}
+
+	if err := s.groupConversations.SetMessageRetention(ctx, conversationID, retention); err != nil {
+		return fmt.Errorf("set message retention error: %w", err)
+	}
+
+	text := "turned off disappearing messages"
+	if retention.Enabled() {
+		text = fmt.Sprintf("set messages to disappear after %s", retention)
+	}
+
+	retentionMessage, err := domain.NewMessage(conversationID, userID, domain.MessageTypeSystem, text)
+	if err != nil {
+		return fmt.Errorf("create retention message error: %w", err)
+	}
+
+	if _, err := s.messages.Send(ctx, retentionMessage); err != nil {
+		return fmt.Errorf("store retention message error: %w", err)
+	}
+
+	if err := s.cache.InvalidateConversation(ctx, conversationID); err != nil {
+		return fmt.Errorf("invalidate cache error: %w", err)
+	}
+
+	conversationDTO, err := s.queries.GetConversation(conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("get conversation error: %w", err)
+	}
+
+	if err := s.notifications.Broadcast(ctx, conversationDTO.ID, conversationUpdated(conversationDTO)); err != nil {
+		return fmt.Errorf("notify error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *groupConversationService) TransferOwnership(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, newOwnerID uuid.UUID) error {
+	return s.transferOwnership(ctx, conversationID, userID, newOwnerID, s.groupConversations.TransferOwnership)
+}
+
+// TransferOwnershipAndLeave promotes the successor and removes the previous
+// owner in one transaction, so a failed leave never leaves a demoted owner behind.
+func (s *groupConversationService) TransferOwnershipAndLeave(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, newOwnerID uuid.UUID) error {
+	return s.transferOwnership(ctx, conversationID, userID, newOwnerID, s.groupConversations.TransferOwnershipAndLeave)
+}
+
+func (s *groupConversationService) transferOwnership(
+	ctx context.Context,
+	conversationID uuid.UUID,
+	userID uuid.UUID,
+	newOwnerID uuid.UUID,
+	store func(ctx context.Context, previousOwner *domain.Participant, newOwner *domain.Participant) error,
+) error {
+	owner, err := getParticipant(s.queries, conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("get participant error: %w", err)
+	}
+
+	successor, err := getParticipant(s.queries, conversationID, newOwnerID)
+	if err != nil {
+		return fmt.Errorf("get successor error: %w", err)
+	}
+
+	if err := owner.TransferOwnership(successor); err != nil {
+		return fmt.Errorf("transfer ownership error: %w", err)
+	}
+
+	if err := store(ctx, owner, successor); err != nil {
+		return fmt.Errorf("store ownership error: %w", err)
+	}
+
+	ownerMessage, err := domain.NewMessage(conversationID, newOwnerID, domain.MessageTypeSystem, "is now the owner of the conversation")
+	if err != nil {
+		return fmt.Errorf("create owner message error: %w", err)
+	}
+
+	if _, err := s.messages.Send(ctx, ownerMessage); err != nil {
+		return fmt.Errorf("store owner message error: %w", err)
+	}
+
+	if err := s.cache.InvalidateConversation(ctx, conversationID); err != nil {
+		return fmt.Errorf("invalidate cache error: %w", err)
+	}
+
+	conversationDTO, err := s.queries.GetConversation(conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("get conversation error: %w", err)
+	}
+
+	if err := s.notifications.Broadcast(ctx, conversationDTO.ID, conversationUpdated(conversationDTO)); err != nil {
 		return fmt.Errorf("notify error: %w", err)
 	}
 
 	return nil
 }
+
+// conversationUpdated builds the conversation_updated broadcast from the shared
+// fields only; role, permissions, ownership and the latest visible pin differ
+// per member and are left out so one member's view never overwrites another's.
+func conversationUpdated(conversation readModel.ConversationFullDTO) ws.OutgoingNotification {
+	return ws.OutgoingNotification{
+		Type: "conversation_updated",
+		Payload: readModel.ConversationUpdatedDTO{
+			ID:                conversation.ID,
+			Name:              conversation.Name,
+			Avatar:            conversation.Avatar,
+			CreatedAt:         conversation.CreatedAt,
+			Type:              conversation.Type,
+			ParticipantsCount: conversation.ParticipantsCount,
+			Visibility:        conversation.Visibility,
+			PinnedCount:       conversation.PinnedCount,
+			MessageTTL:        conversation.MessageTTL,
+		},
+	}
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"testing"
+	"time"
 
 	"GitHub/go-chat/backend/internal/domain"
 	"GitHub/go-chat/backend/internal/readModel"
@@ -38,6 +39,26 @@ func (m *MockGroupConversationRepository) Delete(ctx context.Context, id uuid.UU
 	return args.Error(0)
 }
 
+func (m *MockGroupConversationRepository) SetVisibility(ctx context.Context, conversationID uuid.UUID, visibility domain.ConversationVisibility) error {
+	args := m.Called(ctx, conversationID, visibility)
+	return args.Error(0)
+}
+
+func (m *MockGroupConversationRepository) SetMessageRetention(ctx context.Context, conversationID uuid.UUID, retention domain.MessageRetention) error {
+	args := m.Called(ctx, conversationID, retention)
+	return args.Error(0)
+}
+
+func (m *MockGroupConversationRepository) TransferOwnership(ctx context.Context, previousOwner *domain.Participant, newOwner *domain.Participant) error {
+	args := m.Called(ctx, previousOwner, newOwner)
+	return args.Error(0)
+}
+
+func (m *MockGroupConversationRepository) TransferOwnershipAndLeave(ctx context.Context, previousOwner *domain.Participant, newOwner *domain.Participant) error {
+	args := m.Called(ctx, previousOwner, newOwner)
+	return args.Error(0)
+}
+
 func (m *MockGroupConversationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.GroupConversation, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -50,13 +71,33 @@ type MockQueriesRepository struct {
 	mock.Mock
 }
 
+func (m *MockQueriesRepository) GetPublicGroups(userID uuid.UUID, search string, paginationInfo readModel.PaginationInfo) ([]readModel.PublicGroupDTO, error) {
+	args := m.Called(userID, search, paginationInfo)
+	return args.Get(0).([]readModel.PublicGroupDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepository) GetPendingJoinRequests(conversationID uuid.UUID) ([]readModel.JoinRequestDTO, error) {
+	args := m.Called(conversationID)
+	return args.Get(0).([]readModel.JoinRequestDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepository) GetPinnedMessages(conversationID uuid.UUID, userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.PinnedMessageDTO, error) {
+	args := m.Called(conversationID, userID, paginationInfo)
+	return args.Get(0).([]readModel.PinnedMessageDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepository) GetBlockedUsers(userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error) {
+	args := m.Called(userID, paginationInfo)
+	return args.Get(0).([]readModel.ContactDTO), args.Error(1)
+}
+
 func (m *MockQueriesRepository) GetContacts(userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error) {
 	args := m.Called(userID, paginationInfo)
 	return args.Get(0).([]readModel.ContactDTO), args.Error(1)
 }
 
-func (m *MockQueriesRepository) GetPotentialInvitees(conversationID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error) {
-	args := m.Called(conversationID, paginationInfo)
+func (m *MockQueriesRepository) GetPotentialInvitees(conversationID uuid.UUID, userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error) {
+	args := m.Called(conversationID, userID, paginationInfo)
 	return args.Get(0).([]readModel.ContactDTO), args.Error(1)
 }
 
@@ -90,8 +131,8 @@ func (m *MockQueriesRepository) RenameConversationAndReturn(conversationID uuid.
 	return args.Error(0)
 }
 
-func (m *MockQueriesRepository) GetConversationMessages(conversationID uuid.UUID, cursor *readModel.MessageCursor, limit int) (readModel.MessagePageDTO, error) {
-	args := m.Called(conversationID, cursor, limit)
+func (m *MockQueriesRepository) GetConversationMessages(conversationID uuid.UUID, userID uuid.UUID, cursor *readModel.MessageCursor, limit int) (readModel.MessagePageDTO, error) {
+	args := m.Called(conversationID, userID, cursor, limit)
 	return args.Get(0).(readModel.MessagePageDTO), args.Error(1)
 }
 
@@ -100,6 +141,11 @@ func (m *MockQueriesRepository) GetNotificationMessage(messageID uuid.UUID) (rea
 	return args.Get(0).(readModel.MessageDTO), args.Error(1)
 }
 
+func (m *MockQueriesRepository) GetMessageRevisions(messageID uuid.UUID, userID uuid.UUID) ([]readModel.MessageRevisionDTO, error) {
+	args := m.Called(messageID, userID)
+	return args.Get(0).([]readModel.MessageRevisionDTO), args.Error(1)
+}
+
 func (m *MockQueriesRepository) StoreMessageAndReturn(id uuid.UUID, conversationID uuid.UUID, userID uuid.UUID, content string, messageType int32) (readModel.MessageDTO, error) {
 	args := m.Called(id, conversationID, userID, content, messageType)
 	return args.Get(0).(readModel.MessageDTO), args.Error(1)
@@ -115,6 +161,54 @@ func (m *MockQueriesRepository) IsMemberOwner(conversationID uuid.UUID, userID u
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockQueriesRepository) GetActiveInviteLinks(conversationID uuid.UUID) ([]readModel.InviteLinkDTO, error) {
+	args := m.Called(conversationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]readModel.InviteLinkDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepository) GetMemberRole(conversationID uuid.UUID, userID uuid.UUID) (string, error) {
+	args := m.Called(conversationID, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockQueriesRepository) GetGroupVisibility(conversationID uuid.UUID) (string, error) {
+	args := m.Called(conversationID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockQueriesRepository) GetMemberIDsByRoles(conversationID uuid.UUID, roles []string) ([]uuid.UUID, error) {
+	args := m.Called(conversationID, roles)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockQueriesRepository) GetParticipantIDsByNames(conversationID uuid.UUID, names []string) ([]uuid.UUID, error) {
+	args := m.Called(conversationID, names)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockQueriesRepository) HasBlocked(blockerID uuid.UUID, blockedID uuid.UUID) (bool, error) {
+	args := m.Called(blockerID, blockedID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQueriesRepository) IsBlockedInDirectConversation(conversationID uuid.UUID, userID uuid.UUID) (bool, error) {
+	args := m.Called(conversationID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQueriesRepository) IsMessageHidden(messageID uuid.UUID, userID uuid.UUID) (bool, error) {
+	args := m.Called(messageID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQueriesRepository) GetLongestStandingMember(conversationID uuid.UUID, excludeUserID uuid.UUID) (uuid.UUID, error) {
+	args := m.Called(conversationID, excludeUserID)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
 func (m *MockQueriesRepository) InviteToConversationAtomic(conversationID uuid.UUID, inviteeID uuid.UUID, participantID uuid.UUID) (uuid.UUID, error) {
 	args := m.Called(conversationID, inviteeID, participantID)
 	return args.Get(0).(uuid.UUID), args.Error(1)
@@ -125,6 +219,39 @@ func (m *MockQueriesRepository) LeaveConversationAtomic(conversationID uuid.UUID
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockQueriesRepository) GetThreadMessages(threadID uuid.UUID, userID uuid.UUID, cursor *readModel.MessageCursor, limit int) (readModel.MessagePageDTO, error) {
+	args := m.Called(threadID, userID, cursor, limit)
+	return args.Get(0).(readModel.MessagePageDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepository) GetMentions(userID uuid.UUID, cursor *readModel.MessageCursor, limit int) (readModel.MessagePageDTO, error) {
+	args := m.Called(userID, cursor, limit)
+	return args.Get(0).(readModel.MessagePageDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepository) GetScheduledMessages(userID uuid.UUID, conversationID *uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ScheduledMessageDTO, error) {
+	args := m.Called(userID, conversationID, paginationInfo)
+	return args.Get(0).([]readModel.ScheduledMessageDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepository) GetMessageReactions(messageID uuid.UUID) ([]readModel.ReactionDTO, error) {
+	args := m.Called(messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]readModel.ReactionDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepository) GetPoll(messageID uuid.UUID) (readModel.PollDTO, error) {
+	args := m.Called(messageID)
+	return args.Get(0).(readModel.PollDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepository) SearchMessages(userID uuid.UUID, conversationID *uuid.UUID, query string, cursor *readModel.MessageSearchCursor, limit int) (readModel.MessageSearchPageDTO, error) {
+	args := m.Called(userID, conversationID, query, cursor, limit)
+	return args.Get(0).(readModel.MessageSearchPageDTO), args.Error(1)
+}
+
 type MockMessageService struct {
 	mock.Mock
 }
@@ -134,6 +261,26 @@ func (m *MockMessageService) Send(ctx context.Context, message *domain.Message)
 	return args.Get(0).(readModel.MessageDTO), args.Error(1)
 }
 
+func (m *MockMessageService) Edit(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, content string) (readModel.MessageDTO, error) {
+	args := m.Called(ctx, messageID, userID, content)
+	return args.Get(0).(readModel.MessageDTO), args.Error(1)
+}
+
+func (m *MockMessageService) Delete(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, scope domain.MessageDeletionScope) error {
+	args := m.Called(ctx, messageID, userID, scope)
+	return args.Error(0)
+}
+
+func (m *MockMessageService) Reply(ctx context.Context, parentID uuid.UUID, userID uuid.UUID, content string) (readModel.MessageDTO, error) {
+	args := m.Called(ctx, parentID, userID, content)
+	return args.Get(0).(readModel.MessageDTO), args.Error(1)
+}
+
+func (m *MockMessageService) Forward(ctx context.Context, messageID uuid.UUID, conversationID uuid.UUID, userID uuid.UUID) (readModel.MessageDTO, error) {
+	args := m.Called(ctx, messageID, conversationID, userID)
+	return args.Get(0).(readModel.MessageDTO), args.Error(1)
+}
+
 type MockNotificationService struct {
 	mock.Mock
 }
@@ -143,8 +290,8 @@ func (m *MockNotificationService) Broadcast(ctx context.Context, conversationID
 	return args.Error(0)
 }
 
-func (m *MockNotificationService) RegisterClient(ctx context.Context, conn *websocket.Conn, userID uuid.UUID) uuid.UUID {
-	args := m.Called(ctx, conn, userID)
+func (m *MockNotificationService) RegisterClient(ctx context.Context, conn *websocket.Conn, userID uuid.UUID, resume map[uuid.UUID]int64) uuid.UUID {
+	args := m.Called(ctx, conn, userID, resume)
 	return args.Get(0).(uuid.UUID)
 }
 
@@ -161,6 +308,11 @@ func (m *MockNotificationService) Shutdown() {
 	m.Called()
 }
 
+func (m *MockNotificationService) NotifyUser(ctx context.Context, userID uuid.UUID, notification ws.OutgoingNotification) error {
+	args := m.Called(ctx, userID, notification)
+	return args.Error(0)
+}
+
 type MockCacheService struct {
 	mock.Mock
 }
@@ -204,7 +356,7 @@ func TestGroupConversationService_CreateGroupConversation(t *testing.T) {
 		mockGroupConversations.On("Store", mock.Anything, mock.AnythingOfType("*domain.GroupConversation")).Return(nil)
 		mockCache.On("InvalidateUserConversations", mock.Anything, userID).Return(nil)
 
-		err := service.CreateGroupConversation(ctx, conversationID, name, userID)
+		err := service.CreateGroupConversation(ctx, conversationID, name, userID, "public")
 
 		assert.NoError(t, err)
 		mockGroupConversations.AssertExpectations(t)
@@ -216,7 +368,7 @@ func TestGroupConversationService_CreateGroupConversation(t *testing.T) {
 		mockCache.ExpectedCalls = nil
 		mockGroupConversations.On("Store", mock.Anything, mock.AnythingOfType("*domain.GroupConversation")).Return(assert.AnError)
 
-		err := service.CreateGroupConversation(ctx, conversationID, name, userID)
+		err := service.CreateGroupConversation(ctx, conversationID, name, userID, "public")
 
 		assert.Error(t, err)
 	})
@@ -227,7 +379,7 @@ func TestGroupConversationService_CreateGroupConversation(t *testing.T) {
 		mockGroupConversations.On("Store", mock.Anything, mock.AnythingOfType("*domain.GroupConversation")).Return(nil)
 		mockCache.On("InvalidateUserConversations", mock.Anything, userID).Return(assert.AnError)
 
-		err := service.CreateGroupConversation(ctx, conversationID, name, userID)
+		err := service.CreateGroupConversation(ctx, conversationID, name, userID, "public")
 
 		assert.Error(t, err)
 	})
@@ -253,7 +405,7 @@ func TestGroupConversationService_DeleteGroupConversation(t *testing.T) {
 	)
 
 	t.Run("successful deletion", func(t *testing.T) {
-		mockQueries.On("IsMemberOwner", conversationID, userID).Return(true, nil)
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("owner", nil)
 		mockGroupConversations.On("Delete", mock.Anything, conversationID).Return(nil)
 		mockCache.On("InvalidateConversation", mock.Anything, conversationID).Return(nil)
 		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.Anything).Return(nil)
@@ -267,13 +419,121 @@ func TestGroupConversationService_DeleteGroupConversation(t *testing.T) {
 		mockNotifications.AssertExpectations(t)
 	})
 
-	t.Run("user not owner", func(t *testing.T) {
+	t.Run("admin cannot delete", func(t *testing.T) {
 		mockQueries.ExpectedCalls = nil
-		mockQueries.On("IsMemberOwner", conversationID, userID).Return(false, nil)
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("admin", nil)
 
 		err := service.DeleteGroupConversation(ctx, conversationID, userID)
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrorInsufficientPermissions)
+	})
+}
+
+func TestGroupConversationService_SetVisibility(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+
+	mockGroupConversations := new(MockGroupConversationRepository)
+	mockQueries := new(MockQueriesRepository)
+	mockMessages := new(MockMessageService)
+	mockNotifications := new(MockNotificationService)
+	mockCache := new(MockCacheService)
+
+	service := NewGroupConversationService(
+		mockGroupConversations,
+		mockQueries,
+		mockMessages,
+		mockNotifications,
+		mockCache,
+	)
+
+	t.Run("admin makes group private", func(t *testing.T) {
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("admin", nil)
+		mockGroupConversations.On("SetVisibility", mock.Anything, conversationID, domain.ConversationVisibilityPrivate).Return(nil)
+		mockMessages.On("Send", mock.Anything, mock.AnythingOfType("*domain.Message")).Return(readModel.MessageDTO{}, nil)
+		mockCache.On("InvalidateConversation", mock.Anything, conversationID).Return(nil)
+		mockQueries.On("GetConversation", conversationID, userID).Return(readModel.ConversationFullDTO{ID: conversationID}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.Anything).Return(nil)
+
+		err := service.SetVisibility(ctx, conversationID, userID, "private")
+
+		assert.NoError(t, err)
+		mockGroupConversations.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("moderator cannot change visibility", func(t *testing.T) {
+		mockQueries.ExpectedCalls = nil
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("moderator", nil)
+
+		err := service.SetVisibility(ctx, conversationID, userID, "public")
+
+		assert.ErrorIs(t, err, domain.ErrorInsufficientPermissions)
+	})
+
+	t.Run("unknown visibility", func(t *testing.T) {
+		err := service.SetVisibility(ctx, conversationID, userID, "secret")
+
+		assert.ErrorIs(t, err, domain.ErrorUnknownVisibility)
+	})
+}
+
+func TestGroupConversationService_SetMessageRetention(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+
+	mockGroupConversations := new(MockGroupConversationRepository)
+	mockQueries := new(MockQueriesRepository)
+	mockMessages := new(MockMessageService)
+	mockNotifications := new(MockNotificationService)
+	mockCache := new(MockCacheService)
+
+	service := NewGroupConversationService(
+		mockGroupConversations,
+		mockQueries,
+		mockMessages,
+		mockNotifications,
+		mockCache,
+	)
+
+	t.Run("owner enables disappearing messages", func(t *testing.T) {
+		retention, _ := domain.NewMessageRetention(time.Hour)
+
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("owner", nil)
+		mockGroupConversations.On("SetMessageRetention", mock.Anything, conversationID, retention).Return(nil)
+		mockMessages.On("Send", mock.Anything, mock.MatchedBy(func(m *domain.Message) bool {
+			return m.Content.String() == "set messages to disappear after 1h"
+		})).Return(readModel.MessageDTO{}, nil)
+		mockCache.On("InvalidateConversation", mock.Anything, conversationID).Return(nil)
+		mockQueries.On("GetConversation", conversationID, userID).Return(readModel.ConversationFullDTO{ID: conversationID, Role: "owner", MessageTTL: 3600}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, ws.OutgoingNotification{
+			Type:    "conversation_updated",
+			Payload: readModel.ConversationUpdatedDTO{ID: conversationID, MessageTTL: 3600},
+		}).Return(nil)
+
+		err := service.SetMessageRetention(ctx, conversationID, userID, time.Hour)
+
+		assert.NoError(t, err)
+		mockGroupConversations.AssertExpectations(t)
+		mockMessages.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("admin cannot change retention", func(t *testing.T) {
+		mockQueries.ExpectedCalls = nil
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("admin", nil)
+
+		err := service.SetMessageRetention(ctx, conversationID, userID, time.Hour)
+
+		assert.ErrorIs(t, err, domain.ErrorInsufficientPermissions)
+	})
+
+	t.Run("invalid retention", func(t *testing.T) {
+		err := service.SetMessageRetention(ctx, conversationID, userID, time.Second)
+
+		assert.ErrorIs(t, err, domain.ErrorInvalidMessageRetention)
 	})
 }
 
@@ -298,12 +558,15 @@ func TestGroupConversationService_Rename(t *testing.T) {
 	)
 
 	t.Run("successful rename", func(t *testing.T) {
-		mockQueries.On("IsMemberOwner", conversationID, userID).Return(true, nil)
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("admin", nil)
 		mockGroupConversations.On("Rename", mock.Anything, conversationID, newName).Return(nil)
 		mockMessages.On("Send", mock.Anything, mock.AnythingOfType("*domain.Message")).Return(readModel.MessageDTO{}, nil)
 		mockCache.On("InvalidateConversation", mock.Anything, conversationID).Return(nil)
-		mockQueries.On("GetConversation", conversationID, userID).Return(readModel.ConversationFullDTO{ID: conversationID}, nil)
-		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.Anything).Return(nil)
+		mockQueries.On("GetConversation", conversationID, userID).Return(readModel.ConversationFullDTO{ID: conversationID, Name: newName, Role: "admin", IsOwner: true}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, ws.OutgoingNotification{
+			Type:    "conversation_updated",
+			Payload: readModel.ConversationUpdatedDTO{ID: conversationID, Name: newName},
+		}).Return(nil)
 
 		err := service.Rename(ctx, conversationID, userID, newName)
 
@@ -315,12 +578,84 @@ func TestGroupConversationService_Rename(t *testing.T) {
 		mockCache.AssertExpectations(t)
 	})
 
-	t.Run("user not owner", func(t *testing.T) {
+	t.Run("moderator cannot rename", func(t *testing.T) {
 		mockQueries.ExpectedCalls = nil
-		mockQueries.On("IsMemberOwner", conversationID, userID).Return(false, nil)
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("moderator", nil)
 
 		err := service.Rename(ctx, conversationID, userID, newName)
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrorInsufficientPermissions)
+	})
+}
+
+func TestGroupConversationService_TransferOwnership(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+	newOwnerID := uuid.New()
+
+	mockGroupConversations := new(MockGroupConversationRepository)
+	mockQueries := new(MockQueriesRepository)
+	mockMessages := new(MockMessageService)
+	mockNotifications := new(MockNotificationService)
+	mockCache := new(MockCacheService)
+
+	service := NewGroupConversationService(
+		mockGroupConversations,
+		mockQueries,
+		mockMessages,
+		mockNotifications,
+		mockCache,
+	)
+
+	t.Run("successful transfer", func(t *testing.T) {
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("owner", nil)
+		mockQueries.On("GetMemberRole", conversationID, newOwnerID).Return("member", nil)
+		mockGroupConversations.On("TransferOwnership", mock.Anything,
+			mock.MatchedBy(func(p *domain.Participant) bool {
+				return p.UserID == userID && p.Role == domain.ParticipantRoleAdmin
+			}),
+			mock.MatchedBy(func(p *domain.Participant) bool {
+				return p.UserID == newOwnerID && p.Role == domain.ParticipantRoleOwner
+			}),
+		).Return(nil)
+		mockMessages.On("Send", mock.Anything, mock.MatchedBy(func(message *domain.Message) bool {
+			return message.UserID == newOwnerID && message.Type == domain.MessageTypeSystem
+		})).Return(readModel.MessageDTO{}, nil)
+		mockCache.On("InvalidateConversation", mock.Anything, conversationID).Return(nil)
+		mockQueries.On("GetConversation", conversationID, userID).Return(readModel.ConversationFullDTO{ID: conversationID, IsOwner: true, Role: "owner"}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, ws.OutgoingNotification{
+			Type:    "conversation_updated",
+			Payload: readModel.ConversationUpdatedDTO{ID: conversationID},
+		}).Return(nil)
+
+		err := service.TransferOwnership(ctx, conversationID, userID, newOwnerID)
+
+		assert.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+		mockGroupConversations.AssertExpectations(t)
+		mockMessages.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("only owner can transfer", func(t *testing.T) {
+		mockQueries.ExpectedCalls = nil
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("admin", nil)
+		mockQueries.On("GetMemberRole", conversationID, newOwnerID).Return("member", nil)
+
+		err := service.TransferOwnership(ctx, conversationID, userID, newOwnerID)
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotOwner)
+	})
+
+	t.Run("new owner must be a member", func(t *testing.T) {
+		mockQueries.ExpectedCalls = nil
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("owner", nil)
+		mockQueries.On("GetMemberRole", conversationID, newOwnerID).Return("", domain.ErrorUserNotInConversation)
+
+		err := service.TransferOwnership(ctx, conversationID, userID, newOwnerID)
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotInConversation)
 	})
 }
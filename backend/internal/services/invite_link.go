@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+	"GitHub/go-chat/backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type InviteLinkService interface {
+	CreateInviteLink(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, maxUses *int, expiresAt *time.Time) (readModel.InviteLinkDTO, error)
+	RevokeInviteLink(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, linkID uuid.UUID) error
+	GetInviteLinks(conversationID uuid.UUID, userID uuid.UUID) ([]readModel.InviteLinkDTO, error)
+}
+
+type inviteLinkService struct {
+	inviteLinks repository.InviteLinkRepository
+	queries     readModel.QueriesRepository
+	tokens      InviteTokens
+}
+
+func NewInviteLinkService(
+	inviteLinks repository.InviteLinkRepository,
+	queries readModel.QueriesRepository,
+	tokens InviteTokens,
+) InviteLinkService {
+	return &inviteLinkService{
+		inviteLinks: inviteLinks,
+		queries:     queries,
+		tokens:      tokens,
+	}
+}
+
+func (s *inviteLinkService) CreateInviteLink(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, maxUses *int, expiresAt *time.Time) (readModel.InviteLinkDTO, error) {
+	if err := s.authorize(conversationID, userID); err != nil {
+		return readModel.InviteLinkDTO{}, err
+	}
+
+	link, err := domain.NewInviteLink(conversationID, userID, maxUses, expiresAt, time.Now())
+	if err != nil {
+		return readModel.InviteLinkDTO{}, fmt.Errorf("new invite link error: %w", err)
+	}
+
+	if err := s.inviteLinks.Store(ctx, link); err != nil {
+		return readModel.InviteLinkDTO{}, fmt.Errorf("store invite link error: %w", err)
+	}
+
+	creator, err := s.queries.GetUserByID(userID)
+	if err != nil {
+		return readModel.InviteLinkDTO{}, fmt.Errorf("get creator error: %w", err)
+	}
+
+	return readModel.InviteLinkDTO{
+		ID:             link.ID,
+		ConversationID: link.ConversationID,
+		Token:          s.tokens.Sign(link.ID),
+		Creator:        creator,
+		MaxUses:        link.MaxUses,
+		UseCount:       link.UseCount,
+		ExpiresAt:      link.ExpiresAt,
+		CreatedAt:      link.CreatedAt,
+	}, nil
+}
+
+func (s *inviteLinkService) RevokeInviteLink(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, linkID uuid.UUID) error {
+	if err := s.authorize(conversationID, userID); err != nil {
+		return err
+	}
+
+	if err := s.inviteLinks.Revoke(ctx, conversationID, linkID); err != nil {
+		return fmt.Errorf("revoke invite link error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *inviteLinkService) GetInviteLinks(conversationID uuid.UUID, userID uuid.UUID) ([]readModel.InviteLinkDTO, error) {
+	if err := s.authorize(conversationID, userID); err != nil {
+		return nil, err
+	}
+
+	links, err := s.queries.GetActiveInviteLinks(conversationID)
+	if err != nil {
+		return nil, fmt.Errorf("get invite links error: %w", err)
+	}
+
+	for i := range links {
+		links[i].Token = s.tokens.Sign(links[i].ID)
+	}
+
+	return links, nil
+}
+
+func (s *inviteLinkService) authorize(conversationID uuid.UUID, userID uuid.UUID) error {
+	participant, err := getParticipant(s.queries, conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("get participant error: %w", err)
+	}
+
+	if err := participant.Authorize(domain.PermissionManageInviteLinks); err != nil {
+		return fmt.Errorf("authorize error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInviteLinkRepository struct {
+	mock.Mock
+}
+
+func (m *MockInviteLinkRepository) Store(ctx context.Context, link *domain.InviteLink) error {
+	args := m.Called(ctx, link)
+	return args.Error(0)
+}
+
+func (m *MockInviteLinkRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.InviteLink, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InviteLink), args.Error(1)
+}
+
+func (m *MockInviteLinkRepository) Revoke(ctx context.Context, conversationID uuid.UUID, id uuid.UUID) error {
+	args := m.Called(ctx, conversationID, id)
+	return args.Error(0)
+}
+
+func (m *MockInviteLinkRepository) Redeem(ctx context.Context, id uuid.UUID, participant *domain.Participant) error {
+	args := m.Called(ctx, id, participant)
+	return args.Error(0)
+}
+
+func TestInviteLinkService_CreateInviteLink(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+	tokens := NewInviteTokens("test-invite-secret")
+
+	mockInviteLinks := new(MockInviteLinkRepository)
+	mockQueries := new(MockQueriesRepository)
+
+	service := NewInviteLinkService(mockInviteLinks, mockQueries, tokens)
+
+	t.Run("admin creates link", func(t *testing.T) {
+		maxUses := 10
+		expiresAt := time.Now().Add(24 * time.Hour)
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("admin", nil)
+		mockQueries.On("GetUserByID", userID).Return(readModel.UserDTO{ID: userID, Name: "admin"}, nil)
+		mockInviteLinks.On("Store", mock.Anything, mock.MatchedBy(func(link *domain.InviteLink) bool {
+			return link.ConversationID == conversationID && link.CreatorID == userID && *link.MaxUses == maxUses
+		})).Return(nil)
+
+		link, err := service.CreateInviteLink(ctx, conversationID, userID, &maxUses, &expiresAt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, userID, link.Creator.ID)
+		assert.Equal(t, 0, link.UseCount)
+		parsedID, err := tokens.Parse(link.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, link.ID, parsedID)
+		mockInviteLinks.AssertExpectations(t)
+	})
+
+	t.Run("member cannot create link", func(t *testing.T) {
+		mockQueries.ExpectedCalls = nil
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("member", nil)
+
+		_, err := service.CreateInviteLink(ctx, conversationID, userID, nil, nil)
+
+		assert.ErrorIs(t, err, domain.ErrorInsufficientPermissions)
+	})
+
+	t.Run("rejects expiry in the past", func(t *testing.T) {
+		mockQueries.ExpectedCalls = nil
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("owner", nil)
+		expiresAt := time.Now().Add(-time.Hour)
+
+		_, err := service.CreateInviteLink(ctx, conversationID, userID, nil, &expiresAt)
+
+		assert.ErrorIs(t, err, domain.ErrorInvalidInviteLinkExpiry)
+	})
+}
+
+func TestInviteLinkService_RevokeInviteLink(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+	linkID := uuid.New()
+
+	mockInviteLinks := new(MockInviteLinkRepository)
+	mockQueries := new(MockQueriesRepository)
+
+	service := NewInviteLinkService(mockInviteLinks, mockQueries, NewInviteTokens("test-invite-secret"))
+
+	mockQueries.On("GetMemberRole", conversationID, userID).Return("owner", nil)
+	mockInviteLinks.On("Revoke", mock.Anything, conversationID, linkID).Return(domain.ErrorInviteLinkNotFound)
+
+	err := service.RevokeInviteLink(ctx, conversationID, userID, linkID)
+
+	assert.ErrorIs(t, err, domain.ErrorInviteLinkNotFound)
+	mockInviteLinks.AssertExpectations(t)
+}
+
+func TestInviteLinkService_GetInviteLinks(t *testing.T) {
+	conversationID := uuid.New()
+	userID := uuid.New()
+	linkID := uuid.New()
+	tokens := NewInviteTokens("test-invite-secret")
+
+	mockQueries := new(MockQueriesRepository)
+
+	service := NewInviteLinkService(new(MockInviteLinkRepository), mockQueries, tokens)
+
+	mockQueries.On("GetMemberRole", conversationID, userID).Return("admin", nil)
+	mockQueries.On("GetActiveInviteLinks", conversationID).Return([]readModel.InviteLinkDTO{{ID: linkID, UseCount: 3}}, nil)
+
+	links, err := service.GetInviteLinks(conversationID, userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, links, 1)
+	assert.Equal(t, 3, links[0].UseCount)
+	assert.Equal(t, tokens.Sign(linkID), links[0].Token)
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+
+	"GitHub/go-chat/backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type InviteTokens interface {
+	Sign(linkID uuid.UUID) string
+	Parse(token string) (uuid.UUID, error)
+}
+
+type inviteTokens struct {
+	secret []byte
+}
+
+func NewInviteTokens(secret string) *inviteTokens {
+	return &inviteTokens{
+		secret: []byte(secret),
+	}
+}
+
+func (t *inviteTokens) Sign(linkID uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString(linkID[:]) + "." + base64.RawURLEncoding.EncodeToString(t.signature(linkID))
+}
+
+func (t *inviteTokens) Parse(token string) (uuid.UUID, error) {
+	encodedID, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, domain.ErrorInvalidInviteToken
+	}
+
+	id, err := base64.RawURLEncoding.DecodeString(encodedID)
+	if err != nil {
+		return uuid.Nil, domain.ErrorInvalidInviteToken
+	}
+
+	linkID, err := uuid.FromBytes(id)
+	if err != nil {
+		return uuid.Nil, domain.ErrorInvalidInviteToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, t.signature(linkID)) {
+		return uuid.Nil, domain.ErrorInvalidInviteToken
+	}
+
+	return linkID, nil
+}
+
+func (t *inviteTokens) signature(linkID uuid.UUID) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write(linkID[:])
+	return mac.Sum(nil)
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"testing"
+
+	"GitHub/go-chat/backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInviteTokens_SignAndParse(t *testing.T) {
+	tokens := NewInviteTokens("test-invite-secret")
+	linkID := uuid.New()
+
+	parsed, err := tokens.Parse(tokens.Sign(linkID))
+
+	assert.NoError(t, err)
+	assert.Equal(t, linkID, parsed)
+}
+
+func TestInviteTokens_Parse_Invalid(t *testing.T) {
+	tokens := NewInviteTokens("test-invite-secret")
+	token := tokens.Sign(uuid.New())
+
+	for _, invalid := range []string{
+		"",
+		"not-a-token",
+		token[:len(token)-2],
+		NewInviteTokens("another-secret").Sign(uuid.New()),
+		tokens.Sign(uuid.New())[:22] + token[22:],
+	} {
+		_, err := tokens.Parse(invalid)
+		assert.ErrorIs(t, err, domain.ErrorInvalidInviteToken, invalid)
+	}
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"GitHub/go-chat/backend/internal/domain"
 	"GitHub/go-chat/backend/internal/readModel"
@@ -13,43 +14,76 @@ import (
 )
 
 type MembershipService interface {
-	Join(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error
-	Leave(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error
+	Join(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, inviteToken string) error
+	Leave(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, promoteSuccessor bool) error
 	Invite(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, inviteeID uuid.UUID) error
 	Kick(ctx context.Context, conversationID uuid.UUID, kickerID uuid.UUID, targetID uuid.UUID) error
+	Promote(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, targetID uuid.UUID, role string) error
+	Demote(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, targetID uuid.UUID, role string) error
+	MarkAsRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, messageID uuid.UUID) error
+	RequestToJoin(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error
+	GetJoinRequests(conversationID uuid.UUID, userID uuid.UUID) ([]readModel.JoinRequestDTO, error)
+	ApproveJoinRequest(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, requestID uuid.UUID) error
+	RejectJoinRequest(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, requestID uuid.UUID) error
+	GetNotificationSettings(conversationID uuid.UUID, userID uuid.UUID) (readModel.NotificationSettingsDTO, error)
+	UpdateNotificationSettings(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, level string, mutedUntil *time.Time) (readModel.NotificationSettingsDTO, error)
 }
 
 type membershipService struct {
-	participants  repository.ParticipantRepository
-	queries       readModel.QueriesRepository
-	messages      MessageService
-	notifications NotificationService
-	cache         CacheService
+	participants       repository.ParticipantRepository
+	inviteLinks        repository.InviteLinkRepository
+	joinRequests       repository.JoinRequestRepository
+	queries            readModel.QueriesRepository
+	messages           MessageService
+	groupConversations GroupConversationService
+	inviteTokens       InviteTokens
+	notifications      NotificationService
+	cache              CacheService
 }
 
 func NewMembershipService(
 	participants repository.ParticipantRepository,
+	inviteLinks repository.InviteLinkRepository,
+	joinRequests repository.JoinRequestRepository,
 	queries readModel.QueriesRepository,
 	messages MessageService,
+	groupConversations GroupConversationService,
+	inviteTokens InviteTokens,
 	notifications NotificationService,
 	cache CacheService,
 ) MembershipService {
 	return &membershipService{
-		participants:  participants,
-		queries:       queries,
-		messages:      messages,
-		notifications: notifications,
-		cache:         cache,
+		participants:       participants,
+		inviteLinks:        inviteLinks,
+		joinRequests:       joinRequests,
+		queries:            queries,
+		messages:           messages,
+		groupConversations: groupConversations,
+		inviteTokens:       inviteTokens,
+		notifications:      notifications,
+		cache:              cache,
 	}
 }
 
-func (s *membershipService) Join(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error {
+func (s *membershipService) Join(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, inviteToken string) error {
 	participant := domain.NewParticipant(uuid.New(), conversationID, userID)
 
-	if err := s.participants.Store(ctx, participant); err != nil {
-		return fmt.Errorf("store participant error: %w", err)
+	if inviteToken == "" {
+		if err := s.authorizeOpenJoin(conversationID); err != nil {
+			return fmt.Errorf("authorize join error: %w", err)
+		}
+
+		if err := s.participants.Store(ctx, participant); err != nil {
+			return fmt.Errorf("store participant error: %w", err)
+		}
+	} else if err := s.redeemInvite(ctx, inviteToken, participant); err != nil {
+		return fmt.Errorf("redeem invite error: %w", err)
 	}
 
+	return s.announceJoin(ctx, conversationID, userID)
+}
+
+func (s *membershipService) announceJoin(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error {
 	joinedMessage, err := domain.NewMessage(conversationID, userID, domain.MessageTypeSystem, "joined the conversation")
 	if err != nil {
 		return fmt.Errorf("create joined message error: %w", err)
@@ -70,22 +104,213 @@ func (s *membershipService) Join(ctx context.Context, conversationID uuid.UUID,
 	return nil
 }
 
-func (s *membershipService) Leave(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error {
-	isOwner, err := s.queries.IsMemberOwner(conversationID, userID)
+func (s *membershipService) authorizeOpenJoin(conversationID uuid.UUID) error {
+	visibility, err := s.getVisibility(conversationID)
 	if err != nil {
-		return fmt.Errorf("is owner error: %w", err)
+		return err
 	}
-	if isOwner {
-		return fmt.Errorf("owner cannot leave: %w", domain.ErrorOwnerCannotLeave)
+
+	return visibility.AuthorizeJoin()
+}
+
+func (s *membershipService) getVisibility(conversationID uuid.UUID) (domain.ConversationVisibility, error) {
+	visibility, err := s.queries.GetGroupVisibility(conversationID)
+	if err != nil {
+		return domain.ConversationVisibility{}, fmt.Errorf("get visibility error: %w", err)
 	}
 
-	rowsAffected, err := s.queries.LeaveConversationAtomic(conversationID, userID)
+	conversationVisibility, err := domain.NewConversationVisibility(visibility)
 	if err != nil {
-		return fmt.Errorf("leave conversation error: %w", err)
+		return domain.ConversationVisibility{}, fmt.Errorf("conversation visibility error: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("user is not in conversation: %w", domain.ErrorUserNotInConversation)
+	return conversationVisibility, nil
+}
+
+func (s *membershipService) redeemInvite(ctx context.Context, inviteToken string, participant *domain.Participant) error {
+	linkID, err := s.inviteTokens.Parse(inviteToken)
+	if err != nil {
+		return fmt.Errorf("parse invite token error: %w", err)
+	}
+
+	link, err := s.inviteLinks.GetByID(ctx, linkID)
+	if err != nil {
+		return fmt.Errorf("get invite link error: %w", err)
+	}
+
+	if err := link.Validate(participant.ConversationID, time.Now()); err != nil {
+		return fmt.Errorf("validate invite link error: %w", err)
+	}
+
+	if err := s.inviteLinks.Redeem(ctx, link.ID, participant); err != nil {
+		return fmt.Errorf("store participant error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *membershipService) RequestToJoin(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error {
+	isMember, err := s.queries.IsMember(conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("is member error: %w", err)
+	}
+	if isMember {
+		return domain.ErrorAlreadyInConversation
+	}
+
+	visibility, err := s.getVisibility(conversationID)
+	if err != nil {
+		return err
+	}
+
+	request, err := domain.NewJoinRequest(conversationID, userID, visibility)
+	if err != nil {
+		return fmt.Errorf("new join request error: %w", err)
+	}
+
+	if err := s.joinRequests.Store(ctx, request); err != nil {
+		return fmt.Errorf("store join request error: %w", err)
+	}
+
+	requester, err := s.queries.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("get requester error: %w", err)
+	}
+
+	reviewerIDs, err := s.queries.GetMemberIDsByRoles(conversationID, domain.PermissionManageJoinRequests.Roles())
+	if err != nil {
+		return fmt.Errorf("get reviewers error: %w", err)
+	}
+
+	notification := ws.OutgoingNotification{
+		Type:   "join_request_created",
+		UserID: userID,
+		Payload: readModel.JoinRequestDTO{
+			ID:             request.ID,
+			ConversationID: conversationID,
+			User:           requester,
+			CreatedAt:      request.CreatedAt,
+		},
+	}
+
+	for _, reviewerID := range reviewerIDs {
+		if err := s.notifications.NotifyUser(ctx, reviewerID, notification); err != nil {
+			return fmt.Errorf("notify error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *membershipService) GetJoinRequests(conversationID uuid.UUID, userID uuid.UUID) ([]readModel.JoinRequestDTO, error) {
+	participant, err := getParticipant(s.queries, conversationID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get participant error: %w", err)
+	}
+
+	if err := participant.Authorize(domain.PermissionManageJoinRequests); err != nil {
+		return nil, fmt.Errorf("authorize error: %w", err)
+	}
+
+	requests, err := s.queries.GetPendingJoinRequests(conversationID)
+	if err != nil {
+		return nil, fmt.Errorf("get join requests error: %w", err)
+	}
+
+	return requests, nil
+}
+
+func (s *membershipService) ApproveJoinRequest(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, requestID uuid.UUID) error {
+	approver, err := getParticipant(s.queries, conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("get participant error: %w", err)
+	}
+
+	request, err := s.joinRequests.GetByID(ctx, requestID)
+	if err != nil {
+		return fmt.Errorf("get join request error: %w", err)
+	}
+
+	participant, err := request.Approve(approver)
+	if err != nil {
+		return fmt.Errorf("approve join request error: %w", err)
+	}
+
+	if err := s.joinRequests.Approve(ctx, request, participant); err != nil {
+		return fmt.Errorf("store approval error: %w", err)
+	}
+
+	if err := s.announceJoin(ctx, conversationID, request.UserID); err != nil {
+		return err
+	}
+
+	return s.notifyJoinRequestDecision(ctx, request)
+}
+
+func (s *membershipService) RejectJoinRequest(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, requestID uuid.UUID) error {
+	approver, err := getParticipant(s.queries, conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("get participant error: %w", err)
+	}
+
+	request, err := s.joinRequests.GetByID(ctx, requestID)
+	if err != nil {
+		return fmt.Errorf("get join request error: %w", err)
+	}
+
+	if err := request.Reject(approver); err != nil {
+		return fmt.Errorf("reject join request error: %w", err)
+	}
+
+	if err := s.joinRequests.Reject(ctx, request); err != nil {
+		return fmt.Errorf("store rejection error: %w", err)
+	}
+
+	return s.notifyJoinRequestDecision(ctx, request)
+}
+
+func (s *membershipService) notifyJoinRequestDecision(ctx context.Context, request *domain.JoinRequest) error {
+	if err := s.notifications.NotifyUser(ctx, request.UserID, ws.OutgoingNotification{
+		Type:   "join_request_" + request.Status.String(),
+		UserID: *request.DecidedBy,
+		Payload: map[string]interface{}{
+			"conversation_id": request.ConversationID,
+			"request_id":      request.ID,
+		},
+	}); err != nil {
+		return fmt.Errorf("notify error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *membershipService) Leave(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, promoteSuccessor bool) error {
+	isOwner, err := s.queries.IsMemberOwner(conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("is owner error: %w", err)
+	}
+	if isOwner {
+		if !promoteSuccessor {
+			return fmt.Errorf("owner cannot leave: %w", domain.ErrorOwnerCannotLeave)
+		}
+
+		successorID, err := s.queries.GetLongestStandingMember(conversationID, userID)
+		if err != nil {
+			return fmt.Errorf("get successor error: %w", err)
+		}
+
+		if err := s.groupConversations.TransferOwnershipAndLeave(ctx, conversationID, userID, successorID); err != nil {
+			return fmt.Errorf("transfer ownership error: %w", err)
+		}
+	} else {
+		rowsAffected, err := s.queries.LeaveConversationAtomic(conversationID, userID)
+		if err != nil {
+			return fmt.Errorf("leave conversation error: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("user is not in conversation: %w", domain.ErrorUserNotInConversation)
+		}
 	}
 
 	leftMessage, err := domain.NewMessage(conversationID, userID, domain.MessageTypeSystem, "left the conversation")
@@ -109,12 +334,21 @@ func (s *membershipService) Leave(ctx context.Context, conversationID uuid.UUID,
 }
 
 func (s *membershipService) Invite(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, inviteeID uuid.UUID) error {
-	isMember, err := s.queries.IsMember(conversationID, userID)
+	inviter, err := getParticipant(s.queries, conversationID, userID)
 	if err != nil {
-		return fmt.Errorf("is member error: %w", err)
+		return fmt.Errorf("get participant error: %w", err)
 	}
-	if !isMember {
-		return fmt.Errorf("user is not in conversation: %w", domain.ErrorUserNotInConversation)
+
+	if err := inviter.Authorize(domain.PermissionInvite); err != nil {
+		return fmt.Errorf("authorize error: %w", err)
+	}
+
+	blocked, err := s.queries.HasBlocked(inviteeID, userID)
+	if err != nil {
+		return fmt.Errorf("has blocked error: %w", err)
+	}
+	if blocked {
+		return domain.ErrorUserBlocked
 	}
 
 	participantID := uuid.New()
@@ -149,20 +383,22 @@ func (s *membershipService) Invite(ctx context.Context, conversationID uuid.UUID
 }
 
 func (s *membershipService) Kick(ctx context.Context, conversationID uuid.UUID, kickerID uuid.UUID, targetID uuid.UUID) error {
-	isOwner, err := s.queries.IsMemberOwner(conversationID, kickerID)
-	if err != nil {
-		return fmt.Errorf("is owner error: %w", err)
+	if kickerID == targetID {
+		return domain.ErrorCannotKickOneself
 	}
-	if !isOwner {
-		return fmt.Errorf("user is not owner: %w", domain.ErrorUserNotOwner)
+
+	kicker, err := getParticipant(s.queries, conversationID, kickerID)
+	if err != nil {
+		return fmt.Errorf("get participant error: %w", err)
 	}
 
-	isMember, err := s.queries.IsMember(conversationID, targetID)
+	target, err := getParticipant(s.queries, conversationID, targetID)
 	if err != nil {
-		return fmt.Errorf("is member error: %w", err)
+		return fmt.Errorf("get target error: %w", err)
 	}
-	if !isMember {
-		return fmt.Errorf("target is not in conversation: %w", domain.ErrorUserNotInConversation)
+
+	if err := kicker.Moderate(target, domain.PermissionKick); err != nil {
+		return fmt.Errorf("authorize error: %w", err)
 	}
 
 	rowsAffected, err := s.queries.LeaveConversationAtomic(conversationID, targetID)
@@ -192,7 +428,7 @@ func (s *membershipService) Kick(ctx context.Context, conversationID uuid.UUID,
 		return fmt.Errorf("get conversation error: %w", err)
 	}
 
-	if err := s.notifications.Broadcast(ctx, conversationDTO.ID, ws.OutgoingNotification{Type: "conversation_updated", Payload: conversationDTO}); err != nil {
+	if err := s.notifications.Broadcast(ctx, conversationDTO.ID, conversationUpdated(conversationDTO)); err != nil {
 		return fmt.Errorf("notify error: %w", err)
 	}
 
@@ -202,3 +438,155 @@ func (s *membershipService) Kick(ctx context.Context, conversationID uuid.UUID,
 
 	return nil
 }
+
+func (s *membershipService) Promote(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, targetID uuid.UUID, role string) error {
+	return s.changeRole(ctx, conversationID, userID, targetID, role, "promoted", (*domain.Participant).Promote)
+}
+
+func (s *membershipService) Demote(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, targetID uuid.UUID, role string) error {
+	return s.changeRole(ctx, conversationID, userID, targetID, role, "demoted", (*domain.Participant).Demote)
+}
+
+func (s *membershipService) changeRole(
+	ctx context.Context,
+	conversationID uuid.UUID,
+	userID uuid.UUID,
+	targetID uuid.UUID,
+	role string,
+	action string,
+	change func(actor *domain.Participant, target *domain.Participant, role domain.ParticipantRole) error,
+) error {
+	newRole, err := domain.NewParticipantRole(role)
+	if err != nil {
+		return fmt.Errorf("participant role error: %w", err)
+	}
+
+	actor, err := getParticipant(s.queries, conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("get participant error: %w", err)
+	}
+
+	target, err := getParticipant(s.queries, conversationID, targetID)
+	if err != nil {
+		return fmt.Errorf("get target error: %w", err)
+	}
+
+	if err := change(actor, target, newRole); err != nil {
+		return fmt.Errorf("change role error: %w", err)
+	}
+
+	if err := s.participants.UpdateRole(ctx, target); err != nil {
+		return fmt.Errorf("update role error: %w", err)
+	}
+
+	roleMessage, err := domain.NewMessage(conversationID, targetID, domain.MessageTypeSystem, fmt.Sprintf("was %s to %s", action, newRole))
+	if err != nil {
+		return fmt.Errorf("create role message error: %w", err)
+	}
+
+	if _, err := s.messages.Send(ctx, roleMessage); err != nil {
+		return fmt.Errorf("store role message error: %w", err)
+	}
+
+	if err := s.notifications.Broadcast(ctx, conversationID, ws.OutgoingNotification{
+		Type:   "participant_role_changed",
+		UserID: userID,
+		Payload: map[string]interface{}{
+			"conversation_id": conversationID,
+			"user_id":         targetID,
+			"role":            newRole.String(),
+		},
+	}); err != nil {
+		return fmt.Errorf("notify error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *membershipService) MarkAsRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, messageID uuid.UUID) error {
+	isMember, err := s.queries.IsMember(conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("is member error: %w", err)
+	}
+	if !isMember {
+		return fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation)
+	}
+
+	advanced, err := s.participants.MarkRead(ctx, conversationID, userID, messageID)
+	if err != nil {
+		return fmt.Errorf("mark read error: %w", err)
+	}
+	if !advanced {
+		return nil
+	}
+
+	if err := s.notifications.Broadcast(ctx, conversationID, ws.OutgoingNotification{
+		Type:   "read_receipt",
+		UserID: userID,
+		Payload: map[string]interface{}{
+			"conversation_id": conversationID,
+			"user_id":         userID,
+			"message_id":      messageID,
+		},
+	}); err != nil {
+		return fmt.Errorf("notify error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *membershipService) GetNotificationSettings(conversationID uuid.UUID, userID uuid.UUID) (readModel.NotificationSettingsDTO, error) {
+	if _, err := getParticipant(s.queries, conversationID, userID); err != nil {
+		return readModel.NotificationSettingsDTO{}, fmt.Errorf("get participant error: %w", err)
+	}
+
+	participant, err := s.participants.GetByConversationIDAndUserID(context.Background(), conversationID, userID)
+	if err != nil {
+		return readModel.NotificationSettingsDTO{}, fmt.Errorf("get participant error: %w", err)
+	}
+
+	return readModel.NotificationSettingsDTO{
+		ConversationID:    conversationID,
+		NotificationLevel: participant.NotificationPreferences.Level.String(),
+		MutedUntil:        participant.NotificationPreferences.MutedUntil,
+	}, nil
+}
+
+func (s *membershipService) UpdateNotificationSettings(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, level string, mutedUntil *time.Time) (readModel.NotificationSettingsDTO, error) {
+	preferences, err := domain.NewNotificationPreferences(level, mutedUntil)
+	if err != nil {
+		return readModel.NotificationSettingsDTO{}, fmt.Errorf("notification preferences error: %w", err)
+	}
+
+	participant, err := getParticipant(s.queries, conversationID, userID)
+	if err != nil {
+		return readModel.NotificationSettingsDTO{}, fmt.Errorf("get participant error: %w", err)
+	}
+
+	participant.NotificationPreferences = preferences
+
+	if err := s.participants.UpdateNotificationPreferences(ctx, participant); err != nil {
+		return readModel.NotificationSettingsDTO{}, fmt.Errorf("update notification preferences error: %w", err)
+	}
+
+	if err := s.notifications.InvalidateMembership(ctx, userID); err != nil {
+		return readModel.NotificationSettingsDTO{}, fmt.Errorf("invalidate membership error: %w", err)
+	}
+
+	settings := readModel.NotificationSettingsDTO{
+		ConversationID:    conversationID,
+		NotificationLevel: preferences.Level.String(),
+		MutedUntil:        preferences.MutedUntil,
+	}
+
+	if err := s.notifications.NotifyUser(ctx, userID, ws.OutgoingNotification{
+		Type:           "notification_settings_updated",
+		UserID:         userID,
+		ConversationID: &conversationID,
+		Payload:        settings,
+	}); err != nil {
+		return readModel.NotificationSettingsDTO{}, fmt.Errorf("notify error: %w", err)
+	}
+
+	return settings, nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"testing"
+	"time"
 
 	"GitHub/go-chat/backend/internal/domain"
 	"GitHub/go-chat/backend/internal/readModel"
@@ -46,17 +47,60 @@ func (m *MockParticipantRepository) GetConversationIDsByUserID(ctx context.Conte
 	return args.Get(0).([]uuid.UUID), args.Error(1)
 }
 
+func (m *MockParticipantRepository) MarkRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, messageID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, conversationID, userID, messageID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockParticipantRepository) UpdateRole(ctx context.Context, participant *domain.Participant) error {
+	args := m.Called(ctx, participant)
+	return args.Error(0)
+}
+
+func (m *MockParticipantRepository) UpdateNotificationPreferences(ctx context.Context, participant *domain.Participant) error {
+	args := m.Called(ctx, participant)
+	return args.Error(0)
+}
+
+func (m *MockParticipantRepository) GetNotificationPreferencesByUserID(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]domain.NotificationPreferences, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.NotificationPreferences), args.Error(1)
+}
+
 type MockQueriesRepositoryForMembership struct {
 	mock.Mock
 }
 
+func (m *MockQueriesRepositoryForMembership) GetPublicGroups(userID uuid.UUID, search string, paginationInfo readModel.PaginationInfo) ([]readModel.PublicGroupDTO, error) {
+	args := m.Called(userID, search, paginationInfo)
+	return args.Get(0).([]readModel.PublicGroupDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) GetPendingJoinRequests(conversationID uuid.UUID) ([]readModel.JoinRequestDTO, error) {
+	args := m.Called(conversationID)
+	return args.Get(0).([]readModel.JoinRequestDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) GetPinnedMessages(conversationID uuid.UUID, userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.PinnedMessageDTO, error) {
+	args := m.Called(conversationID, userID, paginationInfo)
+	return args.Get(0).([]readModel.PinnedMessageDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) GetBlockedUsers(userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error) {
+	args := m.Called(userID, paginationInfo)
+	return args.Get(0).([]readModel.ContactDTO), args.Error(1)
+}
+
 func (m *MockQueriesRepositoryForMembership) GetContacts(userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error) {
 	args := m.Called(userID, paginationInfo)
 	return args.Get(0).([]readModel.ContactDTO), args.Error(1)
 }
 
-func (m *MockQueriesRepositoryForMembership) GetPotentialInvitees(conversationID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error) {
-	args := m.Called(conversationID, paginationInfo)
+func (m *MockQueriesRepositoryForMembership) GetPotentialInvitees(conversationID uuid.UUID, userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ContactDTO, error) {
+	args := m.Called(conversationID, userID, paginationInfo)
 	return args.Get(0).([]readModel.ContactDTO), args.Error(1)
 }
 
@@ -90,8 +134,8 @@ func (m *MockQueriesRepositoryForMembership) RenameConversationAndReturn(convers
 	return args.Error(0)
 }
 
-func (m *MockQueriesRepositoryForMembership) GetConversationMessages(conversationID uuid.UUID, cursor *readModel.MessageCursor, limit int) (readModel.MessagePageDTO, error) {
-	args := m.Called(conversationID, cursor, limit)
+func (m *MockQueriesRepositoryForMembership) GetConversationMessages(conversationID uuid.UUID, userID uuid.UUID, cursor *readModel.MessageCursor, limit int) (readModel.MessagePageDTO, error) {
+	args := m.Called(conversationID, userID, cursor, limit)
 	return args.Get(0).(readModel.MessagePageDTO), args.Error(1)
 }
 
@@ -100,6 +144,11 @@ func (m *MockQueriesRepositoryForMembership) GetNotificationMessage(messageID uu
 	return args.Get(0).(readModel.MessageDTO), args.Error(1)
 }
 
+func (m *MockQueriesRepositoryForMembership) GetMessageRevisions(messageID uuid.UUID, userID uuid.UUID) ([]readModel.MessageRevisionDTO, error) {
+	args := m.Called(messageID, userID)
+	return args.Get(0).([]readModel.MessageRevisionDTO), args.Error(1)
+}
+
 func (m *MockQueriesRepositoryForMembership) StoreMessageAndReturn(id uuid.UUID, conversationID uuid.UUID, userID uuid.UUID, content string, messageType int32) (readModel.MessageDTO, error) {
 	args := m.Called(id, conversationID, userID, content, messageType)
 	return args.Get(0).(readModel.MessageDTO), args.Error(1)
@@ -115,6 +164,54 @@ func (m *MockQueriesRepositoryForMembership) IsMemberOwner(conversationID uuid.U
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockQueriesRepositoryForMembership) GetActiveInviteLinks(conversationID uuid.UUID) ([]readModel.InviteLinkDTO, error) {
+	args := m.Called(conversationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]readModel.InviteLinkDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) GetMemberRole(conversationID uuid.UUID, userID uuid.UUID) (string, error) {
+	args := m.Called(conversationID, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) GetGroupVisibility(conversationID uuid.UUID) (string, error) {
+	args := m.Called(conversationID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) GetMemberIDsByRoles(conversationID uuid.UUID, roles []string) ([]uuid.UUID, error) {
+	args := m.Called(conversationID, roles)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) GetParticipantIDsByNames(conversationID uuid.UUID, names []string) ([]uuid.UUID, error) {
+	args := m.Called(conversationID, names)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) HasBlocked(blockerID uuid.UUID, blockedID uuid.UUID) (bool, error) {
+	args := m.Called(blockerID, blockedID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) IsBlockedInDirectConversation(conversationID uuid.UUID, userID uuid.UUID) (bool, error) {
+	args := m.Called(conversationID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) IsMessageHidden(messageID uuid.UUID, userID uuid.UUID) (bool, error) {
+	args := m.Called(messageID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) GetLongestStandingMember(conversationID uuid.UUID, excludeUserID uuid.UUID) (uuid.UUID, error) {
+	args := m.Called(conversationID, excludeUserID)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
 func (m *MockQueriesRepositoryForMembership) InviteToConversationAtomic(conversationID uuid.UUID, inviteeID uuid.UUID, participantID uuid.UUID) (uuid.UUID, error) {
 	args := m.Called(conversationID, inviteeID, participantID)
 	return args.Get(0).(uuid.UUID), args.Error(1)
@@ -125,6 +222,39 @@ func (m *MockQueriesRepositoryForMembership) LeaveConversationAtomic(conversatio
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockQueriesRepositoryForMembership) GetThreadMessages(threadID uuid.UUID, userID uuid.UUID, cursor *readModel.MessageCursor, limit int) (readModel.MessagePageDTO, error) {
+	args := m.Called(threadID, userID, cursor, limit)
+	return args.Get(0).(readModel.MessagePageDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) GetMentions(userID uuid.UUID, cursor *readModel.MessageCursor, limit int) (readModel.MessagePageDTO, error) {
+	args := m.Called(userID, cursor, limit)
+	return args.Get(0).(readModel.MessagePageDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) GetScheduledMessages(userID uuid.UUID, conversationID *uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ScheduledMessageDTO, error) {
+	args := m.Called(userID, conversationID, paginationInfo)
+	return args.Get(0).([]readModel.ScheduledMessageDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) GetMessageReactions(messageID uuid.UUID) ([]readModel.ReactionDTO, error) {
+	args := m.Called(messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]readModel.ReactionDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) GetPoll(messageID uuid.UUID) (readModel.PollDTO, error) {
+	args := m.Called(messageID)
+	return args.Get(0).(readModel.PollDTO), args.Error(1)
+}
+
+func (m *MockQueriesRepositoryForMembership) SearchMessages(userID uuid.UUID, conversationID *uuid.UUID, query string, cursor *readModel.MessageSearchCursor, limit int) (readModel.MessageSearchPageDTO, error) {
+	args := m.Called(userID, conversationID, query, cursor, limit)
+	return args.Get(0).(readModel.MessageSearchPageDTO), args.Error(1)
+}
+
 type MockMessageRepositoryForMembership struct {
 	mock.Mock
 }
@@ -134,6 +264,37 @@ func (m *MockMessageRepositoryForMembership) Send(ctx context.Context, message *
 	return args.Get(0).(readModel.MessageDTO), args.Error(1)
 }
 
+func (m *MockMessageRepositoryForMembership) Edit(ctx context.Context, message *domain.Message) (readModel.MessageDTO, error) {
+	args := m.Called(ctx, message)
+	return args.Get(0).(readModel.MessageDTO), args.Error(1)
+}
+
+func (m *MockMessageRepositoryForMembership) GetByID(ctx context.Context, id uuid.UUID) (*domain.Message, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepositoryForMembership) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepositoryForMembership) Hide(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(ctx, messageID, userID)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepositoryForMembership) DeleteExpired(ctx context.Context, limit int) ([]domain.ExpiredMessage, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ExpiredMessage), args.Error(1)
+}
+
 type MockMessageServiceForMembership struct {
 	mock.Mock
 }
@@ -143,6 +304,65 @@ func (m *MockMessageServiceForMembership) Send(ctx context.Context, message *dom
 	return args.Get(0).(readModel.MessageDTO), args.Error(1)
 }
 
+func (m *MockMessageServiceForMembership) Edit(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, content string) (readModel.MessageDTO, error) {
+	args := m.Called(ctx, messageID, userID, content)
+	return args.Get(0).(readModel.MessageDTO), args.Error(1)
+}
+
+func (m *MockMessageServiceForMembership) Delete(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, scope domain.MessageDeletionScope) error {
+	args := m.Called(ctx, messageID, userID, scope)
+	return args.Error(0)
+}
+
+func (m *MockMessageServiceForMembership) Reply(ctx context.Context, parentID uuid.UUID, userID uuid.UUID, content string) (readModel.MessageDTO, error) {
+	args := m.Called(ctx, parentID, userID, content)
+	return args.Get(0).(readModel.MessageDTO), args.Error(1)
+}
+
+func (m *MockMessageServiceForMembership) Forward(ctx context.Context, messageID uuid.UUID, conversationID uuid.UUID, userID uuid.UUID) (readModel.MessageDTO, error) {
+	args := m.Called(ctx, messageID, conversationID, userID)
+	return args.Get(0).(readModel.MessageDTO), args.Error(1)
+}
+
+type MockGroupConversationServiceForMembership struct {
+	mock.Mock
+}
+
+func (m *MockGroupConversationServiceForMembership) CreateGroupConversation(ctx context.Context, conversationID uuid.UUID, name string, userID uuid.UUID, visibility string) error {
+	args := m.Called(ctx, conversationID, name, userID, visibility)
+	return args.Error(0)
+}
+
+func (m *MockGroupConversationServiceForMembership) DeleteGroupConversation(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(ctx, conversationID, userID)
+	return args.Error(0)
+}
+
+func (m *MockGroupConversationServiceForMembership) Rename(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, name string) error {
+	args := m.Called(ctx, conversationID, userID, name)
+	return args.Error(0)
+}
+
+func (m *MockGroupConversationServiceForMembership) SetVisibility(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, visibility string) error {
+	args := m.Called(ctx, conversationID, userID, visibility)
+	return args.Error(0)
+}
+
+func (m *MockGroupConversationServiceForMembership) SetMessageRetention(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, ttl time.Duration) error {
+	args := m.Called(ctx, conversationID, userID, ttl)
+	return args.Error(0)
+}
+
+func (m *MockGroupConversationServiceForMembership) TransferOwnership(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, newOwnerID uuid.UUID) error {
+	args := m.Called(ctx, conversationID, userID, newOwnerID)
+	return args.Error(0)
+}
+
+func (m *MockGroupConversationServiceForMembership) TransferOwnershipAndLeave(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, newOwnerID uuid.UUID) error {
+	args := m.Called(ctx, conversationID, userID, newOwnerID)
+	return args.Error(0)
+}
+
 type MockNotificationServiceForMembership struct {
 	mock.Mock
 }
@@ -152,8 +372,8 @@ func (m *MockNotificationServiceForMembership) Broadcast(ctx context.Context, co
 	return args.Error(0)
 }
 
-func (m *MockNotificationServiceForMembership) RegisterClient(ctx context.Context, conn *websocket.Conn, userID uuid.UUID) uuid.UUID {
-	args := m.Called(ctx, conn, userID)
+func (m *MockNotificationServiceForMembership) RegisterClient(ctx context.Context, conn *websocket.Conn, userID uuid.UUID, resume map[uuid.UUID]int64) uuid.UUID {
+	args := m.Called(ctx, conn, userID, resume)
 	return args.Get(0).(uuid.UUID)
 }
 
@@ -170,6 +390,11 @@ func (m *MockNotificationServiceForMembership) Shutdown() {
 	m.Called()
 }
 
+func (m *MockNotificationServiceForMembership) NotifyUser(ctx context.Context, userID uuid.UUID, notification ws.OutgoingNotification) error {
+	args := m.Called(ctx, userID, notification)
+	return args.Error(0)
+}
+
 type MockCacheServiceForMembership struct {
 	mock.Mock
 }
@@ -189,6 +414,33 @@ func (m *MockCacheServiceForMembership) InvalidateUserConversations(ctx context.
 	return args.Error(0)
 }
 
+type MockJoinRequestRepository struct {
+	mock.Mock
+}
+
+func (m *MockJoinRequestRepository) Store(ctx context.Context, request *domain.JoinRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
+func (m *MockJoinRequestRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.JoinRequest, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.JoinRequest), args.Error(1)
+}
+
+func (m *MockJoinRequestRepository) Approve(ctx context.Context, request *domain.JoinRequest, participant *domain.Participant) error {
+	args := m.Called(ctx, request, participant)
+	return args.Error(0)
+}
+
+func (m *MockJoinRequestRepository) Reject(ctx context.Context, request *domain.JoinRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
 func TestMembershipService_Join(t *testing.T) {
 	ctx := context.Background()
 	conversationID := uuid.New()
@@ -202,19 +454,24 @@ func TestMembershipService_Join(t *testing.T) {
 
 	service := NewMembershipService(
 		mockParticipants,
+		new(MockInviteLinkRepository),
+		new(MockJoinRequestRepository),
 		mockQueries,
 		mockMessages,
+		new(MockGroupConversationServiceForMembership),
+		NewInviteTokens("test-invite-secret"),
 		mockNotifications,
 		mockCache,
 	)
 
 	t.Run("successful join", func(t *testing.T) {
+		mockQueries.On("GetGroupVisibility", conversationID).Return("public", nil)
 		mockParticipants.On("Store", mock.Anything, mock.AnythingOfType("*domain.Participant")).Return(nil)
 		mockMessages.On("Send", mock.Anything, mock.AnythingOfType("*domain.Message")).Return(readModel.MessageDTO{}, nil)
 		mockCache.On("InvalidateParticipants", mock.Anything, conversationID).Return(nil)
 		mockNotifications.On("InvalidateMembership", mock.Anything, userID).Return(nil)
 
-		err := service.Join(ctx, conversationID, userID)
+		err := service.Join(ctx, conversationID, userID, "")
 
 		assert.NoError(t, err)
 		mockParticipants.AssertExpectations(t)
@@ -230,10 +487,102 @@ func TestMembershipService_Join(t *testing.T) {
 		mockCache.ExpectedCalls = nil
 		mockParticipants.On("Store", mock.Anything, mock.AnythingOfType("*domain.Participant")).Return(assert.AnError)
 
-		err := service.Join(ctx, conversationID, userID)
+		err := service.Join(ctx, conversationID, userID, "")
 
 		assert.Error(t, err)
 	})
+
+	t.Run("private group requires invite", func(t *testing.T) {
+		privateConversationID := uuid.New()
+		mockQueries.On("GetGroupVisibility", privateConversationID).Return("private", nil)
+
+		err := service.Join(ctx, privateConversationID, userID, "")
+
+		assert.ErrorIs(t, err, domain.ErrorConversationIsPrivate)
+		mockParticipants.AssertNotCalled(t, "Store", mock.Anything, mock.MatchedBy(func(p *domain.Participant) bool {
+			return p.ConversationID == privateConversationID
+		}))
+	})
+}
+
+func TestMembershipService_JoinWithInviteLink(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+	tokens := NewInviteTokens("test-invite-secret")
+
+	newService := func(inviteLinks *MockInviteLinkRepository, messages *MockMessageServiceForMembership, notifications *MockNotificationServiceForMembership, cache *MockCacheServiceForMembership) MembershipService {
+		return NewMembershipService(
+			new(MockParticipantRepository),
+			inviteLinks,
+			new(MockJoinRequestRepository),
+			new(MockQueriesRepositoryForMembership),
+			messages,
+			new(MockGroupConversationServiceForMembership),
+			tokens,
+			notifications,
+			cache,
+		)
+	}
+
+	t.Run("redeems a valid link", func(t *testing.T) {
+		link, err := domain.NewInviteLink(conversationID, uuid.New(), nil, nil, time.Now())
+		assert.NoError(t, err)
+
+		mockInviteLinks := new(MockInviteLinkRepository)
+		mockMessages := new(MockMessageServiceForMembership)
+		mockNotifications := new(MockNotificationServiceForMembership)
+		mockCache := new(MockCacheServiceForMembership)
+		mockInviteLinks.On("GetByID", mock.Anything, link.ID).Return(link, nil)
+		mockInviteLinks.On("Redeem", mock.Anything, link.ID, mock.MatchedBy(func(p *domain.Participant) bool {
+			return p.ConversationID == conversationID && p.UserID == userID
+		})).Return(nil)
+		mockMessages.On("Send", mock.Anything, mock.AnythingOfType("*domain.Message")).Return(readModel.MessageDTO{}, nil)
+		mockCache.On("InvalidateParticipants", mock.Anything, conversationID).Return(nil)
+		mockNotifications.On("InvalidateMembership", mock.Anything, userID).Return(nil)
+
+		err = newService(mockInviteLinks, mockMessages, mockNotifications, mockCache).Join(ctx, conversationID, userID, tokens.Sign(link.ID))
+
+		assert.NoError(t, err)
+		mockInviteLinks.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("rejects forged token", func(t *testing.T) {
+		mockInviteLinks := new(MockInviteLinkRepository)
+
+		err := newService(mockInviteLinks, nil, nil, nil).Join(ctx, conversationID, userID, NewInviteTokens("other-secret").Sign(uuid.New()))
+
+		assert.ErrorIs(t, err, domain.ErrorInvalidInviteToken)
+		mockInviteLinks.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects link for another conversation", func(t *testing.T) {
+		link, err := domain.NewInviteLink(uuid.New(), uuid.New(), nil, nil, time.Now())
+		assert.NoError(t, err)
+
+		mockInviteLinks := new(MockInviteLinkRepository)
+		mockInviteLinks.On("GetByID", mock.Anything, link.ID).Return(link, nil)
+
+		err = newService(mockInviteLinks, nil, nil, nil).Join(ctx, conversationID, userID, tokens.Sign(link.ID))
+
+		assert.ErrorIs(t, err, domain.ErrorInvalidInviteToken)
+		mockInviteLinks.AssertNotCalled(t, "Redeem", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects revoked link", func(t *testing.T) {
+		link, err := domain.NewInviteLink(conversationID, uuid.New(), nil, nil, time.Now())
+		assert.NoError(t, err)
+		revokedAt := time.Now()
+		link.RevokedAt = &revokedAt
+
+		mockInviteLinks := new(MockInviteLinkRepository)
+		mockInviteLinks.On("GetByID", mock.Anything, link.ID).Return(link, nil)
+
+		err = newService(mockInviteLinks, nil, nil, nil).Join(ctx, conversationID, userID, tokens.Sign(link.ID))
+
+		assert.ErrorIs(t, err, domain.ErrorInviteLinkRevoked)
+	})
 }
 
 func TestMembershipService_Leave(t *testing.T) {
@@ -244,13 +593,18 @@ func TestMembershipService_Leave(t *testing.T) {
 	mockParticipants := new(MockParticipantRepository)
 	mockQueries := new(MockQueriesRepositoryForMembership)
 	mockMessages := new(MockMessageServiceForMembership)
+	mockGroupConversations := new(MockGroupConversationServiceForMembership)
 	mockNotifications := new(MockNotificationServiceForMembership)
 	mockCache := new(MockCacheServiceForMembership)
 
 	service := NewMembershipService(
 		mockParticipants,
+		new(MockInviteLinkRepository),
+		new(MockJoinRequestRepository),
 		mockQueries,
 		mockMessages,
+		mockGroupConversations,
+		NewInviteTokens("test-invite-secret"),
 		mockNotifications,
 		mockCache,
 	)
@@ -262,7 +616,7 @@ func TestMembershipService_Leave(t *testing.T) {
 		mockCache.On("InvalidateParticipants", mock.Anything, conversationID).Return(nil)
 		mockNotifications.On("InvalidateMembership", mock.Anything, userID).Return(nil)
 
-		err := service.Leave(ctx, conversationID, userID)
+		err := service.Leave(ctx, conversationID, userID, false)
 
 		assert.NoError(t, err)
 		mockQueries.AssertExpectations(t)
@@ -275,9 +629,42 @@ func TestMembershipService_Leave(t *testing.T) {
 		mockQueries.ExpectedCalls = nil
 		mockQueries.On("IsMemberOwner", conversationID, userID).Return(true, nil)
 
-		err := service.Leave(ctx, conversationID, userID)
+		err := service.Leave(ctx, conversationID, userID, false)
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrorOwnerCannotLeave)
+		mockGroupConversations.AssertNotCalled(t, "TransferOwnershipAndLeave", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("owner leaves and promotes successor", func(t *testing.T) {
+		successorID := uuid.New()
+		mockQueries.ExpectedCalls = nil
+		mockQueries.Calls = nil
+		mockMessages.ExpectedCalls = nil
+		mockCache.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		mockQueries.On("IsMemberOwner", conversationID, userID).Return(true, nil)
+		mockQueries.On("GetLongestStandingMember", conversationID, userID).Return(successorID, nil)
+		mockGroupConversations.On("TransferOwnershipAndLeave", mock.Anything, conversationID, userID, successorID).Return(nil)
+		mockMessages.On("Send", mock.Anything, mock.AnythingOfType("*domain.Message")).Return(readModel.MessageDTO{}, nil)
+		mockCache.On("InvalidateParticipants", mock.Anything, conversationID).Return(nil)
+		mockNotifications.On("InvalidateMembership", mock.Anything, userID).Return(nil)
+
+		err := service.Leave(ctx, conversationID, userID, true)
+
+		assert.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+		mockQueries.AssertNotCalled(t, "LeaveConversationAtomic", conversationID, userID)
+		mockGroupConversations.AssertExpectations(t)
+	})
+
+	t.Run("owner cannot leave without successor", func(t *testing.T) {
+		mockQueries.ExpectedCalls = nil
+		mockQueries.On("IsMemberOwner", conversationID, userID).Return(true, nil)
+		mockQueries.On("GetLongestStandingMember", conversationID, userID).Return(uuid.Nil, domain.ErrorNoSuccessor)
+
+		err := service.Leave(ctx, conversationID, userID, true)
+
+		assert.ErrorIs(t, err, domain.ErrorNoSuccessor)
 	})
 
 	t.Run("user not in conversation", func(t *testing.T) {
@@ -285,7 +672,7 @@ func TestMembershipService_Leave(t *testing.T) {
 		mockQueries.On("IsMemberOwner", conversationID, userID).Return(false, nil)
 		mockQueries.On("LeaveConversationAtomic", conversationID, userID).Return(int64(0), nil)
 
-		err := service.Leave(ctx, conversationID, userID)
+		err := service.Leave(ctx, conversationID, userID, false)
 
 		assert.Error(t, err)
 	})
@@ -305,14 +692,19 @@ func TestMembershipService_Invite(t *testing.T) {
 
 	service := NewMembershipService(
 		mockParticipants,
+		new(MockInviteLinkRepository),
+		new(MockJoinRequestRepository),
 		mockQueries,
 		mockMessages,
+		new(MockGroupConversationServiceForMembership),
+		NewInviteTokens("test-invite-secret"),
 		mockNotifications,
 		mockCache,
 	)
 
 	t.Run("successful invite", func(t *testing.T) {
-		mockQueries.On("IsMember", conversationID, userID).Return(true, nil)
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("member", nil)
+		mockQueries.On("HasBlocked", inviteeID, userID).Return(false, nil)
 		mockQueries.On("InviteToConversationAtomic", conversationID, inviteeID, mock.Anything).Return(uuid.New(), nil)
 		mockMessages.On("Send", mock.Anything, mock.AnythingOfType("*domain.Message")).Return(readModel.MessageDTO{}, nil)
 		mockNotifications.On("InvalidateMembership", mock.Anything, inviteeID).Return(nil)
@@ -329,11 +721,21 @@ func TestMembershipService_Invite(t *testing.T) {
 
 	t.Run("user not in conversation", func(t *testing.T) {
 		mockQueries.ExpectedCalls = nil
-		mockQueries.On("IsMember", conversationID, userID).Return(false, nil)
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("", domain.ErrorUserNotInConversation)
 
 		err := service.Invite(ctx, conversationID, userID, inviteeID)
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrorUserNotInConversation)
+	})
+
+	t.Run("invitee blocked inviter", func(t *testing.T) {
+		mockQueries.ExpectedCalls = nil
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("member", nil)
+		mockQueries.On("HasBlocked", inviteeID, userID).Return(true, nil)
+
+		err := service.Invite(ctx, conversationID, userID, inviteeID)
+
+		assert.ErrorIs(t, err, domain.ErrorUserBlocked)
 	})
 }
 
@@ -351,15 +753,19 @@ func TestMembershipService_Kick(t *testing.T) {
 
 	service := NewMembershipService(
 		mockParticipants,
+		new(MockInviteLinkRepository),
+		new(MockJoinRequestRepository),
 		mockQueries,
 		mockMessages,
+		new(MockGroupConversationServiceForMembership),
+		NewInviteTokens("test-invite-secret"),
 		mockNotifications,
 		mockCache,
 	)
 
 	t.Run("successful kick", func(t *testing.T) {
-		mockQueries.On("IsMemberOwner", conversationID, kickerID).Return(true, nil)
-		mockQueries.On("IsMember", conversationID, targetID).Return(true, nil)
+		mockQueries.On("GetMemberRole", conversationID, kickerID).Return("moderator", nil)
+		mockQueries.On("GetMemberRole", conversationID, targetID).Return("member", nil)
 		mockQueries.On("LeaveConversationAtomic", conversationID, targetID).Return(int64(1), nil)
 		mockMessages.On("Send", mock.Anything, mock.AnythingOfType("*domain.Message")).Return(readModel.MessageDTO{}, nil)
 		mockCache.On("InvalidateParticipants", mock.Anything, conversationID).Return(nil)
@@ -376,22 +782,408 @@ func TestMembershipService_Kick(t *testing.T) {
 		mockCache.AssertExpectations(t)
 	})
 
-	t.Run("kicker not owner", func(t *testing.T) {
+	t.Run("kicker without permission", func(t *testing.T) {
 		mockQueries.ExpectedCalls = nil
-		mockQueries.On("IsMemberOwner", conversationID, kickerID).Return(false, nil)
+		mockQueries.On("GetMemberRole", conversationID, kickerID).Return("member", nil)
+		mockQueries.On("GetMemberRole", conversationID, targetID).Return("member", nil)
 
 		err := service.Kick(ctx, conversationID, kickerID, targetID)
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrorInsufficientPermissions)
+	})
+
+	t.Run("moderator cannot kick admin", func(t *testing.T) {
+		mockQueries.ExpectedCalls = nil
+		mockQueries.On("GetMemberRole", conversationID, kickerID).Return("moderator", nil)
+		mockQueries.On("GetMemberRole", conversationID, targetID).Return("admin", nil)
+
+		err := service.Kick(ctx, conversationID, kickerID, targetID)
+
+		assert.ErrorIs(t, err, domain.ErrorInsufficientPermissions)
 	})
 
 	t.Run("target not in conversation", func(t *testing.T) {
 		mockQueries.ExpectedCalls = nil
-		mockQueries.On("IsMemberOwner", conversationID, kickerID).Return(true, nil)
-		mockQueries.On("IsMember", conversationID, targetID).Return(false, nil)
+		mockQueries.On("GetMemberRole", conversationID, kickerID).Return("owner", nil)
+		mockQueries.On("GetMemberRole", conversationID, targetID).Return("", domain.ErrorUserNotInConversation)
 
 		err := service.Kick(ctx, conversationID, kickerID, targetID)
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrorUserNotInConversation)
+	})
+}
+
+func TestMembershipService_ChangeRole(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+	targetID := uuid.New()
+
+	newService := func() (MembershipService, *MockParticipantRepository, *MockQueriesRepositoryForMembership, *MockMessageServiceForMembership, *MockNotificationServiceForMembership) {
+		mockParticipants := new(MockParticipantRepository)
+		mockQueries := new(MockQueriesRepositoryForMembership)
+		mockMessages := new(MockMessageServiceForMembership)
+		mockNotifications := new(MockNotificationServiceForMembership)
+
+		service := NewMembershipService(
+			mockParticipants,
+			new(MockInviteLinkRepository),
+			new(MockJoinRequestRepository),
+			mockQueries,
+			mockMessages,
+			new(MockGroupConversationServiceForMembership),
+			NewInviteTokens("test-invite-secret"),
+			mockNotifications,
+			new(MockCacheServiceForMembership),
+		)
+
+		return service, mockParticipants, mockQueries, mockMessages, mockNotifications
+	}
+
+	t.Run("owner promotes member to admin", func(t *testing.T) {
+		service, mockParticipants, mockQueries, mockMessages, mockNotifications := newService()
+
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("owner", nil)
+		mockQueries.On("GetMemberRole", conversationID, targetID).Return("member", nil)
+		mockParticipants.On("UpdateRole", mock.Anything, mock.MatchedBy(func(participant *domain.Participant) bool {
+			return participant.UserID == targetID && participant.Role == domain.ParticipantRoleAdmin
+		})).Return(nil)
+		mockMessages.On("Send", mock.Anything, mock.MatchedBy(func(message *domain.Message) bool {
+			return message.Type == domain.MessageTypeSystem && message.Content.String() == "was promoted to admin"
+		})).Return(readModel.MessageDTO{}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.MatchedBy(func(notification ws.OutgoingNotification) bool {
+			return notification.Type == "participant_role_changed"
+		})).Return(nil)
+
+		err := service.Promote(ctx, conversationID, userID, targetID, "admin")
+
+		assert.NoError(t, err)
+		mockParticipants.AssertExpectations(t)
+		mockMessages.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("admin demotes moderator", func(t *testing.T) {
+		service, mockParticipants, mockQueries, mockMessages, mockNotifications := newService()
+
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("admin", nil)
+		mockQueries.On("GetMemberRole", conversationID, targetID).Return("moderator", nil)
+		mockParticipants.On("UpdateRole", mock.Anything, mock.Anything).Return(nil)
+		mockMessages.On("Send", mock.Anything, mock.MatchedBy(func(message *domain.Message) bool {
+			return message.Content.String() == "was demoted to member"
+		})).Return(readModel.MessageDTO{}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.Anything).Return(nil)
+
+		err := service.Demote(ctx, conversationID, userID, targetID, "member")
+
+		assert.NoError(t, err)
+		mockParticipants.AssertExpectations(t)
+	})
+
+	t.Run("admin cannot promote to admin", func(t *testing.T) {
+		service, mockParticipants, mockQueries, _, _ := newService()
+
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("admin", nil)
+		mockQueries.On("GetMemberRole", conversationID, targetID).Return("member", nil)
+
+		err := service.Promote(ctx, conversationID, userID, targetID, "admin")
+
+		assert.ErrorIs(t, err, domain.ErrorCannotAssignRole)
+		mockParticipants.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("promote cannot lower role", func(t *testing.T) {
+		service, _, mockQueries, _, _ := newService()
+
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("owner", nil)
+		mockQueries.On("GetMemberRole", conversationID, targetID).Return("admin", nil)
+
+		err := service.Promote(ctx, conversationID, userID, targetID, "moderator")
+
+		assert.ErrorIs(t, err, domain.ErrorCannotAssignRole)
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		service, _, _, _, _ := newService()
+
+		err := service.Promote(ctx, conversationID, userID, targetID, "superuser")
+
+		assert.ErrorIs(t, err, domain.ErrorUnknownParticipantRole)
+	})
+}
+
+func TestMembershipService_MarkAsRead(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+	messageID := uuid.New()
+
+	mockParticipants := new(MockParticipantRepository)
+	mockQueries := new(MockQueriesRepositoryForMembership)
+	mockMessages := new(MockMessageServiceForMembership)
+	mockNotifications := new(MockNotificationServiceForMembership)
+	mockCache := new(MockCacheServiceForMembership)
+
+	service := NewMembershipService(
+		mockParticipants,
+		new(MockInviteLinkRepository),
+		new(MockJoinRequestRepository),
+		mockQueries,
+		mockMessages,
+		new(MockGroupConversationServiceForMembership),
+		NewInviteTokens("test-invite-secret"),
+		mockNotifications,
+		mockCache,
+	)
+
+	t.Run("marker advanced", func(t *testing.T) {
+		mockQueries.On("IsMember", conversationID, userID).Return(true, nil)
+		mockParticipants.On("MarkRead", mock.Anything, conversationID, userID, messageID).Return(true, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "read_receipt" && n.UserID == userID
+		})).Return(nil)
+
+		err := service.MarkAsRead(ctx, conversationID, userID, messageID)
+
+		assert.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+		mockParticipants.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("marker already past message", func(t *testing.T) {
+		mockQueries.ExpectedCalls = nil
+		mockParticipants.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		mockQueries.On("IsMember", conversationID, userID).Return(true, nil)
+		mockParticipants.On("MarkRead", mock.Anything, conversationID, userID, messageID).Return(false, nil)
+
+		err := service.MarkAsRead(ctx, conversationID, userID, messageID)
+
+		assert.NoError(t, err)
+		mockParticipants.AssertExpectations(t)
+	})
+
+	t.Run("not member", func(t *testing.T) {
+		mockQueries.ExpectedCalls = nil
+		mockParticipants.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		mockQueries.On("IsMember", conversationID, userID).Return(false, nil)
+
+		err := service.MarkAsRead(ctx, conversationID, userID, messageID)
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotInConversation)
+	})
+}
+
+func TestMembershipService_JoinRequests(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+	adminID := uuid.New()
+
+	newService := func() (MembershipService, *MockJoinRequestRepository, *MockQueriesRepositoryForMembership, *MockNotificationServiceForMembership, *MockMessageServiceForMembership, *MockCacheServiceForMembership) {
+		mockJoinRequests := new(MockJoinRequestRepository)
+		mockQueries := new(MockQueriesRepositoryForMembership)
+		mockNotifications := new(MockNotificationServiceForMembership)
+		mockMessages := new(MockMessageServiceForMembership)
+		mockCache := new(MockCacheServiceForMembership)
+
+		service := NewMembershipService(
+			new(MockParticipantRepository),
+			new(MockInviteLinkRepository),
+			mockJoinRequests,
+			mockQueries,
+			mockMessages,
+			new(MockGroupConversationServiceForMembership),
+			NewInviteTokens("test-invite-secret"),
+			mockNotifications,
+			mockCache,
+		)
+
+		return service, mockJoinRequests, mockQueries, mockNotifications, mockMessages, mockCache
+	}
+
+	newRequest := func() *domain.JoinRequest {
+		request, err := domain.NewJoinRequest(conversationID, userID, domain.ConversationVisibilityPrivate)
+		assert.NoError(t, err)
+		return request
+	}
+
+	t.Run("request notifies reviewers", func(t *testing.T) {
+		service, mockJoinRequests, mockQueries, mockNotifications, _, _ := newService()
+
+		mockQueries.On("IsMember", conversationID, userID).Return(false, nil)
+		mockQueries.On("GetGroupVisibility", conversationID).Return("private", nil)
+		mockJoinRequests.On("Store", mock.Anything, mock.AnythingOfType("*domain.JoinRequest")).Return(nil)
+		mockQueries.On("GetUserByID", userID).Return(readModel.UserDTO{ID: userID}, nil)
+		mockQueries.On("GetMemberIDsByRoles", conversationID, []string{"owner", "admin"}).Return([]uuid.UUID{adminID}, nil)
+		mockNotifications.On("NotifyUser", mock.Anything, adminID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "join_request_created" && n.UserID == userID
+		})).Return(nil)
+
+		err := service.RequestToJoin(ctx, conversationID, userID)
+
+		assert.NoError(t, err)
+		mockJoinRequests.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("public group does not need a request", func(t *testing.T) {
+		service, _, mockQueries, _, _, _ := newService()
+
+		mockQueries.On("IsMember", conversationID, userID).Return(false, nil)
+		mockQueries.On("GetGroupVisibility", conversationID).Return("public", nil)
+
+		err := service.RequestToJoin(ctx, conversationID, userID)
+
+		assert.ErrorIs(t, err, domain.ErrorJoinRequestNotNeeded)
+	})
+
+	t.Run("member cannot request", func(t *testing.T) {
+		service, _, mockQueries, _, _, _ := newService()
+
+		mockQueries.On("IsMember", conversationID, userID).Return(true, nil)
+
+		err := service.RequestToJoin(ctx, conversationID, userID)
+
+		assert.ErrorIs(t, err, domain.ErrorAlreadyInConversation)
+	})
+
+	t.Run("approve adds participant", func(t *testing.T) {
+		service, mockJoinRequests, mockQueries, mockNotifications, mockMessages, mockCache := newService()
+		request := newRequest()
+
+		mockQueries.On("GetMemberRole", conversationID, adminID).Return("admin", nil)
+		mockJoinRequests.On("GetByID", mock.Anything, request.ID).Return(request, nil)
+		mockJoinRequests.On("Approve", mock.Anything, request, mock.MatchedBy(func(p *domain.Participant) bool {
+			return p.ConversationID == conversationID && p.UserID == userID
+		})).Return(nil)
+		mockMessages.On("Send", mock.Anything, mock.AnythingOfType("*domain.Message")).Return(readModel.MessageDTO{}, nil)
+		mockCache.On("InvalidateParticipants", mock.Anything, conversationID).Return(nil)
+		mockNotifications.On("InvalidateMembership", mock.Anything, userID).Return(nil)
+		mockNotifications.On("NotifyUser", mock.Anything, userID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "join_request_approved"
+		})).Return(nil)
+
+		err := service.ApproveJoinRequest(ctx, conversationID, adminID, request.ID)
+
+		assert.NoError(t, err)
+		mockJoinRequests.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("reject notifies requester", func(t *testing.T) {
+		service, mockJoinRequests, mockQueries, mockNotifications, _, _ := newService()
+		request := newRequest()
+
+		mockQueries.On("GetMemberRole", conversationID, adminID).Return("owner", nil)
+		mockJoinRequests.On("GetByID", mock.Anything, request.ID).Return(request, nil)
+		mockJoinRequests.On("Reject", mock.Anything, request).Return(nil)
+		mockNotifications.On("NotifyUser", mock.Anything, userID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "join_request_rejected"
+		})).Return(nil)
+
+		err := service.RejectJoinRequest(ctx, conversationID, adminID, request.ID)
+
+		assert.NoError(t, err)
+		mockJoinRequests.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("moderator cannot list requests", func(t *testing.T) {
+		service, _, mockQueries, _, _, _ := newService()
+
+		mockQueries.On("GetMemberRole", conversationID, adminID).Return("moderator", nil)
+
+		_, err := service.GetJoinRequests(conversationID, adminID)
+
+		assert.ErrorIs(t, err, domain.ErrorInsufficientPermissions)
+	})
+}
+
+func TestMembershipService_UpdateNotificationSettings(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+	mutedUntil := time.Now().Add(time.Hour)
+
+	newService := func() (MembershipService, *MockParticipantRepository, *MockQueriesRepositoryForMembership, *MockNotificationServiceForMembership) {
+		mockParticipants := new(MockParticipantRepository)
+		mockQueries := new(MockQueriesRepositoryForMembership)
+		mockNotifications := new(MockNotificationServiceForMembership)
+
+		service := NewMembershipService(
+			mockParticipants,
+			new(MockInviteLinkRepository),
+			new(MockJoinRequestRepository),
+			mockQueries,
+			new(MockMessageServiceForMembership),
+			new(MockGroupConversationServiceForMembership),
+			NewInviteTokens("test-invite-secret"),
+			mockNotifications,
+			new(MockCacheServiceForMembership),
+		)
+
+		return service, mockParticipants, mockQueries, mockNotifications
+	}
+
+	t.Run("mute conversation", func(t *testing.T) {
+		service, mockParticipants, mockQueries, mockNotifications := newService()
+
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("member", nil)
+		mockParticipants.On("UpdateNotificationPreferences", mock.Anything, mock.MatchedBy(func(p *domain.Participant) bool {
+			return p.UserID == userID &&
+				p.NotificationPreferences.Level == domain.NotificationLevelMentions &&
+				p.NotificationPreferences.MutedUntil.Equal(mutedUntil)
+		})).Return(nil)
+		mockNotifications.On("InvalidateMembership", mock.Anything, userID).Return(nil)
+		mockNotifications.On("NotifyUser", mock.Anything, userID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "notification_settings_updated"
+		})).Return(nil)
+
+		settings, err := service.UpdateNotificationSettings(ctx, conversationID, userID, "mentions", &mutedUntil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "mentions", settings.NotificationLevel)
+		assert.Equal(t, &mutedUntil, settings.MutedUntil)
+		mockParticipants.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("unknown level", func(t *testing.T) {
+		service, _, _, _ := newService()
+
+		_, err := service.UpdateNotificationSettings(ctx, conversationID, userID, "sometimes", nil)
+
+		assert.ErrorIs(t, err, domain.ErrorUnknownNotificationLevel)
+	})
+
+	t.Run("not member", func(t *testing.T) {
+		service, _, mockQueries, _ := newService()
+
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("", domain.ErrorUserNotInConversation)
+
+		_, err := service.UpdateNotificationSettings(ctx, conversationID, userID, "all", nil)
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotInConversation)
+	})
+
+	t.Run("get returns the member's own settings", func(t *testing.T) {
+		service, mockParticipants, mockQueries, _ := newService()
+
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("member", nil)
+		mockParticipants.On("GetByConversationIDAndUserID", mock.Anything, conversationID, userID).Return(&domain.Participant{
+			ConversationID:          conversationID,
+			UserID:                  userID,
+			NotificationPreferences: domain.NotificationPreferences{Level: domain.NotificationLevelMentions, MutedUntil: &mutedUntil},
+		}, nil)
+
+		settings, err := service.GetNotificationSettings(conversationID, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "mentions", settings.NotificationLevel)
+		assert.Equal(t, &mutedUntil, settings.MutedUntil)
 	})
 }
@@ -2,41 +2,295 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"GitHub/go-chat/backend/internal/domain"
 	"GitHub/go-chat/backend/internal/readModel"
 	"GitHub/go-chat/backend/internal/repository"
 	ws "GitHub/go-chat/backend/internal/websocket"
+
+	"github.com/google/uuid"
 )
 
 type MessageService interface {
 	Send(ctx context.Context, message *domain.Message) (readModel.MessageDTO, error)
+	Reply(ctx context.Context, parentID uuid.UUID, userID uuid.UUID, content string) (readModel.MessageDTO, error)
+	Forward(ctx context.Context, messageID uuid.UUID, conversationID uuid.UUID, userID uuid.UUID) (readModel.MessageDTO, error)
+	Edit(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, content string) (readModel.MessageDTO, error)
+	Delete(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, scope domain.MessageDeletionScope) error
 }
 
 type messageService struct {
 	messages      repository.MessageRepository
+	queries       readModel.QueriesRepository
 	notifications NotificationService
 }
 
 func NewMessageService(
 	messages repository.MessageRepository,
+	queries readModel.QueriesRepository,
 	notifications NotificationService,
 ) MessageService {
 	return &messageService{
 		messages:      messages,
+		queries:       queries,
 		notifications: notifications,
 	}
 }
 
 func (s *messageService) Send(ctx context.Context, message *domain.Message) (readModel.MessageDTO, error) {
+	if message.Type == domain.MessageTypeUser {
+		if err := s.ensureNotBlocked(message.ConversationID, message.UserID); err != nil {
+			return readModel.MessageDTO{}, err
+		}
+	}
+
+	if err := s.resolveMentions(message); err != nil {
+		return readModel.MessageDTO{}, err
+	}
+
 	dto, err := s.messages.Send(ctx, message)
 	if err != nil {
 		return dto, err
 	}
 
-	if err := s.notifications.Broadcast(ctx, message.ConversationID, ws.OutgoingNotification{Type: "message", Payload: dto, UserID: message.UserID}); err != nil {
+	if err := s.notifications.Broadcast(ctx, message.ConversationID, ws.OutgoingNotification{Type: "message", Payload: dto, UserID: message.UserID, MentionedUserIDs: message.Mentions}); err != nil {
+		return dto, err
+	}
+
+	if err := s.notifyMentioned(ctx, message, message.Mentions, dto); err != nil {
+		return dto, err
+	}
+
+	return dto, nil
+}
+
+func (s *messageService) Reply(ctx context.Context, parentID uuid.UUID, userID uuid.UUID, content string) (readModel.MessageDTO, error) {
+	parent, err := s.messages.GetByID(ctx, parentID)
+	if err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("get parent message error: %w", err)
+	}
+
+	isMember, err := s.queries.IsMember(parent.ConversationID, userID)
+	if err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("is member error: %w", err)
+	}
+	if !isMember {
+		return readModel.MessageDTO{}, fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation)
+	}
+
+	hidden, err := s.queries.IsMessageHidden(parent.ID, userID)
+	if err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("is message hidden error: %w", err)
+	}
+	if hidden {
+		return readModel.MessageDTO{}, domain.ErrorMessageNotFound
+	}
+
+	if err := s.ensureNotBlocked(parent.ConversationID, userID); err != nil {
+		return readModel.MessageDTO{}, err
+	}
+
+	reply, err := domain.NewReplyMessage(parent, userID, content)
+	if err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("new reply message error: %w", err)
+	}
+
+	if err := s.resolveMentions(reply); err != nil {
+		return readModel.MessageDTO{}, err
+	}
+
+	dto, err := s.messages.Send(ctx, reply)
+	if err != nil {
+		return dto, fmt.Errorf("store reply error: %w", err)
+	}
+
+	if err := s.notifications.Broadcast(ctx, reply.ConversationID, ws.OutgoingNotification{Type: "thread_reply", Payload: dto, UserID: userID, MentionedUserIDs: reply.Mentions}); err != nil {
+		return dto, fmt.Errorf("notify error: %w", err)
+	}
+
+	if err := s.notifyMentioned(ctx, reply, reply.Mentions, dto); err != nil {
 		return dto, err
 	}
 
 	return dto, nil
 }
+
+func (s *messageService) Forward(ctx context.Context, messageID uuid.UUID, conversationID uuid.UUID, userID uuid.UUID) (readModel.MessageDTO, error) {
+	original, err := s.messages.GetByID(ctx, messageID)
+	if err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("get original message error: %w", err)
+	}
+
+	for _, id := range []uuid.UUID{original.ConversationID, conversationID} {
+		isMember, err := s.queries.IsMember(id, userID)
+		if err != nil {
+			return readModel.MessageDTO{}, fmt.Errorf("is member error: %w", err)
+		}
+		if !isMember {
+			return readModel.MessageDTO{}, fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation)
+		}
+	}
+
+	hidden, err := s.queries.IsMessageHidden(original.ID, userID)
+	if err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("is message hidden error: %w", err)
+	}
+	if hidden {
+		return readModel.MessageDTO{}, domain.ErrorMessageNotFound
+	}
+
+	message, err := domain.NewForwardedMessage(original, conversationID, userID)
+	if err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("new forwarded message error: %w", err)
+	}
+
+	return s.Send(ctx, message)
+}
+
+func (s *messageService) resolveMentions(message *domain.Message) error {
+	names := message.MentionedNames()
+	if len(names) == 0 {
+		message.Mentions = nil
+		return nil
+	}
+
+	userIDs, err := s.queries.GetParticipantIDsByNames(message.ConversationID, names)
+	if err != nil {
+		return fmt.Errorf("resolve mentions error: %w", err)
+	}
+
+	message.Mention(userIDs)
+
+	return nil
+}
+
+func (s *messageService) notifyMentioned(ctx context.Context, message *domain.Message, userIDs []uuid.UUID, dto readModel.MessageDTO) error {
+	for _, userID := range userIDs {
+		if err := s.notifications.NotifyUser(ctx, userID, ws.OutgoingNotification{
+			Type:           "mentioned",
+			UserID:         message.UserID,
+			ConversationID: &message.ConversationID,
+			Payload:        dto,
+		}); err != nil {
+			return fmt.Errorf("notify mentioned error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *messageService) ensureNotBlocked(conversationID uuid.UUID, userID uuid.UUID) error {
+	blocked, err := s.queries.IsBlockedInDirectConversation(conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("is blocked error: %w", err)
+	}
+	if blocked {
+		return domain.ErrorUserBlocked
+	}
+
+	return nil
+}
+
+func (s *messageService) Edit(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, content string) (readModel.MessageDTO, error) {
+	message, err := s.messages.GetByID(ctx, messageID)
+	if err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("get message error: %w", err)
+	}
+
+	isMember, err := s.queries.IsMember(message.ConversationID, userID)
+	if err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("is member error: %w", err)
+	}
+	if !isMember {
+		return readModel.MessageDTO{}, fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation)
+	}
+
+	if err := message.Edit(userID, content); err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("edit message error: %w", err)
+	}
+
+	previous := message.Mentions
+	if err := s.resolveMentions(message); err != nil {
+		return readModel.MessageDTO{}, err
+	}
+
+	dto, err := s.messages.Edit(ctx, message)
+	if err != nil {
+		return dto, fmt.Errorf("store edited message error: %w", err)
+	}
+
+	if err := s.notifications.Broadcast(ctx, message.ConversationID, ws.OutgoingNotification{Type: "message_edited", Payload: dto, UserID: userID}); err != nil {
+		return dto, fmt.Errorf("notify error: %w", err)
+	}
+
+	if err := s.notifyMentioned(ctx, message, message.MentionedSince(previous), dto); err != nil {
+		return dto, err
+	}
+
+	return dto, nil
+}
+
+func (s *messageService) Delete(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, scope domain.MessageDeletionScope) error {
+	message, err := s.messages.GetByID(ctx, messageID)
+	if err != nil {
+		return fmt.Errorf("get message error: %w", err)
+	}
+
+	notification := ws.OutgoingNotification{
+		Type: "message_deleted",
+		Payload: map[string]interface{}{
+			"message_id":      message.ID,
+			"conversation_id": message.ConversationID,
+			"scope":           scope.String(),
+		},
+		UserID: userID,
+	}
+
+	switch scope {
+	case domain.MessageDeletionScopeSelf:
+		isMember, err := s.queries.IsMember(message.ConversationID, userID)
+		if err != nil {
+			return fmt.Errorf("is member error: %w", err)
+		}
+		if !isMember {
+			return fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation)
+		}
+
+		if err := s.messages.Hide(ctx, message.ID, userID); err != nil {
+			return fmt.Errorf("hide message error: %w", err)
+		}
+
+		if err := s.notifications.NotifyUser(ctx, userID, notification); err != nil {
+			return fmt.Errorf("notify error: %w", err)
+		}
+
+	case domain.MessageDeletionScopeEveryone:
+		canDeleteOthers := false
+		participant, err := getParticipant(s.queries, message.ConversationID, userID)
+		switch {
+		case err == nil:
+			canDeleteOthers = participant.Role.Can(domain.PermissionDeleteMessages)
+		case !errors.Is(err, domain.ErrorUserNotInConversation):
+			return fmt.Errorf("get participant error: %w", err)
+		}
+
+		if err := message.Delete(userID, canDeleteOthers); err != nil {
+			return fmt.Errorf("delete message error: %w", err)
+		}
+
+		if err := s.messages.Delete(ctx, message.ID); err != nil {
+			return fmt.Errorf("store deleted message error: %w", err)
+		}
+
+		if err := s.notifications.Broadcast(ctx, message.ConversationID, notification); err != nil {
+			return fmt.Errorf("notify error: %w", err)
+		}
+
+	default:
+		return domain.ErrorUnknownDeletionScope
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/repository"
+	ws "GitHub/go-chat/backend/internal/websocket"
+)
+
+type MessageReaper interface {
+	Run()
+	Shutdown()
+}
+
+type messageReaper struct {
+	ctx           context.Context
+	cancel        context.CancelFunc
+	messages      repository.MessageRepository
+	notifications NotificationService
+	interval      time.Duration
+	batchSize     int
+}
+
+func NewMessageReaper(
+	ctx context.Context,
+	messages repository.MessageRepository,
+	notifications NotificationService,
+	interval time.Duration,
+	batchSize int,
+) MessageReaper {
+	reaperCtx, cancel := context.WithCancel(ctx)
+
+	return &messageReaper{
+		ctx:           reaperCtx,
+		cancel:        cancel,
+		messages:      messages,
+		notifications: notifications,
+		interval:      interval,
+		batchSize:     batchSize,
+	}
+}
+
+func (r *messageReaper) Run() {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.reapExpired()
+
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *messageReaper) Shutdown() {
+	r.cancel()
+}
+
+// reapExpired soft-deletes expired messages and then announces each one. Read
+// queries already hide messages past expires_at, so message_deleted is only a
+// hint for open clients: a failed broadcast is logged and not retried, and a
+// client that misses it must refetch the conversation to drop the message.
+func (r *messageReaper) reapExpired() {
+	for {
+		expired, err := r.messages.DeleteExpired(r.ctx, r.batchSize)
+		if err != nil {
+			log.Printf("Error deleting expired messages: %v", err)
+			return
+		}
+
+		for _, message := range expired {
+			notification := ws.OutgoingNotification{
+				Type: "message_deleted",
+				Payload: map[string]interface{}{
+					"message_id":      message.ID,
+					"conversation_id": message.ConversationID,
+					"scope":           domain.MessageDeletionScopeEveryone.String(),
+				},
+			}
+
+			if err := r.notifications.Broadcast(r.ctx, message.ConversationID, notification); err != nil {
+				log.Printf("Error broadcasting expired message %s: %v", message.ID, err)
+			}
+		}
+
+		if len(expired) < r.batchSize || r.ctx.Err() != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+	ws "GitHub/go-chat/backend/internal/websocket"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMessageReaper_ReapExpired(t *testing.T) {
+	t.Run("broadcasts deletion for each expired message", func(t *testing.T) {
+		mockMessages := new(MockMessageRepository)
+		mockNotifications := new(MockNotificationServiceForMessageTest)
+		reaper := NewMessageReaper(context.Background(), mockMessages, mockNotifications, time.Second, 2).(*messageReaper)
+
+		conversationID := uuid.New()
+		first := domain.ExpiredMessage{ID: uuid.New(), ConversationID: conversationID}
+		second := domain.ExpiredMessage{ID: uuid.New(), ConversationID: conversationID}
+		third := domain.ExpiredMessage{ID: uuid.New(), ConversationID: uuid.New()}
+
+		mockMessages.On("DeleteExpired", mock.Anything, 2).Return([]domain.ExpiredMessage{first, second}, nil).Once()
+		mockMessages.On("DeleteExpired", mock.Anything, 2).Return([]domain.ExpiredMessage{third}, nil).Once()
+		for _, expired := range []domain.ExpiredMessage{first, second, third} {
+			mockNotifications.On("Broadcast", mock.Anything, expired.ConversationID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+				payload := n.Payload.(map[string]interface{})
+				return n.Type == "message_deleted" && payload["message_id"] == expired.ID
+			})).Return(nil).Once()
+		}
+
+		reaper.reapExpired()
+
+		mockMessages.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("stops on repository error", func(t *testing.T) {
+		mockMessages := new(MockMessageRepository)
+		mockNotifications := new(MockNotificationServiceForMessageTest)
+		reaper := NewMessageReaper(context.Background(), mockMessages, mockNotifications, time.Second, 2).(*messageReaper)
+
+		mockMessages.On("DeleteExpired", mock.Anything, 2).Return(nil, errors.New("db error")).Once()
+
+		reaper.reapExpired()
+
+		mockMessages.AssertExpectations(t)
+		mockNotifications.AssertNotCalled(t, "Broadcast", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -23,6 +23,37 @@ func (m *MockMessageRepository) Send(ctx context.Context, message *domain.Messag
 	return args.Get(0).(readModel.MessageDTO), args.Error(1)
 }
 
+func (m *MockMessageRepository) Edit(ctx context.Context, message *domain.Message) (readModel.MessageDTO, error) {
+	args := m.Called(ctx, message)
+	return args.Get(0).(readModel.MessageDTO), args.Error(1)
+}
+
+func (m *MockMessageRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Message, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) Hide(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(ctx, messageID, userID)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) DeleteExpired(ctx context.Context, limit int) ([]domain.ExpiredMessage, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ExpiredMessage), args.Error(1)
+}
+
 type MockNotificationServiceForMessageTest struct {
 	mock.Mock
 }
@@ -32,8 +63,8 @@ func (m *MockNotificationServiceForMessageTest) Broadcast(ctx context.Context, c
 	return args.Error(0)
 }
 
-func (m *MockNotificationServiceForMessageTest) RegisterClient(ctx context.Context, conn *websocket.Conn, userID uuid.UUID) uuid.UUID {
-	args := m.Called(ctx, conn, userID)
+func (m *MockNotificationServiceForMessageTest) RegisterClient(ctx context.Context, conn *websocket.Conn, userID uuid.UUID, resume map[uuid.UUID]int64) uuid.UUID {
+	args := m.Called(ctx, conn, userID, resume)
 	return args.Get(0).(uuid.UUID)
 }
 
@@ -50,6 +81,11 @@ func (m *MockNotificationServiceForMessageTest) Shutdown() {
 	m.Called()
 }
 
+func (m *MockNotificationServiceForMessageTest) NotifyUser(ctx context.Context, userID uuid.UUID, notification ws.OutgoingNotification) error {
+	args := m.Called(ctx, userID, notification)
+	return args.Error(0)
+}
+
 func TestMessageService_Send(t *testing.T) {
 	ctx := context.Background()
 	conversationID := uuid.New()
@@ -57,9 +93,12 @@ func TestMessageService_Send(t *testing.T) {
 	content := "Hello, world!"
 
 	mockRepository := new(MockMessageRepository)
+	mockQueries := new(MockQueriesRepository)
 	mockNotifications := new(MockNotificationServiceForMessageTest)
 
-	service := NewMessageService(mockRepository, mockNotifications)
+	service := NewMessageService(mockRepository, mockQueries, mockNotifications)
+
+	mockQueries.On("IsBlockedInDirectConversation", conversationID, userID).Return(false, nil)
 
 	t.Run("successful send", func(t *testing.T) {
 		message, err := domain.NewMessage(conversationID, userID, domain.MessageTypeUser, content)
@@ -101,4 +140,511 @@ func TestMessageService_Send(t *testing.T) {
 
 		assert.Error(t, err)
 	})
+
+	t.Run("blocked in direct conversation", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		blockedID := uuid.New()
+		message, err := domain.NewMessage(conversationID, blockedID, domain.MessageTypeUser, content)
+		assert.NoError(t, err)
+
+		mockQueries.On("IsBlockedInDirectConversation", conversationID, blockedID).Return(true, nil)
+
+		_, err = service.Send(ctx, message)
+
+		assert.ErrorIs(t, err, domain.ErrorUserBlocked)
+	})
+
+	t.Run("mentions are resolved and notified", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		mentionedID := uuid.New()
+		message, err := domain.NewMessage(conversationID, userID, domain.MessageTypeUser, "hey @alice and @stranger")
+		assert.NoError(t, err)
+
+		mockQueries.On("GetParticipantIDsByNames", conversationID, []string{"alice", "stranger"}).Return([]uuid.UUID{mentionedID}, nil)
+		mockRepository.On("Send", mock.Anything, message).Return(readModel.MessageDTO{}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "message" && n.Mentions(mentionedID)
+		})).Return(nil)
+		mockNotifications.On("NotifyUser", mock.Anything, mentionedID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "mentioned" && *n.ConversationID == conversationID
+		})).Return(nil)
+
+		_, err = service.Send(ctx, message)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{mentionedID}, message.Mentions)
+		mockRepository.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+}
+
+func TestMessageService_Reply(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	authorID := uuid.New()
+	replierID := uuid.New()
+
+	mockRepository := new(MockMessageRepository)
+	mockQueries := new(MockQueriesRepository)
+	mockNotifications := new(MockNotificationServiceForMessageTest)
+
+	service := NewMessageService(mockRepository, mockQueries, mockNotifications)
+
+	t.Run("successful reply", func(t *testing.T) {
+		parent, err := domain.NewMessage(conversationID, authorID, domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, parent.ID).Return(parent, nil)
+		mockQueries.On("IsMember", conversationID, replierID).Return(true, nil)
+		mockQueries.On("IsMessageHidden", parent.ID, replierID).Return(false, nil)
+		mockQueries.On("IsBlockedInDirectConversation", conversationID, replierID).Return(false, nil)
+		mockRepository.On("Send", mock.Anything, mock.MatchedBy(func(m *domain.Message) bool {
+			return m.ParentID != nil && *m.ParentID == parent.ID && m.ConversationID == conversationID
+		})).Return(readModel.MessageDTO{ParentID: &parent.ID}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "thread_reply"
+		})).Return(nil)
+
+		dto, err := service.Reply(ctx, parent.ID, replierID, "Hi")
+
+		assert.NoError(t, err)
+		assert.Equal(t, parent.ID, *dto.ParentID)
+		mockRepository.AssertExpectations(t)
+		mockQueries.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("reply to reply joins root thread", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockQueries.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		root, err := domain.NewMessage(conversationID, authorID, domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+		parent, err := domain.NewReplyMessage(root, authorID, "Hi")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, parent.ID).Return(parent, nil)
+		mockQueries.On("IsMember", conversationID, replierID).Return(true, nil)
+		mockQueries.On("IsMessageHidden", parent.ID, replierID).Return(false, nil)
+		mockQueries.On("IsBlockedInDirectConversation", conversationID, replierID).Return(false, nil)
+		mockRepository.On("Send", mock.Anything, mock.MatchedBy(func(m *domain.Message) bool {
+			return m.ParentID != nil && *m.ParentID == root.ID
+		})).Return(readModel.MessageDTO{}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.Anything).Return(nil)
+
+		_, err = service.Reply(ctx, parent.ID, replierID, "Hey")
+
+		assert.NoError(t, err)
+		mockRepository.AssertExpectations(t)
+	})
+
+	t.Run("not member", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockQueries.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		parent, err := domain.NewMessage(conversationID, authorID, domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, parent.ID).Return(parent, nil)
+		mockQueries.On("IsMember", conversationID, replierID).Return(false, nil)
+
+		_, err = service.Reply(ctx, parent.ID, replierID, "Hi")
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotInConversation)
+	})
+
+	t.Run("system message", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockQueries.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		parent, err := domain.NewMessage(conversationID, authorID, domain.MessageTypeSystem, "joined the conversation")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, parent.ID).Return(parent, nil)
+		mockQueries.On("IsMember", conversationID, replierID).Return(true, nil)
+		mockQueries.On("IsMessageHidden", parent.ID, replierID).Return(false, nil)
+		mockQueries.On("IsBlockedInDirectConversation", conversationID, replierID).Return(false, nil)
+
+		_, err = service.Reply(ctx, parent.ID, replierID, "Welcome")
+
+		assert.ErrorIs(t, err, domain.ErrorMessageNotRepliable)
+	})
+
+	t.Run("parent hidden by replier", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockQueries.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		mockRepository.Calls = nil
+		parent, err := domain.NewMessage(conversationID, authorID, domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, parent.ID).Return(parent, nil)
+		mockQueries.On("IsMember", conversationID, replierID).Return(true, nil)
+		mockQueries.On("IsMessageHidden", parent.ID, replierID).Return(true, nil)
+
+		_, err = service.Reply(ctx, parent.ID, replierID, "Hi")
+
+		assert.ErrorIs(t, err, domain.ErrorMessageNotFound)
+		mockRepository.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("deleted parent", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockQueries.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		parentID := uuid.New()
+
+		mockRepository.On("GetByID", mock.Anything, parentID).Return(nil, domain.ErrorMessageNotFound)
+
+		_, err := service.Reply(ctx, parentID, replierID, "Hi")
+
+		assert.ErrorIs(t, err, domain.ErrorMessageNotFound)
+	})
+}
+
+func TestMessageService_Forward(t *testing.T) {
+	ctx := context.Background()
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	authorID := uuid.New()
+	forwarderID := uuid.New()
+
+	mockRepository := new(MockMessageRepository)
+	mockQueries := new(MockQueriesRepository)
+	mockNotifications := new(MockNotificationServiceForMessageTest)
+
+	service := NewMessageService(mockRepository, mockQueries, mockNotifications)
+
+	t.Run("successful forward", func(t *testing.T) {
+		original, err := domain.NewMessage(sourceID, authorID, domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, original.ID).Return(original, nil)
+		mockQueries.On("IsMember", sourceID, forwarderID).Return(true, nil)
+		mockQueries.On("IsMember", targetID, forwarderID).Return(true, nil)
+		mockQueries.On("IsMessageHidden", original.ID, forwarderID).Return(false, nil)
+		mockQueries.On("IsBlockedInDirectConversation", targetID, forwarderID).Return(false, nil).Once()
+		mockRepository.On("Send", mock.Anything, mock.MatchedBy(func(m *domain.Message) bool {
+			return m.ConversationID == targetID && m.UserID == forwarderID &&
+				m.ForwardedFrom != nil && m.ForwardedFrom.UserID == authorID && m.ForwardedFrom.ConversationID == sourceID
+		})).Return(readModel.MessageDTO{ConversationId: targetID}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, targetID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "message"
+		})).Return(nil)
+
+		_, err = service.Forward(ctx, original.ID, targetID, forwarderID)
+
+		assert.NoError(t, err)
+		mockRepository.AssertExpectations(t)
+		mockQueries.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("mentions in forwarded text are notified", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockQueries.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		mentionedID := uuid.New()
+		original, err := domain.NewMessage(sourceID, authorID, domain.MessageTypeUser, "ask @bob")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, original.ID).Return(original, nil)
+		mockQueries.On("IsMember", sourceID, forwarderID).Return(true, nil)
+		mockQueries.On("IsMember", targetID, forwarderID).Return(true, nil)
+		mockQueries.On("IsMessageHidden", original.ID, forwarderID).Return(false, nil)
+		mockQueries.On("IsBlockedInDirectConversation", targetID, forwarderID).Return(false, nil)
+		mockQueries.On("GetParticipantIDsByNames", targetID, []string{"bob"}).Return([]uuid.UUID{mentionedID}, nil)
+		mockRepository.On("Send", mock.Anything, mock.Anything).Return(readModel.MessageDTO{ConversationId: targetID}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, targetID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "message" && n.Mentions(mentionedID)
+		})).Return(nil)
+		mockNotifications.On("NotifyUser", mock.Anything, mentionedID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "mentioned" && *n.ConversationID == targetID
+		})).Return(nil)
+
+		_, err = service.Forward(ctx, original.ID, targetID, forwarderID)
+
+		assert.NoError(t, err)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("message hidden for forwarder", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockRepository.Calls = nil
+		mockQueries.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		original, err := domain.NewMessage(sourceID, authorID, domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, original.ID).Return(original, nil)
+		mockQueries.On("IsMember", sourceID, forwarderID).Return(true, nil)
+		mockQueries.On("IsMember", targetID, forwarderID).Return(true, nil)
+		mockQueries.On("IsMessageHidden", original.ID, forwarderID).Return(true, nil)
+
+		_, err = service.Forward(ctx, original.ID, targetID, forwarderID)
+
+		assert.ErrorIs(t, err, domain.ErrorMessageNotFound)
+		mockRepository.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not member of target", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockQueries.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		original, err := domain.NewMessage(sourceID, authorID, domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, original.ID).Return(original, nil)
+		mockQueries.On("IsMember", sourceID, forwarderID).Return(true, nil)
+		mockQueries.On("IsMember", targetID, forwarderID).Return(false, nil)
+
+		_, err = service.Forward(ctx, original.ID, targetID, forwarderID)
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotInConversation)
+	})
+}
+
+func TestMessageService_Edit(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	authorID := uuid.New()
+
+	mockRepository := new(MockMessageRepository)
+	mockQueries := new(MockQueriesRepository)
+	mockNotifications := new(MockNotificationServiceForMessageTest)
+
+	service := NewMessageService(mockRepository, mockQueries, mockNotifications)
+
+	t.Run("successful edit", func(t *testing.T) {
+		message, err := domain.NewMessage(conversationID, authorID, domain.MessageTypeUser, "Helo")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("IsMember", conversationID, authorID).Return(true, nil)
+		mockRepository.On("Edit", mock.Anything, message).Return(readModel.MessageDTO{ID: message.ID, Text: "Hello", RevisionCount: 1}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "message_edited"
+		})).Return(nil)
+
+		dto, err := service.Edit(ctx, message.ID, authorID, "Hello")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello", message.Content.String())
+		assert.Equal(t, int64(1), dto.RevisionCount)
+		mockRepository.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("not author", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		message, err := domain.NewMessage(conversationID, authorID, domain.MessageTypeUser, "Helo")
+		assert.NoError(t, err)
+
+		otherID := uuid.New()
+
+		mockRepository.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("IsMember", conversationID, otherID).Return(true, nil)
+
+		_, err = service.Edit(ctx, message.ID, otherID, "Hello")
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotAuthor)
+	})
+
+	t.Run("system message", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		message, err := domain.NewMessage(conversationID, authorID, domain.MessageTypeSystem, "joined the conversation")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+
+		_, err = service.Edit(ctx, message.ID, authorID, "left the conversation")
+
+		assert.ErrorIs(t, err, domain.ErrorMessageNotEditable)
+	})
+
+	t.Run("non member", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		mockRepository.Calls = nil
+		formerID := uuid.New()
+		message, err := domain.NewMessage(conversationID, formerID, domain.MessageTypeUser, "Helo")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("IsMember", conversationID, formerID).Return(false, nil)
+
+		_, err = service.Edit(ctx, message.ID, formerID, "Hello")
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotInConversation)
+		mockRepository.AssertNotCalled(t, "Edit", mock.Anything, mock.Anything)
+	})
+
+	t.Run("message not found", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		messageID := uuid.New()
+
+		mockRepository.On("GetByID", mock.Anything, messageID).Return(nil, assert.AnError)
+
+		_, err := service.Edit(ctx, messageID, authorID, "Hello")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("only newly mentioned users are notified", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		mockNotifications.Calls = nil
+		aliceID := uuid.New()
+		bobID := uuid.New()
+		message, err := domain.NewMessage(conversationID, authorID, domain.MessageTypeUser, "hey @alice")
+		assert.NoError(t, err)
+		message.Mention([]uuid.UUID{aliceID})
+
+		mockRepository.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("GetParticipantIDsByNames", conversationID, []string{"alice", "bob"}).Return([]uuid.UUID{aliceID, bobID}, nil)
+		mockRepository.On("Edit", mock.Anything, message).Return(readModel.MessageDTO{ID: message.ID}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "message_edited"
+		})).Return(nil)
+		mockNotifications.On("NotifyUser", mock.Anything, bobID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "mentioned"
+		})).Return(nil)
+
+		_, err = service.Edit(ctx, message.ID, authorID, "hey @Alice and @Bob")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{aliceID, bobID}, message.Mentions)
+		mockRepository.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+		mockNotifications.AssertNotCalled(t, "NotifyUser", mock.Anything, aliceID, mock.Anything)
+	})
+}
+
+func TestMessageService_Delete(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	authorID := uuid.New()
+
+	mockRepository := new(MockMessageRepository)
+	mockQueries := new(MockQueriesRepository)
+	mockNotifications := new(MockNotificationServiceForMessageTest)
+
+	service := NewMessageService(mockRepository, mockQueries, mockNotifications)
+
+	t.Run("author deletes for everyone", func(t *testing.T) {
+		message, err := domain.NewMessage(conversationID, authorID, domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("GetMemberRole", conversationID, authorID).Return("member", nil)
+		mockRepository.On("Delete", mock.Anything, message.ID).Return(nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "message_deleted"
+		})).Return(nil)
+
+		err = service.Delete(ctx, message.ID, authorID, domain.MessageDeletionScopeEveryone)
+
+		assert.NoError(t, err)
+		mockRepository.AssertExpectations(t)
+		mockQueries.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("moderator deletes for everyone", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockQueries.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		moderatorID := uuid.New()
+		message, err := domain.NewMessage(conversationID, authorID, domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("GetMemberRole", conversationID, moderatorID).Return("moderator", nil)
+		mockRepository.On("Delete", mock.Anything, message.ID).Return(nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.Anything).Return(nil)
+
+		err = service.Delete(ctx, message.ID, moderatorID, domain.MessageDeletionScopeEveryone)
+
+		assert.NoError(t, err)
+		mockRepository.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("not author nor owner", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockQueries.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		otherID := uuid.New()
+		message, err := domain.NewMessage(conversationID, authorID, domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("GetMemberRole", conversationID, otherID).Return("member", nil)
+
+		err = service.Delete(ctx, message.ID, otherID, domain.MessageDeletionScopeEveryone)
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotAuthor)
+	})
+
+	t.Run("author who left deletes for everyone", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockQueries.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		message, err := domain.NewMessage(conversationID, authorID, domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("GetMemberRole", conversationID, authorID).Return("", domain.ErrorUserNotInConversation)
+		mockRepository.On("Delete", mock.Anything, message.ID).Return(nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.Anything).Return(nil)
+
+		err = service.Delete(ctx, message.ID, authorID, domain.MessageDeletionScopeEveryone)
+
+		assert.NoError(t, err)
+		mockRepository.AssertExpectations(t)
+	})
+
+	t.Run("member hides for self", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockQueries.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		memberID := uuid.New()
+		message, err := domain.NewMessage(conversationID, authorID, domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("IsMember", conversationID, memberID).Return(true, nil)
+		mockRepository.On("Hide", mock.Anything, message.ID, memberID).Return(nil)
+		mockNotifications.On("NotifyUser", mock.Anything, memberID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "message_deleted"
+		})).Return(nil)
+
+		err = service.Delete(ctx, message.ID, memberID, domain.MessageDeletionScopeSelf)
+
+		assert.NoError(t, err)
+		mockRepository.AssertExpectations(t)
+		mockQueries.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("non member hides for self", func(t *testing.T) {
+		mockRepository.ExpectedCalls = nil
+		mockQueries.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		outsiderID := uuid.New()
+		message, err := domain.NewMessage(conversationID, authorID, domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+
+		mockRepository.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("IsMember", conversationID, outsiderID).Return(false, nil)
+
+		err = service.Delete(ctx, message.ID, outsiderID, domain.MessageDeletionScopeSelf)
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotInConversation)
+	})
 }
@@ -2,7 +2,11 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"log"
 
+	"GitHub/go-chat/backend/internal/domain"
 	ws "GitHub/go-chat/backend/internal/websocket"
 
 	"github.com/google/uuid"
@@ -11,15 +15,32 @@ import (
 
 type NotificationService interface {
 	Broadcast(ctx context.Context, conversationID uuid.UUID, notification ws.OutgoingNotification) error
-	RegisterClient(ctx context.Context, conn *websocket.Conn, userID uuid.UUID) uuid.UUID
+	NotifyUser(ctx context.Context, userID uuid.UUID, notification ws.OutgoingNotification) error
+	RegisterClient(ctx context.Context, conn *websocket.Conn, userID uuid.UUID, resume map[uuid.UUID]int64) uuid.UUID
 	Run()
 	InvalidateMembership(ctx context.Context, userID uuid.UUID) error
 	Shutdown()
 }
 
+type EventLog interface {
+	Append(ctx context.Context, conversationID uuid.UUID, notification ws.OutgoingNotification) (ws.OutgoingNotification, error)
+	ReadAfter(ctx context.Context, conversationID uuid.UUID, afterSeq int64, limit int) ([]ws.OutgoingNotification, int64, error)
+}
+
+type clientRegistration struct {
+	client *ws.Client
+	resume map[uuid.UUID]int64
+}
+
 type broadcastMessage struct {
 	notification   ws.OutgoingNotification
 	conversationID uuid.UUID
+	exceptUserID   uuid.UUID
+}
+
+type userNotificationMessage struct {
+	notification ws.OutgoingNotification
+	userID       uuid.UUID
 }
 
 type BroadcastMessage struct {
@@ -28,11 +49,18 @@ type BroadcastMessage struct {
 	MessageID      string                  `json:"message_id"`
 	ServerID       string                  `json:"server_id"`
 	ConversationID uuid.UUID               `json:"conversation_id"`
+	RecipientID    uuid.UUID               `json:"recipient_id,omitempty"`
+	ExceptSender   bool                    `json:"except_sender,omitempty"`
+}
+
+type presenceUpdate struct {
+	Status string `json:"status"`
 }
 
 type SubscriptionEvent struct {
-	Action string    `json:"action"`
-	UserID uuid.UUID `json:"user_id"`
+	Action   string    `json:"action"`
+	UserID   uuid.UUID `json:"user_id"`
+	ServerID string    `json:"server_id"`
 }
 
 type notificationService struct {
@@ -40,66 +68,270 @@ type notificationService struct {
 	cancel        context.CancelFunc
 	serverID      string
 	activeClients ws.ActiveClients
+	typing        *ws.TypingIndicators
+	presence      PresenceService
+	broadcaster   RedisBroadcaster
+	events        EventLog
 
-	broadcast chan broadcastMessage
+	broadcast        chan broadcastMessage
+	userNotification chan userNotificationMessage
 
-	registerClient chan *ws.Client
+	registerClient chan clientRegistration
 	removeClient   chan *ws.Client
+	incoming       chan ws.IncomingNotification
+	presenceQueue  chan func(ctx context.Context) error
 }
 
 func NewNotificationService(
 	ctx context.Context,
 	serverID string,
 	activeClients ws.ActiveClients,
+	presence PresenceService,
+	broadcaster RedisBroadcaster,
+	events EventLog,
 ) NotificationService {
 	nmCtx, cancel := context.WithCancel(ctx)
 
 	nm := &notificationService{
-		ctx:            nmCtx,
-		cancel:         cancel,
-		serverID:       serverID,
-		activeClients:  activeClients,
-		broadcast:      make(chan broadcastMessage, 1000),
-		registerClient: make(chan *ws.Client, 100),
-		removeClient:   make(chan *ws.Client, 100),
+		ctx:              nmCtx,
+		cancel:           cancel,
+		serverID:         serverID,
+		activeClients:    activeClients,
+		typing:           ws.NewTypingIndicators(activeClients, broadcaster, ws.TypingTimeout),
+		presence:         presence,
+		broadcaster:      broadcaster,
+		events:           events,
+		broadcast:        make(chan broadcastMessage, 1000),
+		userNotification: make(chan userNotificationMessage, 1000),
+		registerClient:   make(chan clientRegistration, 100),
+		removeClient:     make(chan *ws.Client, 100),
+		incoming:         make(chan ws.IncomingNotification, 1000),
+		presenceQueue:    make(chan func(ctx context.Context) error, 1000),
 	}
 
 	return nm
 }
 
 func (ns *notificationService) Broadcast(ctx context.Context, conversationID uuid.UUID, notification ws.OutgoingNotification) error {
+	sequenced, err := ns.events.Append(ctx, conversationID, notification)
+	if err != nil {
+		log.Printf("Error appending event to conversation %s log: %v", conversationID, err)
+	} else {
+		notification = sequenced
+	}
+
+	ns.DeliverToConversation(ctx, conversationID, notification)
+
+	if err := ns.broadcaster.PublishNotification(ctx, conversationID, notification); err != nil {
+		return fmt.Errorf("publish notification error: %w", err)
+	}
+	return nil
+}
+
+func (ns *notificationService) NotifyUser(ctx context.Context, userID uuid.UUID, notification ws.OutgoingNotification) error {
+	ns.DeliverToUser(ctx, userID, notification)
+
+	if err := ns.broadcaster.PublishUserNotification(ctx, userID, notification); err != nil {
+		return fmt.Errorf("publish user notification error: %w", err)
+	}
+	return nil
+}
+
+func (ns *notificationService) DeliverToConversation(ctx context.Context, conversationID uuid.UUID, notification ws.OutgoingNotification) {
 	ns.broadcast <- broadcastMessage{
 		conversationID: conversationID,
 		notification:   notification,
 	}
-	return nil
 }
 
-func (ns *notificationService) RegisterClient(ctx context.Context, conn *websocket.Conn, userID uuid.UUID) uuid.UUID {
-	client := ws.NewClient(conn, ns.removeClient, userID)
-	ns.registerClient <- client
+func (ns *notificationService) DeliverToConversationExcept(ctx context.Context, conversationID uuid.UUID, exceptUserID uuid.UUID, notification ws.OutgoingNotification) {
+	ns.broadcast <- broadcastMessage{
+		conversationID: conversationID,
+		exceptUserID:   exceptUserID,
+		notification:   notification,
+	}
+}
+
+func (ns *notificationService) DeliverToUser(ctx context.Context, userID uuid.UUID, notification ws.OutgoingNotification) {
+	ns.userNotification <- userNotificationMessage{
+		userID:       userID,
+		notification: notification,
+	}
+}
+
+func (ns *notificationService) RegisterClient(ctx context.Context, conn *websocket.Conn, userID uuid.UUID, resume map[uuid.UUID]int64) uuid.UUID {
+	client := ws.NewClient(conn, ns.removeClient, ns.incoming, userID)
+	if len(resume) > 0 {
+		client.BeginReplay()
+	}
+
+	ns.registerClient <- clientRegistration{client: client, resume: resume}
 	return client.Id
 }
 
 func (ns *notificationService) InvalidateMembership(ctx context.Context, userID uuid.UUID) error {
+	if err := ns.InvalidateLocalMembership(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := ns.broadcaster.PublishInvalidate(ctx, userID); err != nil {
+		return fmt.Errorf("publish invalidate error: %w", err)
+	}
+	return nil
+}
+
+func (ns *notificationService) InvalidateLocalMembership(ctx context.Context, userID uuid.UUID) error {
 	return ns.activeClients.InvalidateMembership(ctx, userID)
 }
 
 func (ns *notificationService) Run() {
+	if err := ns.broadcaster.Subscribe(ns.ctx, ns); err != nil {
+		log.Printf("Error subscribing to broadcasts: %v", err)
+	}
+
+	go ns.runPresence()
+	go ns.typing.Run(ns.ctx)
+
 	for {
 		select {
 		case msg := <-ns.broadcast:
+			if msg.exceptUserID != uuid.Nil {
+				ns.activeClients.NotifyChannelClientsExcept(ns.ctx, msg.conversationID, msg.exceptUserID, msg.notification)
+				continue
+			}
 			ns.activeClients.NotifyChannelClients(ns.ctx, msg.conversationID, msg.notification)
 
-		case client := <-ns.registerClient:
+		case msg := <-ns.userNotification:
+			ns.activeClients.NotifyUserClients(ns.ctx, msg.userID, msg.notification)
+
+		case registration := <-ns.registerClient:
+			client := registration.client
 			ns.activeClients.AddClient(client)
 
 			go client.WritePump()
-			client.ReadPump()
+			go client.ReadPump()
+
+			if len(registration.resume) > 0 {
+				go ns.replay(client, registration.resume)
+			}
+
+			ns.queuePresence(func(ctx context.Context) error {
+				return ns.presence.Connect(ctx, client.UserID, client.Id)
+			})
+
+		case msg := <-ns.incoming:
+			ns.handleIncoming(msg)
 
 		case client := <-ns.removeClient:
 			ns.activeClients.RemoveClient(client)
 
+			ns.queuePresence(func(ctx context.Context) error {
+				return ns.presence.Disconnect(ctx, client.UserID, client.Id)
+			})
+
+		case <-ns.ctx.Done():
+			return
+		}
+	}
+}
+
+func (ns *notificationService) replay(client *ws.Client, resume map[uuid.UUID]int64) {
+	client.FinishReplay(ns.collectReplay(client, resume))
+}
+
+func (ns *notificationService) collectReplay(client *ws.Client, resume map[uuid.UUID]int64) []ws.OutgoingNotification {
+	var replayed []ws.OutgoingNotification
+	budget := ws.ReplayLimit
+
+	for conversationID, afterSeq := range resume {
+		if !ns.activeClients.IsSubscribed(client, conversationID) {
+			continue
+		}
+
+		missed, lastSeq, err := ns.events.ReadAfter(ns.ctx, conversationID, afterSeq, max(budget, 1))
+		if err != nil {
+			log.Printf("Error reading conversation %s events for replay: %v", conversationID, err)
+		}
+
+		if err == nil && lastSeq <= afterSeq {
+			continue
+		}
+
+		complete := err == nil &&
+			len(missed) > 0 &&
+			missed[0].Seq == afterSeq+1 &&
+			missed[len(missed)-1].Seq == lastSeq
+
+		if !complete || budget <= 0 {
+			replayed = append(replayed, resyncRequired(conversationID, lastSeq))
+			continue
+		}
+
+		for _, event := range missed {
+			replayed = append(replayed, ns.activeClients.Prepare(client, conversationID, event))
+		}
+		budget -= len(missed)
+	}
+
+	return replayed
+}
+
+func resyncRequired(conversationID uuid.UUID, lastSeq int64) ws.OutgoingNotification {
+	return ws.OutgoingNotification{
+		Type:           ws.ResyncRequiredEvent,
+		ConversationID: &conversationID,
+		Seq:            lastSeq,
+		Payload: map[string]interface{}{
+			"conversation_id": conversationID,
+		},
+	}
+}
+
+func (ns *notificationService) handleIncoming(notification ws.IncomingNotification) {
+	switch notification.Type {
+	case ws.TypingStartedEvent, ws.TypingStoppedEvent:
+		ns.typing.Handle(ns.ctx, notification)
+	case ws.HeartbeatEvent:
+		ns.queuePresence(func(ctx context.Context) error {
+			return ns.presence.Heartbeat(ctx, notification.UserID)
+		})
+	case PresenceUpdateEvent:
+		var update presenceUpdate
+		if err := json.Unmarshal(notification.Data, &update); err != nil {
+			log.Printf("Error unmarshaling presence update from user %s: %v", notification.UserID, err)
+			return
+		}
+
+		status, err := domain.NewReportedPresenceStatus(update.Status)
+		if err != nil {
+			log.Printf("Invalid presence update from user %s: %v", notification.UserID, err)
+			return
+		}
+
+		ns.queuePresence(func(ctx context.Context) error {
+			return ns.presence.SetStatus(ctx, notification.UserID, notification.ClientID, status)
+		})
+	default:
+		log.Printf("Unknown incoming notification type %q from user %s", notification.Type, notification.UserID)
+	}
+}
+
+func (ns *notificationService) queuePresence(update func(ctx context.Context) error) {
+	select {
+	case ns.presenceQueue <- update:
+	default:
+		log.Println("Presence queue full, dropping presence update")
+	}
+}
+
+func (ns *notificationService) runPresence() {
+	for {
+		select {
+		case update := <-ns.presenceQueue:
+			if err := update(ns.ctx); err != nil {
+				log.Printf("Error updating presence: %v", err)
+			}
+
 		case <-ns.ctx.Done():
 			return
 		}
@@ -108,4 +340,5 @@ func (ns *notificationService) Run() {
 
 func (ns *notificationService) Shutdown() {
 	ns.cancel()
+	_ = ns.broadcaster.Close()
 }
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	ws "GitHub/go-chat/backend/internal/websocket"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeEventLog struct {
+	mu        sync.Mutex
+	maxLength int
+	lastSeqs  map[uuid.UUID]int64
+	events    map[uuid.UUID][]ws.OutgoingNotification
+}
+
+func newFakeEventLog(maxLength int) *fakeEventLog {
+	return &fakeEventLog{
+		maxLength: maxLength,
+		lastSeqs:  make(map[uuid.UUID]int64),
+		events:    make(map[uuid.UUID][]ws.OutgoingNotification),
+	}
+}
+
+func (l *fakeEventLog) Append(ctx context.Context, conversationID uuid.UUID, notification ws.OutgoingNotification) (ws.OutgoingNotification, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lastSeqs[conversationID]++
+	notification.ConversationID = &conversationID
+	notification.Seq = l.lastSeqs[conversationID]
+
+	events := append(l.events[conversationID], notification)
+	if len(events) > l.maxLength {
+		events = events[len(events)-l.maxLength:]
+	}
+	l.events[conversationID] = events
+
+	return notification, nil
+}
+
+func (l *fakeEventLog) ReadAfter(ctx context.Context, conversationID uuid.UUID, afterSeq int64, limit int) ([]ws.OutgoingNotification, int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var events []ws.OutgoingNotification
+	for _, event := range l.events[conversationID] {
+		if event.Seq > afterSeq && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+
+	return events, l.lastSeqs[conversationID], nil
+}
+
+func TestNotificationService_CollectReplay(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	trimmedConversationID := uuid.New()
+	foreignConversationID := uuid.New()
+
+	events := newFakeEventLog(3)
+	activeClients := newRecordingActiveClients()
+	activeClients.subscribed[conversationID] = true
+	activeClients.subscribed[trimmedConversationID] = true
+
+	ns := NewNotificationService(ctx, "server-1", activeClients, nil, nil, events).(*notificationService)
+	client := ws.NewClient(nil, nil, nil, uuid.New())
+
+	for i := 0; i < 3; i++ {
+		_, _ = events.Append(ctx, conversationID, ws.OutgoingNotification{Type: "message"})
+		_, _ = events.Append(ctx, foreignConversationID, ws.OutgoingNotification{Type: "message"})
+	}
+	for i := 0; i < 5; i++ {
+		_, _ = events.Append(ctx, trimmedConversationID, ws.OutgoingNotification{Type: "message"})
+	}
+
+	t.Run("replays missed events in order", func(t *testing.T) {
+		replayed := ns.collectReplay(client, map[uuid.UUID]int64{conversationID: 1})
+
+		assert.Len(t, replayed, 2)
+		assert.Equal(t, int64(2), replayed[0].Seq)
+		assert.Equal(t, int64(3), replayed[1].Seq)
+		assert.Equal(t, &conversationID, replayed[0].ConversationID)
+	})
+
+	t.Run("skips up to date and foreign conversations", func(t *testing.T) {
+		replayed := ns.collectReplay(client, map[uuid.UUID]int64{
+			conversationID:        3,
+			foreignConversationID: 0,
+		})
+
+		assert.Empty(t, replayed)
+	})
+
+	t.Run("prepares replayed events like live delivery", func(t *testing.T) {
+		activeClients.muted[conversationID] = true
+		defer delete(activeClients.muted, conversationID)
+
+		replayed := ns.collectReplay(client, map[uuid.UUID]int64{conversationID: 2})
+
+		assert.Len(t, replayed, 1)
+		assert.True(t, replayed[0].Silent)
+	})
+
+	t.Run("asks for resync when the log was trimmed", func(t *testing.T) {
+		replayed := ns.collectReplay(client, map[uuid.UUID]int64{trimmedConversationID: 1})
+
+		assert.Len(t, replayed, 1)
+		assert.Equal(t, ws.ResyncRequiredEvent, replayed[0].Type)
+		assert.Equal(t, &trimmedConversationID, replayed[0].ConversationID)
+		assert.Equal(t, int64(5), replayed[0].Seq)
+	})
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"fmt"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+
+	"github.com/google/uuid"
+)
+
+func getParticipant(queries readModel.QueriesRepository, conversationID uuid.UUID, userID uuid.UUID) (*domain.Participant, error) {
+	role, err := queries.GetMemberRole(conversationID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get member role error: %w", err)
+	}
+
+	participantRole, err := domain.NewParticipantRole(role)
+	if err != nil {
+		return nil, fmt.Errorf("participant role error: %w", err)
+	}
+
+	return &domain.Participant{
+		ConversationID: conversationID,
+		UserID:         userID,
+		Role:           participantRole,
+	}, nil
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+	"GitHub/go-chat/backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type PinService interface {
+	Pin(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) error
+	Unpin(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) error
+	GetPinnedMessages(conversationID uuid.UUID, userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.PinnedMessageDTO, error)
+}
+
+type pinService struct {
+	pins          repository.PinRepository
+	messages      repository.MessageRepository
+	queries       readModel.QueriesRepository
+	messageSender MessageService
+	notifications NotificationService
+	cache         CacheService
+	limit         int
+}
+
+func NewPinService(
+	pins repository.PinRepository,
+	messages repository.MessageRepository,
+	queries readModel.QueriesRepository,
+	messageSender MessageService,
+	notifications NotificationService,
+	cache CacheService,
+	limit int,
+) PinService {
+	return &pinService{
+		pins:          pins,
+		messages:      messages,
+		queries:       queries,
+		messageSender: messageSender,
+		notifications: notifications,
+		cache:         cache,
+		limit:         limit,
+	}
+}
+
+func (s *pinService) Pin(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) error {
+	message, err := s.messages.GetByID(ctx, messageID)
+	if err != nil {
+		return fmt.Errorf("get message error: %w", err)
+	}
+
+	participant, err := getParticipant(s.queries, message.ConversationID, userID)
+	if err != nil {
+		return fmt.Errorf("get participant error: %w", err)
+	}
+
+	pin, err := domain.NewPin(message, participant)
+	if err != nil {
+		return fmt.Errorf("new pin error: %w", err)
+	}
+
+	if err := s.pins.Store(ctx, pin, s.limit); err != nil {
+		return fmt.Errorf("store pin error: %w", err)
+	}
+
+	return s.notifyPinsUpdated(ctx, message.ConversationID, userID, "pinned a message")
+}
+
+func (s *pinService) Unpin(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) error {
+	message, err := s.messages.GetByID(ctx, messageID)
+	if err != nil {
+		return fmt.Errorf("get message error: %w", err)
+	}
+
+	participant, err := getParticipant(s.queries, message.ConversationID, userID)
+	if err != nil {
+		return fmt.Errorf("get participant error: %w", err)
+	}
+
+	if err := domain.AuthorizeUnpin(message, participant); err != nil {
+		return fmt.Errorf("authorize error: %w", err)
+	}
+
+	if err := s.pins.Delete(ctx, message.ConversationID, message.ID); err != nil {
+		return fmt.Errorf("delete pin error: %w", err)
+	}
+
+	return s.notifyPinsUpdated(ctx, message.ConversationID, userID, "unpinned a message")
+}
+
+func (s *pinService) GetPinnedMessages(conversationID uuid.UUID, userID uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.PinnedMessageDTO, error) {
+	isMember, err := s.queries.IsMember(conversationID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("is member error: %w", err)
+	}
+	if !isMember {
+		return nil, fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation)
+	}
+
+	pins, err := s.queries.GetPinnedMessages(conversationID, userID, paginationInfo)
+	if err != nil {
+		return nil, fmt.Errorf("get pinned messages error: %w", err)
+	}
+
+	return pins, nil
+}
+
+func (s *pinService) notifyPinsUpdated(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, text string) error {
+	pinMessage, err := domain.NewMessage(conversationID, userID, domain.MessageTypeSystem, text)
+	if err != nil {
+		return fmt.Errorf("create pin message error: %w", err)
+	}
+
+	if _, err := s.messageSender.Send(ctx, pinMessage); err != nil {
+		return fmt.Errorf("store pin message error: %w", err)
+	}
+
+	if err := s.cache.InvalidateConversation(ctx, conversationID); err != nil {
+		return fmt.Errorf("invalidate cache error: %w", err)
+	}
+
+	conversationDTO, err := s.queries.GetConversation(conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("get conversation error: %w", err)
+	}
+
+	if err := s.notifications.Broadcast(ctx, conversationDTO.ID, conversationUpdated(conversationDTO)); err != nil {
+		return fmt.Errorf("notify error: %w", err)
+	}
+
+	return nil
+}
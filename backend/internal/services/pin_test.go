@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPinRepository struct {
+	mock.Mock
+}
+
+func (m *MockPinRepository) Store(ctx context.Context, pin *domain.Pin, limit int) error {
+	args := m.Called(ctx, pin, limit)
+	return args.Error(0)
+}
+
+func (m *MockPinRepository) Delete(ctx context.Context, conversationID uuid.UUID, messageID uuid.UUID) error {
+	args := m.Called(ctx, conversationID, messageID)
+	return args.Error(0)
+}
+
+func TestPinService_Pin(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+	message, _ := domain.NewMessage(conversationID, uuid.New(), domain.MessageTypeUser, "hello")
+
+	t.Run("moderator pins a message", func(t *testing.T) {
+		mockPins := new(MockPinRepository)
+		mockMessages := new(MockMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		mockMessageService := new(MockMessageService)
+		mockNotifications := new(MockNotificationService)
+		mockCache := new(MockCacheService)
+		service := NewPinService(mockPins, mockMessages, mockQueries, mockMessageService, mockNotifications, mockCache, 2)
+
+		mockMessages.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("moderator", nil)
+		mockPins.On("Store", mock.Anything, mock.MatchedBy(func(p *domain.Pin) bool {
+			return p.MessageID == message.ID && p.PinnedBy == userID
+		}), 2).Return(nil)
+		mockMessageService.On("Send", mock.Anything, mock.MatchedBy(func(m *domain.Message) bool {
+			return m.Type == domain.MessageTypeSystem && m.ConversationID == conversationID
+		})).Return(readModel.MessageDTO{}, nil)
+		mockCache.On("InvalidateConversation", mock.Anything, conversationID).Return(nil)
+		mockQueries.On("GetConversation", conversationID, userID).Return(readModel.ConversationFullDTO{ID: conversationID, PinnedCount: 2}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.Anything).Return(nil)
+
+		err := service.Pin(ctx, message.ID, userID)
+
+		assert.NoError(t, err)
+		mockPins.AssertExpectations(t)
+		mockMessageService.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("member cannot pin", func(t *testing.T) {
+		mockPins := new(MockPinRepository)
+		mockMessages := new(MockMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		service := NewPinService(mockPins, mockMessages, mockQueries, new(MockMessageService), new(MockNotificationService), new(MockCacheService), 2)
+
+		mockMessages.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("member", nil)
+
+		err := service.Pin(ctx, message.ID, userID)
+
+		assert.ErrorIs(t, err, domain.ErrorInsufficientPermissions)
+		mockPins.AssertNotCalled(t, "Store", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		mockPins := new(MockPinRepository)
+		mockMessages := new(MockMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		service := NewPinService(mockPins, mockMessages, mockQueries, new(MockMessageService), new(MockNotificationService), new(MockCacheService), 2)
+
+		mockMessages.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("admin", nil)
+		mockPins.On("Store", mock.Anything, mock.Anything, 2).Return(domain.ErrorPinLimitReached)
+
+		err := service.Pin(ctx, message.ID, userID)
+
+		assert.ErrorIs(t, err, domain.ErrorPinLimitReached)
+	})
+}
+
+func TestPinService_Unpin(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+	message, _ := domain.NewMessage(conversationID, uuid.New(), domain.MessageTypeUser, "hello")
+
+	t.Run("message not pinned", func(t *testing.T) {
+		mockPins := new(MockPinRepository)
+		mockMessages := new(MockMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		mockMessageService := new(MockMessageService)
+		service := NewPinService(mockPins, mockMessages, mockQueries, mockMessageService, new(MockNotificationService), new(MockCacheService), 2)
+
+		mockMessages.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("GetMemberRole", conversationID, userID).Return("owner", nil)
+		mockPins.On("Delete", mock.Anything, conversationID, message.ID).Return(domain.ErrorMessageNotPinned)
+
+		err := service.Unpin(ctx, message.ID, userID)
+
+		assert.ErrorIs(t, err, domain.ErrorMessageNotPinned)
+		mockMessageService.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+	"GitHub/go-chat/backend/internal/repository"
+	ws "GitHub/go-chat/backend/internal/websocket"
+
+	"github.com/google/uuid"
+)
+
+type PollInput struct {
+	Question       string
+	Options        []string
+	MultipleChoice bool
+	Anonymous      bool
+}
+
+type PollService interface {
+	Create(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, input PollInput) (readModel.MessageDTO, error)
+	Vote(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, optionIDs []uuid.UUID) (readModel.PollDTO, error)
+	Close(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (readModel.PollDTO, error)
+}
+
+type pollService struct {
+	polls         repository.PollRepository
+	messages      repository.MessageRepository
+	queries       readModel.QueriesRepository
+	messageSender MessageService
+	notifications NotificationService
+}
+
+func NewPollService(
+	polls repository.PollRepository,
+	messages repository.MessageRepository,
+	queries readModel.QueriesRepository,
+	messageSender MessageService,
+	notifications NotificationService,
+) PollService {
+	return &pollService{
+		polls:         polls,
+		messages:      messages,
+		queries:       queries,
+		messageSender: messageSender,
+		notifications: notifications,
+	}
+}
+
+func (s *pollService) Create(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, input PollInput) (readModel.MessageDTO, error) {
+	isMember, err := s.queries.IsMember(conversationID, userID)
+	if err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("is member error: %w", err)
+	}
+	if !isMember {
+		return readModel.MessageDTO{}, fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation)
+	}
+
+	poll, err := domain.NewPoll(conversationID, userID, input.Question, input.Options, input.MultipleChoice, input.Anonymous)
+	if err != nil {
+		return readModel.MessageDTO{}, fmt.Errorf("new poll error: %w", err)
+	}
+
+	dto, err := s.messageSender.Send(ctx, domain.NewPollMessage(poll))
+	if err != nil {
+		return dto, fmt.Errorf("send poll message error: %w", err)
+	}
+
+	return dto, nil
+}
+
+func (s *pollService) Vote(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, optionIDs []uuid.UUID) (readModel.PollDTO, error) {
+	poll, err := s.getPoll(ctx, messageID, userID)
+	if err != nil {
+		return readModel.PollDTO{}, err
+	}
+
+	vote, err := poll.Vote(userID, optionIDs)
+	if err != nil {
+		return readModel.PollDTO{}, fmt.Errorf("vote error: %w", err)
+	}
+
+	if err := s.polls.Vote(ctx, vote); err != nil {
+		return readModel.PollDTO{}, fmt.Errorf("store vote error: %w", err)
+	}
+
+	return s.notifyPollUpdated(ctx, poll)
+}
+
+func (s *pollService) Close(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (readModel.PollDTO, error) {
+	poll, err := s.getPoll(ctx, messageID, userID)
+	if err != nil {
+		return readModel.PollDTO{}, err
+	}
+
+	if err := poll.Close(userID, time.Now()); err != nil {
+		return readModel.PollDTO{}, fmt.Errorf("close poll error: %w", err)
+	}
+
+	if err := s.polls.Close(ctx, poll); err != nil {
+		return readModel.PollDTO{}, fmt.Errorf("store closed poll error: %w", err)
+	}
+
+	return s.notifyPollUpdated(ctx, poll)
+}
+
+func (s *pollService) getPoll(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (*domain.Poll, error) {
+	message, err := s.messages.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("get message error: %w", err)
+	}
+
+	isMember, err := s.queries.IsMember(message.ConversationID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("is member error: %w", err)
+	}
+	if !isMember {
+		return nil, fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation)
+	}
+
+	poll := message.Poll()
+	if poll == nil {
+		return nil, domain.ErrorPollNotFound
+	}
+
+	return poll, nil
+}
+
+func (s *pollService) notifyPollUpdated(ctx context.Context, poll *domain.Poll) (readModel.PollDTO, error) {
+	pollDTO, err := s.queries.GetPoll(poll.MessageID)
+	if err != nil {
+		return readModel.PollDTO{}, fmt.Errorf("get poll error: %w", err)
+	}
+
+	if err := s.notifications.Broadcast(ctx, poll.ConversationID, ws.OutgoingNotification{Type: "poll_updated", Payload: pollDTO}); err != nil {
+		return pollDTO, fmt.Errorf("notify error: %w", err)
+	}
+
+	return pollDTO, nil
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+	ws "GitHub/go-chat/backend/internal/websocket"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPollRepository struct {
+	mock.Mock
+}
+
+func (m *MockPollRepository) Vote(ctx context.Context, vote *domain.PollVote) error {
+	args := m.Called(ctx, vote)
+	return args.Error(0)
+}
+
+func (m *MockPollRepository) Close(ctx context.Context, poll *domain.Poll) error {
+	args := m.Called(ctx, poll)
+	return args.Error(0)
+}
+
+func TestPollService_Create(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+	input := PollInput{Question: "Lunch?", Options: []string{"Pizza", "Sushi"}}
+
+	t.Run("sends poll message", func(t *testing.T) {
+		mockQueries := new(MockQueriesRepository)
+		mockMessageService := new(MockMessageService)
+		service := NewPollService(new(MockPollRepository), new(MockMessageRepository), mockQueries, mockMessageService, new(MockNotificationService))
+
+		mockQueries.On("IsMember", conversationID, userID).Return(true, nil)
+		mockMessageService.On("Send", mock.Anything, mock.MatchedBy(func(m *domain.Message) bool {
+			poll := m.Poll()
+			return poll != nil && poll.MessageID == m.ID && poll.Question == "Lunch?" && len(poll.Options) == 2
+		})).Return(readModel.MessageDTO{}, nil)
+
+		_, err := service.Create(ctx, conversationID, userID, input)
+
+		assert.NoError(t, err)
+		mockMessageService.AssertExpectations(t)
+	})
+
+	t.Run("non member cannot create poll", func(t *testing.T) {
+		mockQueries := new(MockQueriesRepository)
+		mockMessageService := new(MockMessageService)
+		service := NewPollService(new(MockPollRepository), new(MockMessageRepository), mockQueries, mockMessageService, new(MockNotificationService))
+
+		mockQueries.On("IsMember", conversationID, userID).Return(false, nil)
+
+		_, err := service.Create(ctx, conversationID, userID, input)
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotInConversation)
+		mockMessageService.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPollService_Vote(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	voterID := uuid.New()
+
+	t.Run("stores vote and broadcasts tally", func(t *testing.T) {
+		poll, _ := domain.NewPoll(conversationID, uuid.New(), "Lunch?", []string{"Pizza", "Sushi"}, false, false)
+		message := domain.NewPollMessage(poll)
+
+		mockPolls := new(MockPollRepository)
+		mockMessages := new(MockMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		mockNotifications := new(MockNotificationService)
+		service := NewPollService(mockPolls, mockMessages, mockQueries, new(MockMessageService), mockNotifications)
+
+		pollDTO := readModel.PollDTO{ID: poll.ID, MessageID: message.ID, TotalVoters: 1}
+
+		mockMessages.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("IsMember", conversationID, voterID).Return(true, nil)
+		mockPolls.On("Vote", mock.Anything, mock.MatchedBy(func(v *domain.PollVote) bool {
+			return v.PollID == poll.ID && v.UserID == voterID && len(v.OptionIDs) == 1 && v.OptionIDs[0] == poll.Options[1].ID
+		})).Return(nil)
+		mockQueries.On("GetPoll", message.ID).Return(pollDTO, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, ws.OutgoingNotification{Type: "poll_updated", Payload: pollDTO}).Return(nil)
+
+		result, err := service.Vote(ctx, message.ID, voterID, []uuid.UUID{poll.Options[1].ID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, pollDTO, result)
+		mockPolls.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("rejects vote on plain message", func(t *testing.T) {
+		message, _ := domain.NewMessage(conversationID, uuid.New(), domain.MessageTypeUser, "hello")
+
+		mockMessages := new(MockMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		service := NewPollService(new(MockPollRepository), mockMessages, mockQueries, new(MockMessageService), new(MockNotificationService))
+
+		mockMessages.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("IsMember", conversationID, voterID).Return(true, nil)
+
+		_, err := service.Vote(ctx, message.ID, voterID, []uuid.UUID{uuid.New()})
+
+		assert.ErrorIs(t, err, domain.ErrorPollNotFound)
+	})
+
+	t.Run("rejects several options on single choice poll", func(t *testing.T) {
+		poll, _ := domain.NewPoll(conversationID, uuid.New(), "Lunch?", []string{"Pizza", "Sushi"}, false, false)
+		message := domain.NewPollMessage(poll)
+
+		mockPolls := new(MockPollRepository)
+		mockMessages := new(MockMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		service := NewPollService(mockPolls, mockMessages, mockQueries, new(MockMessageService), new(MockNotificationService))
+
+		mockMessages.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("IsMember", conversationID, voterID).Return(true, nil)
+
+		_, err := service.Vote(ctx, message.ID, voterID, []uuid.UUID{poll.Options[0].ID, poll.Options[1].ID})
+
+		assert.ErrorIs(t, err, domain.ErrorPollMultipleChoiceOff)
+		mockPolls.AssertNotCalled(t, "Vote", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPollService_Close(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	authorID := uuid.New()
+
+	t.Run("author closes poll", func(t *testing.T) {
+		poll, _ := domain.NewPoll(conversationID, authorID, "Lunch?", []string{"Pizza", "Sushi"}, false, true)
+		message := domain.NewPollMessage(poll)
+
+		mockPolls := new(MockPollRepository)
+		mockMessages := new(MockMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		mockNotifications := new(MockNotificationService)
+		service := NewPollService(mockPolls, mockMessages, mockQueries, new(MockMessageService), mockNotifications)
+
+		mockMessages.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("IsMember", conversationID, authorID).Return(true, nil)
+		mockPolls.On("Close", mock.Anything, mock.MatchedBy(func(p *domain.Poll) bool {
+			return p.ID == poll.ID && p.IsClosed()
+		})).Return(nil)
+		mockQueries.On("GetPoll", message.ID).Return(readModel.PollDTO{ID: poll.ID}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.Anything).Return(nil)
+
+		_, err := service.Close(ctx, message.ID, authorID)
+
+		assert.NoError(t, err)
+		mockPolls.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("other member cannot close poll", func(t *testing.T) {
+		poll, _ := domain.NewPoll(conversationID, authorID, "Lunch?", []string{"Pizza", "Sushi"}, false, false)
+		message := domain.NewPollMessage(poll)
+		memberID := uuid.New()
+
+		mockPolls := new(MockPollRepository)
+		mockMessages := new(MockMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		service := NewPollService(mockPolls, mockMessages, mockQueries, new(MockMessageService), new(MockNotificationService))
+
+		mockMessages.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("IsMember", conversationID, memberID).Return(true, nil)
+
+		_, err := service.Close(ctx, message.ID, memberID)
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotAuthor)
+		mockPolls.AssertNotCalled(t, "Close", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,347 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/repository"
+	ws "GitHub/go-chat/backend/internal/websocket"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PresenceUpdateEvent  = "presence_update"
+	PresenceChangedEvent = "presence_changed"
+)
+
+type PresenceEvent struct {
+	UserID     uuid.UUID  `json:"user_id"`
+	Status     string     `json:"status"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+	ServerID   string     `json:"server_id"`
+}
+
+type PresenceBroker interface {
+	Publish(ctx context.Context, event PresenceEvent) error
+	Subscribe(ctx context.Context) (<-chan PresenceEvent, error)
+	Close() error
+}
+
+type PresenceService interface {
+	Connect(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) error
+	Disconnect(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) error
+	Heartbeat(ctx context.Context, userID uuid.UUID) error
+	SetStatus(ctx context.Context, userID uuid.UUID, clientID uuid.UUID, status domain.PresenceStatus) error
+	Subscribe(ctx context.Context) error
+	Close() error
+}
+
+type presenceService struct {
+	serverID      string
+	presence      repository.PresenceRepository
+	users         repository.UserRepository
+	participants  repository.ParticipantRepository
+	activeClients ws.ActiveClients
+	broker        PresenceBroker
+	sweepInterval time.Duration
+
+	mu      sync.Mutex
+	clients map[uuid.UUID]map[uuid.UUID]domain.PresenceStatus
+
+	deliveredMu sync.Mutex
+	delivered   map[uuid.UUID]domain.PresenceStatus
+}
+
+func NewPresenceService(
+	serverID string,
+	presence repository.PresenceRepository,
+	users repository.UserRepository,
+	participants repository.ParticipantRepository,
+	activeClients ws.ActiveClients,
+	broker PresenceBroker,
+	sweepInterval time.Duration,
+) PresenceService {
+	return &presenceService{
+		serverID:      serverID,
+		presence:      presence,
+		users:         users,
+		participants:  participants,
+		activeClients: activeClients,
+		broker:        broker,
+		sweepInterval: sweepInterval,
+		clients:       make(map[uuid.UUID]map[uuid.UUID]domain.PresenceStatus),
+		delivered:     make(map[uuid.UUID]domain.PresenceStatus),
+	}
+}
+
+func (s *presenceService) Connect(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) error {
+	return s.update(ctx, userID, func(clients map[uuid.UUID]domain.PresenceStatus) {
+		clients[clientID] = domain.PresenceStatusOnline
+	})
+}
+
+func (s *presenceService) Disconnect(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) error {
+	return s.update(ctx, userID, func(clients map[uuid.UUID]domain.PresenceStatus) {
+		delete(clients, clientID)
+	})
+}
+
+func (s *presenceService) SetStatus(ctx context.Context, userID uuid.UUID, clientID uuid.UUID, status domain.PresenceStatus) error {
+	if status == domain.PresenceStatusOffline {
+		return domain.ErrorPresenceStatusNotReportable
+	}
+
+	return s.update(ctx, userID, func(clients map[uuid.UUID]domain.PresenceStatus) {
+		if _, ok := clients[clientID]; ok {
+			clients[clientID] = status
+		}
+	})
+}
+
+func (s *presenceService) Heartbeat(ctx context.Context, userID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clients, ok := s.clients[userID]
+	if !ok {
+		return nil
+	}
+
+	if err := s.presence.SetStatus(ctx, userID, s.serverID, aggregateClientsPresence(clients)); err != nil {
+		return fmt.Errorf("set presence error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *presenceService) update(ctx context.Context, userID uuid.UUID, mutate func(clients map[uuid.UUID]domain.PresenceStatus)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clients, ok := s.clients[userID]
+	if !ok {
+		clients = make(map[uuid.UUID]domain.PresenceStatus)
+	}
+
+	previous := aggregateClientsPresence(clients)
+	mutate(clients)
+	current := aggregateClientsPresence(clients)
+
+	if len(clients) == 0 {
+		delete(s.clients, userID)
+	} else {
+		s.clients[userID] = clients
+	}
+
+	if current == domain.PresenceStatusOffline {
+		if previous == domain.PresenceStatusOffline {
+			return nil
+		}
+
+		return s.disconnectFromServer(ctx, userID)
+	}
+
+	if err := s.presence.SetStatus(ctx, userID, s.serverID, current); err != nil {
+		return fmt.Errorf("set presence error: %w", err)
+	}
+
+	if current == previous {
+		return nil
+	}
+
+	return s.publish(ctx, userID, nil)
+}
+
+func (s *presenceService) disconnectFromServer(ctx context.Context, userID uuid.UUID) error {
+	if err := s.presence.Remove(ctx, userID, s.serverID); err != nil {
+		return fmt.Errorf("remove presence error: %w", err)
+	}
+
+	lastSeenAt := time.Now()
+	if err := s.users.UpdateLastSeen(ctx, userID, lastSeenAt); err != nil {
+		return fmt.Errorf("update last seen error: %w", err)
+	}
+
+	return s.publish(ctx, userID, &lastSeenAt)
+}
+
+func (s *presenceService) publish(ctx context.Context, userID uuid.UUID, lastSeenAt *time.Time) error {
+	statuses, err := s.presence.GetStatuses(ctx, []uuid.UUID{userID})
+	if err != nil {
+		return fmt.Errorf("get presence error: %w", err)
+	}
+
+	status := statuses[userID]
+	event := PresenceEvent{
+		UserID:   userID,
+		Status:   status.String(),
+		ServerID: s.serverID,
+	}
+
+	if status == domain.PresenceStatusOffline {
+		event.LastSeenAt = lastSeenAt
+	}
+
+	if err := s.broker.Publish(ctx, event); err != nil {
+		return fmt.Errorf("publish presence error: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe delivers presence events to local clients and periodically sweeps
+// for users whose entries expired without a disconnect.
+func (s *presenceService) Subscribe(ctx context.Context) error {
+	events, err := s.broker.Subscribe(ctx)
+	if err != nil {
+		return fmt.Errorf("subscribe presence error: %w", err)
+	}
+
+	go s.sweepLoop(ctx)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+
+				s.deliver(ctx, event)
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *presenceService) deliver(ctx context.Context, event PresenceEvent) {
+	status, err := domain.NewPresenceStatus(event.Status)
+	if err != nil {
+		log.Printf("Error parsing presence event for user %s: %v", event.UserID, err)
+		return
+	}
+
+	s.deliveredMu.Lock()
+	if s.delivered[event.UserID] == status {
+		s.deliveredMu.Unlock()
+		return
+	}
+	if status == domain.PresenceStatusOffline {
+		delete(s.delivered, event.UserID)
+	} else {
+		s.delivered[event.UserID] = status
+	}
+	s.deliveredMu.Unlock()
+
+	conversationIDs, err := s.participants.GetConversationIDsByUserID(ctx, event.UserID)
+	if err != nil {
+		log.Printf("Error getting conversations for presence of user %s: %v", event.UserID, err)
+		return
+	}
+
+	s.activeClients.NotifyChannelsClientsExcept(ctx, conversationIDs, event.UserID, ws.OutgoingNotification{
+		Type:   PresenceChangedEvent,
+		UserID: event.UserID,
+		Payload: map[string]interface{}{
+			"user_id":      event.UserID,
+			"status":       event.Status,
+			"last_seen_at": event.LastSeenAt,
+		},
+	})
+}
+
+func (s *presenceService) sweepLoop(ctx context.Context) {
+	ticker := time.NewTicker(s.sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweepExpired(ctx)
+		}
+	}
+}
+
+// sweepExpired derives offline for users announced as online whose entries all
+// expired. A crashed server never disconnects its clients, so nothing else
+// would publish the transition.
+func (s *presenceService) sweepExpired(ctx context.Context) {
+	userIDs := s.remoteOnlineUserIDs()
+	if len(userIDs) == 0 {
+		return
+	}
+
+	statuses, err := s.presence.GetStatuses(ctx, userIDs)
+	if err != nil {
+		log.Printf("Error getting presence for expiry sweep: %v", err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		if statuses[userID] != domain.PresenceStatusOffline {
+			continue
+		}
+
+		claimed, err := s.presence.ClaimExpired(ctx, userID, s.serverID)
+		if err != nil {
+			log.Printf("Error claiming expired presence of user %s: %v", userID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		lastSeenAt := time.Now()
+		if err := s.users.UpdateLastSeen(ctx, userID, lastSeenAt); err != nil {
+			log.Printf("Error updating last seen of user %s: %v", userID, err)
+		}
+
+		if err := s.publish(ctx, userID, &lastSeenAt); err != nil {
+			log.Printf("Error publishing expired presence of user %s: %v", userID, err)
+		}
+	}
+}
+
+func (s *presenceService) remoteOnlineUserIDs() []uuid.UUID {
+	s.deliveredMu.Lock()
+	userIDs := make([]uuid.UUID, 0, len(s.delivered))
+	for userID := range s.delivered {
+		userIDs = append(userIDs, userID)
+	}
+	s.deliveredMu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	remote := userIDs[:0]
+	for _, userID := range userIDs {
+		if _, ok := s.clients[userID]; !ok {
+			remote = append(remote, userID)
+		}
+	}
+
+	return remote
+}
+
+func (s *presenceService) Close() error {
+	return s.broker.Close()
+}
+
+func aggregateClientsPresence(clients map[uuid.UUID]domain.PresenceStatus) domain.PresenceStatus {
+	statuses := make([]domain.PresenceStatus, 0, len(clients))
+	for _, status := range clients {
+		statuses = append(statuses, status)
+	}
+
+	return domain.AggregatePresence(statuses)
+}
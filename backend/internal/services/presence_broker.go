@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	pubsub "GitHub/go-chat/backend/internal/infra/redis"
+)
+
+type presenceBroker struct {
+	broker          MessageBroker
+	presenceChannel string
+	subscription    pubsub.Subscription
+}
+
+func NewPresenceBroker(broker MessageBroker) PresenceBroker {
+	return &presenceBroker{
+		broker:          broker,
+		presenceChannel: pubsub.PresenceChannel,
+	}
+}
+
+func (b *presenceBroker) Publish(ctx context.Context, event PresenceEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("json marshal error: %w", err)
+	}
+
+	if err := b.broker.Publish(ctx, b.presenceChannel, data); err != nil {
+		return fmt.Errorf("broker publish error: %w", err)
+	}
+
+	return nil
+}
+
+func (b *presenceBroker) Subscribe(ctx context.Context) (<-chan PresenceEvent, error) {
+	subscription, err := b.broker.Subscribe(ctx, b.presenceChannel)
+	if err != nil {
+		return nil, fmt.Errorf("broker subscribe error: %w", err)
+	}
+	b.subscription = subscription
+
+	messages := subscription.Messages()
+	events := make(chan PresenceEvent, 100)
+
+	go func() {
+		defer close(events)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var event PresenceEvent
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					log.Printf("Error unmarshaling presence event: %v, payload: %s", err, msg.Payload)
+					continue
+				}
+
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func (b *presenceBroker) Close() error {
+	if b.subscription != nil {
+		if err := b.subscription.Close(); err != nil {
+			log.Printf("Error closing presence subscription: %v", err)
+		}
+	}
+	return nil
+}
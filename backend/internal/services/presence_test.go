@@ -0,0 +1,420 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+	ws "GitHub/go-chat/backend/internal/websocket"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserRepositoryForPresence struct {
+	mock.Mock
+}
+
+func (m *MockUserRepositoryForPresence) Store(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepositoryForPresence) Update(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepositoryForPresence) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepositoryForPresence) FindByUsername(ctx context.Context, username string) (*domain.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepositoryForPresence) UpdateLastSeen(ctx context.Context, id uuid.UUID, lastSeenAt time.Time) error {
+	args := m.Called(ctx, id, lastSeenAt)
+	return args.Error(0)
+}
+
+type fakePresenceRepository struct {
+	mu      sync.Mutex
+	servers map[uuid.UUID]map[string]domain.PresenceStatus
+	claims  map[uuid.UUID]string
+}
+
+func newFakePresenceRepository() *fakePresenceRepository {
+	return &fakePresenceRepository{
+		servers: make(map[uuid.UUID]map[string]domain.PresenceStatus),
+		claims:  make(map[uuid.UUID]string),
+	}
+}
+
+func (r *fakePresenceRepository) SetStatus(ctx context.Context, userID uuid.UUID, serverID string, status domain.PresenceStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.servers[userID] == nil {
+		r.servers[userID] = make(map[string]domain.PresenceStatus)
+	}
+	r.servers[userID][serverID] = status
+	return nil
+}
+
+func (r *fakePresenceRepository) Remove(ctx context.Context, userID uuid.UUID, serverID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.servers[userID], serverID)
+	return nil
+}
+
+func (r *fakePresenceRepository) GetStatuses(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]domain.PresenceStatus, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statuses := make(map[uuid.UUID]domain.PresenceStatus, len(userIDs))
+	for _, userID := range userIDs {
+		var nodeStatuses []domain.PresenceStatus
+		for _, status := range r.servers[userID] {
+			nodeStatuses = append(nodeStatuses, status)
+		}
+		statuses[userID] = domain.AggregatePresence(nodeStatuses)
+	}
+	return statuses, nil
+}
+
+func (r *fakePresenceRepository) ClaimExpired(ctx context.Context, userID uuid.UUID, serverID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.claims[userID]; ok {
+		return false, nil
+	}
+	r.claims[userID] = serverID
+	return true, nil
+}
+
+type fakePresenceBroker struct {
+	mu          sync.Mutex
+	subscribers []chan PresenceEvent
+}
+
+func (b *fakePresenceBroker) Publish(ctx context.Context, event PresenceEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, subscriber := range b.subscribers {
+		subscriber <- event
+	}
+	return nil
+}
+
+func (b *fakePresenceBroker) Subscribe(ctx context.Context) (<-chan PresenceEvent, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	events := make(chan PresenceEvent, 10)
+	b.subscribers = append(b.subscribers, events)
+	return events, nil
+}
+
+func (b *fakePresenceBroker) Close() error {
+	return nil
+}
+
+type presenceNotification struct {
+	channelIDs   []uuid.UUID
+	exceptUserID uuid.UUID
+	notification ws.OutgoingNotification
+}
+
+type fakeActiveClientsForPresence struct {
+	notifications chan presenceNotification
+}
+
+func (f *fakeActiveClientsForPresence) AddClient(c *ws.Client) uuid.UUID {
+	return c.Id
+}
+
+func (f *fakeActiveClientsForPresence) RemoveClient(c *ws.Client) {}
+
+func (f *fakeActiveClientsForPresence) InvalidateMembership(ctx context.Context, userID uuid.UUID) error {
+	return nil
+}
+
+func (f *fakeActiveClientsForPresence) NotifyChannelClients(ctx context.Context, channelID uuid.UUID, notification ws.OutgoingNotification) {
+}
+
+func (f *fakeActiveClientsForPresence) NotifyUserClients(ctx context.Context, userID uuid.UUID, notification ws.OutgoingNotification) {
+}
+
+func (f *fakeActiveClientsForPresence) NotifyChannelClientsExcept(ctx context.Context, channelID uuid.UUID, exceptUserID uuid.UUID, notification ws.OutgoingNotification) {
+}
+
+func (f *fakeActiveClientsForPresence) NotifyChannelsClientsExcept(ctx context.Context, channelIDs []uuid.UUID, exceptUserID uuid.UUID, notification ws.OutgoingNotification) {
+	f.notifications <- presenceNotification{
+		channelIDs:   channelIDs,
+		exceptUserID: exceptUserID,
+		notification: notification,
+	}
+}
+
+func (f *fakeActiveClientsForPresence) IsSubscribed(c *ws.Client, channelID uuid.UUID) bool {
+	return false
+}
+
+func (f *fakeActiveClientsForPresence) Prepare(c *ws.Client, channelID uuid.UUID, notification ws.OutgoingNotification) ws.OutgoingNotification {
+	return notification
+}
+
+func receivePresenceEvent(t *testing.T, events <-chan PresenceEvent) PresenceEvent {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("presence event was not published")
+		return PresenceEvent{}
+	}
+}
+
+func TestPresenceService_ConnectAndDisconnect(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	firstClientID := uuid.New()
+	secondClientID := uuid.New()
+
+	presence := newFakePresenceRepository()
+	broker := &fakePresenceBroker{}
+	users := new(MockUserRepositoryForPresence)
+	service := NewPresenceService("server-1", presence, users, new(MockParticipantRepository), nil, broker, time.Hour)
+
+	events, _ := broker.Subscribe(ctx)
+
+	t.Run("first client goes online", func(t *testing.T) {
+		err := service.Connect(ctx, userID, firstClientID)
+
+		assert.NoError(t, err)
+		event := receivePresenceEvent(t, events)
+		assert.Equal(t, userID, event.UserID)
+		assert.Equal(t, domain.PresenceStatusOnline.String(), event.Status)
+		assert.Equal(t, "server-1", event.ServerID)
+		assert.Nil(t, event.LastSeenAt)
+	})
+
+	t.Run("more clients do not republish", func(t *testing.T) {
+		assert.NoError(t, service.Connect(ctx, userID, secondClientID))
+		assert.NoError(t, service.Disconnect(ctx, userID, firstClientID))
+		assert.NoError(t, service.Heartbeat(ctx, userID))
+
+		assert.Len(t, events, 0)
+	})
+
+	t.Run("last client goes offline", func(t *testing.T) {
+		users.On("UpdateLastSeen", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		err := service.Disconnect(ctx, userID, secondClientID)
+
+		assert.NoError(t, err)
+		event := receivePresenceEvent(t, events)
+		assert.Equal(t, domain.PresenceStatusOffline.String(), event.Status)
+		assert.NotNil(t, event.LastSeenAt)
+		users.AssertExpectations(t)
+
+		statuses, _ := presence.GetStatuses(ctx, []uuid.UUID{userID})
+		assert.Equal(t, domain.PresenceStatusOffline, statuses[userID])
+	})
+}
+
+func TestPresenceService_SetStatus(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	firstClientID := uuid.New()
+	secondClientID := uuid.New()
+
+	broker := &fakePresenceBroker{}
+	service := NewPresenceService("server-1", newFakePresenceRepository(), new(MockUserRepositoryForPresence), new(MockParticipantRepository), nil, broker, time.Hour)
+
+	events, _ := broker.Subscribe(ctx)
+	assert.NoError(t, service.Connect(ctx, userID, firstClientID))
+	receivePresenceEvent(t, events)
+
+	t.Run("away when every client is away", func(t *testing.T) {
+		err := service.SetStatus(ctx, userID, firstClientID, domain.PresenceStatusAway)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PresenceStatusAway.String(), receivePresenceEvent(t, events).Status)
+	})
+
+	t.Run("online when any client is online", func(t *testing.T) {
+		err := service.Connect(ctx, userID, secondClientID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PresenceStatusOnline.String(), receivePresenceEvent(t, events).Status)
+	})
+
+	t.Run("offline cannot be reported", func(t *testing.T) {
+		err := service.SetStatus(ctx, userID, firstClientID, domain.PresenceStatusOffline)
+
+		assert.ErrorIs(t, err, domain.ErrorPresenceStatusNotReportable)
+		assert.Len(t, events, 0)
+	})
+}
+
+func TestPresenceService_MultipleServers(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	presence := newFakePresenceRepository()
+	broker := &fakePresenceBroker{}
+	users := new(MockUserRepositoryForPresence)
+	users.On("UpdateLastSeen", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil)
+
+	first := NewPresenceService("server-1", presence, users, new(MockParticipantRepository), nil, broker, time.Hour)
+	second := NewPresenceService("server-2", presence, users, new(MockParticipantRepository), nil, broker, time.Hour)
+
+	events, _ := broker.Subscribe(ctx)
+
+	firstClientID := uuid.New()
+	secondClientID := uuid.New()
+	assert.NoError(t, first.Connect(ctx, userID, firstClientID))
+	receivePresenceEvent(t, events)
+	assert.NoError(t, second.Connect(ctx, userID, secondClientID))
+	receivePresenceEvent(t, events)
+
+	t.Run("still online while another server has clients", func(t *testing.T) {
+		assert.NoError(t, first.Disconnect(ctx, userID, firstClientID))
+
+		event := receivePresenceEvent(t, events)
+		assert.Equal(t, domain.PresenceStatusOnline.String(), event.Status)
+		assert.Equal(t, "server-1", event.ServerID)
+		assert.Nil(t, event.LastSeenAt)
+	})
+
+	t.Run("offline after the last server disconnects", func(t *testing.T) {
+		assert.NoError(t, second.Disconnect(ctx, userID, secondClientID))
+
+		event := receivePresenceEvent(t, events)
+		assert.Equal(t, domain.PresenceStatusOffline.String(), event.Status)
+		assert.NotNil(t, event.LastSeenAt)
+	})
+}
+
+func TestPresenceService_Subscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	userID := uuid.New()
+	conversationIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	broker := &fakePresenceBroker{}
+	participants := new(MockParticipantRepository)
+	participants.On("GetConversationIDsByUserID", mock.Anything, userID).Return(conversationIDs, nil)
+	activeClients := &fakeActiveClientsForPresence{notifications: make(chan presenceNotification, 10)}
+
+	service := NewPresenceService("server-1", newFakePresenceRepository(), new(MockUserRepositoryForPresence), participants, activeClients, broker, time.Hour)
+	assert.NoError(t, service.Subscribe(ctx))
+
+	receive := func() presenceNotification {
+		select {
+		case n := <-activeClients.notifications:
+			return n
+		case <-time.After(time.Second):
+			t.Fatal("presence change was not delivered")
+			return presenceNotification{}
+		}
+	}
+
+	lastSeenAt := time.Now()
+	assert.NoError(t, broker.Publish(ctx, PresenceEvent{UserID: userID, Status: "online", ServerID: "server-2"}))
+	assert.NoError(t, broker.Publish(ctx, PresenceEvent{UserID: userID, Status: "online", ServerID: "server-3"}))
+	assert.NoError(t, broker.Publish(ctx, PresenceEvent{UserID: userID, Status: "offline", LastSeenAt: &lastSeenAt, ServerID: "server-2"}))
+
+	online := receive()
+	assert.Equal(t, conversationIDs, online.channelIDs)
+	assert.Equal(t, userID, online.exceptUserID)
+	assert.Equal(t, PresenceChangedEvent, online.notification.Type)
+	assert.Equal(t, "online", online.notification.Payload.(map[string]interface{})["status"])
+
+	offline := receive()
+	payload := offline.notification.Payload.(map[string]interface{})
+	assert.Equal(t, "offline", payload["status"])
+	assert.Equal(t, &lastSeenAt, payload["last_seen_at"])
+	assert.Len(t, activeClients.notifications, 0)
+}
+
+func TestPresenceService_SweepExpired(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	userID := uuid.New()
+	conversationIDs := []uuid.UUID{uuid.New()}
+
+	presence := newFakePresenceRepository()
+	broker := &fakePresenceBroker{}
+	participants := new(MockParticipantRepository)
+	participants.On("GetConversationIDsByUserID", mock.Anything, userID).Return(conversationIDs, nil)
+	users := new(MockUserRepositoryForPresence)
+	users.On("UpdateLastSeen", mock.Anything, userID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	crashed := NewPresenceService("server-1", presence, users, participants, nil, broker, time.Hour)
+	firstActiveClients := &fakeActiveClientsForPresence{notifications: make(chan presenceNotification, 10)}
+	first := NewPresenceService("server-2", presence, users, participants, firstActiveClients, broker, time.Hour).(*presenceService)
+	secondActiveClients := &fakeActiveClientsForPresence{notifications: make(chan presenceNotification, 10)}
+	second := NewPresenceService("server-3", presence, users, participants, secondActiveClients, broker, time.Hour).(*presenceService)
+	assert.NoError(t, first.Subscribe(ctx))
+	assert.NoError(t, second.Subscribe(ctx))
+
+	receive := func(activeClients *fakeActiveClientsForPresence) presenceNotification {
+		select {
+		case n := <-activeClients.notifications:
+			return n
+		case <-time.After(time.Second):
+			t.Fatal("presence change was not delivered")
+			return presenceNotification{}
+		}
+	}
+
+	assert.NoError(t, crashed.Connect(ctx, userID, uuid.New()))
+	assert.Equal(t, "online", receive(firstActiveClients).notification.Payload.(map[string]interface{})["status"])
+	assert.Equal(t, "online", receive(secondActiveClients).notification.Payload.(map[string]interface{})["status"])
+
+	t.Run("still online while the entry is fresh", func(t *testing.T) {
+		first.sweepExpired(ctx)
+
+		assert.Len(t, firstActiveClients.notifications, 0)
+		users.AssertNotCalled(t, "UpdateLastSeen", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("offline once the crashed server's entry expires", func(t *testing.T) {
+		presence.mu.Lock()
+		delete(presence.servers[userID], "server-1")
+		presence.mu.Unlock()
+
+		first.sweepExpired(ctx)
+		second.sweepExpired(ctx)
+
+		for _, activeClients := range []*fakeActiveClientsForPresence{firstActiveClients, secondActiveClients} {
+			payload := receive(activeClients).notification.Payload.(map[string]interface{})
+			assert.Equal(t, "offline", payload["status"])
+			assert.NotNil(t, payload["last_seen_at"])
+		}
+		users.AssertExpectations(t)
+		assert.Len(t, firstActiveClients.notifications, 0)
+		assert.Len(t, secondActiveClients.notifications, 0)
+	})
+}
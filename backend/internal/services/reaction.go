@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+	"GitHub/go-chat/backend/internal/repository"
+	ws "GitHub/go-chat/backend/internal/websocket"
+
+	"github.com/google/uuid"
+)
+
+type ReactionService interface {
+	AddReaction(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, emoji string) error
+	RemoveReaction(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, emoji string) error
+}
+
+type reactionService struct {
+	reactions     repository.ReactionRepository
+	messages      repository.MessageRepository
+	queries       readModel.QueriesRepository
+	notifications NotificationService
+}
+
+func NewReactionService(
+	reactions repository.ReactionRepository,
+	messages repository.MessageRepository,
+	queries readModel.QueriesRepository,
+	notifications NotificationService,
+) ReactionService {
+	return &reactionService{
+		reactions:     reactions,
+		messages:      messages,
+		queries:       queries,
+		notifications: notifications,
+	}
+}
+
+func (s *reactionService) AddReaction(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, emoji string) error {
+	reaction, err := domain.NewReaction(messageID, userID, emoji)
+	if err != nil {
+		return fmt.Errorf("new reaction error: %w", err)
+	}
+
+	message, err := s.getMessageForMember(ctx, messageID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.reactions.Add(ctx, reaction); err != nil {
+		return fmt.Errorf("store reaction error: %w", err)
+	}
+
+	return s.notifyReactionUpdated(ctx, message, userID)
+}
+
+func (s *reactionService) RemoveReaction(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, emoji string) error {
+	reaction, err := domain.NewReaction(messageID, userID, emoji)
+	if err != nil {
+		return fmt.Errorf("new reaction error: %w", err)
+	}
+
+	message, err := s.getMessageForMember(ctx, messageID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.reactions.Remove(ctx, reaction); err != nil {
+		return fmt.Errorf("remove reaction error: %w", err)
+	}
+
+	return s.notifyReactionUpdated(ctx, message, userID)
+}
+
+func (s *reactionService) getMessageForMember(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (*domain.Message, error) {
+	message, err := s.messages.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("get message error: %w", err)
+	}
+
+	isMember, err := s.queries.IsMember(message.ConversationID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("is member error: %w", err)
+	}
+	if !isMember {
+		return nil, fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation)
+	}
+
+	return message, nil
+}
+
+func (s *reactionService) notifyReactionUpdated(ctx context.Context, message *domain.Message, userID uuid.UUID) error {
+	reactions, err := s.queries.GetMessageReactions(message.ID)
+	if err != nil {
+		return fmt.Errorf("get reactions error: %w", err)
+	}
+
+	if err := s.notifications.Broadcast(ctx, message.ConversationID, ws.OutgoingNotification{
+		Type:   "reaction_updated",
+		UserID: userID,
+		Payload: map[string]interface{}{
+			"message_id":      message.ID,
+			"conversation_id": message.ConversationID,
+			"reactions":       reactions,
+		},
+	}); err != nil {
+		return fmt.Errorf("notify error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+	ws "GitHub/go-chat/backend/internal/websocket"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReactionRepository struct {
+	mock.Mock
+}
+
+func (m *MockReactionRepository) Add(ctx context.Context, reaction *domain.Reaction) error {
+	args := m.Called(ctx, reaction)
+	return args.Error(0)
+}
+
+func (m *MockReactionRepository) Remove(ctx context.Context, reaction *domain.Reaction) error {
+	args := m.Called(ctx, reaction)
+	return args.Error(0)
+}
+
+func TestReactionService_AddReaction(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+
+	mockReactions := new(MockReactionRepository)
+	mockMessages := new(MockMessageRepository)
+	mockQueries := new(MockQueriesRepository)
+	mockNotifications := new(MockNotificationServiceForMessageTest)
+
+	service := NewReactionService(mockReactions, mockMessages, mockQueries, mockNotifications)
+
+	t.Run("successful add", func(t *testing.T) {
+		message, err := domain.NewMessage(conversationID, uuid.New(), domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+		reactions := []readModel.ReactionDTO{{Emoji: "👍", Count: 1, UserIDs: []uuid.UUID{userID}}}
+
+		mockMessages.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("IsMember", conversationID, userID).Return(true, nil)
+		mockReactions.On("Add", mock.Anything, &domain.Reaction{MessageID: message.ID, UserID: userID, Emoji: "👍"}).Return(nil)
+		mockQueries.On("GetMessageReactions", message.ID).Return(reactions, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			payload, ok := n.Payload.(map[string]interface{})
+			return n.Type == "reaction_updated" && ok && assert.ObjectsAreEqual(reactions, payload["reactions"])
+		})).Return(nil)
+
+		err = service.AddReaction(ctx, message.ID, userID, "👍")
+
+		assert.NoError(t, err)
+		mockMessages.AssertExpectations(t)
+		mockQueries.AssertExpectations(t)
+		mockReactions.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("invalid emoji", func(t *testing.T) {
+		mockMessages.ExpectedCalls = nil
+		mockQueries.ExpectedCalls = nil
+		mockReactions.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+
+		err := service.AddReaction(ctx, uuid.New(), userID, "not an emoji")
+
+		assert.ErrorIs(t, err, domain.ErrorInvalidEmoji)
+	})
+
+	t.Run("not member", func(t *testing.T) {
+		mockMessages.ExpectedCalls = nil
+		mockQueries.ExpectedCalls = nil
+		mockReactions.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		message, err := domain.NewMessage(conversationID, uuid.New(), domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+
+		mockMessages.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("IsMember", conversationID, userID).Return(false, nil)
+
+		err = service.AddReaction(ctx, message.ID, userID, "👍")
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotInConversation)
+	})
+}
+
+func TestReactionService_RemoveReaction(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+
+	mockReactions := new(MockReactionRepository)
+	mockMessages := new(MockMessageRepository)
+	mockQueries := new(MockQueriesRepository)
+	mockNotifications := new(MockNotificationServiceForMessageTest)
+
+	service := NewReactionService(mockReactions, mockMessages, mockQueries, mockNotifications)
+
+	t.Run("successful remove", func(t *testing.T) {
+		message, err := domain.NewMessage(conversationID, uuid.New(), domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+
+		mockMessages.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("IsMember", conversationID, userID).Return(true, nil)
+		mockReactions.On("Remove", mock.Anything, &domain.Reaction{MessageID: message.ID, UserID: userID, Emoji: "👍"}).Return(nil)
+		mockQueries.On("GetMessageReactions", message.ID).Return([]readModel.ReactionDTO{}, nil)
+		mockNotifications.On("Broadcast", mock.Anything, conversationID, mock.MatchedBy(func(n ws.OutgoingNotification) bool {
+			return n.Type == "reaction_updated"
+		})).Return(nil)
+
+		err = service.RemoveReaction(ctx, message.ID, userID, "👍")
+
+		assert.NoError(t, err)
+		mockReactions.AssertExpectations(t)
+		mockNotifications.AssertExpectations(t)
+	})
+
+	t.Run("remove error", func(t *testing.T) {
+		mockMessages.ExpectedCalls = nil
+		mockQueries.ExpectedCalls = nil
+		mockReactions.ExpectedCalls = nil
+		mockNotifications.ExpectedCalls = nil
+		message, err := domain.NewMessage(conversationID, uuid.New(), domain.MessageTypeUser, "Hello")
+		assert.NoError(t, err)
+
+		mockMessages.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockQueries.On("IsMember", conversationID, userID).Return(true, nil)
+		mockReactions.On("Remove", mock.Anything, mock.Anything).Return(assert.AnError)
+
+		err = service.RemoveReaction(ctx, message.ID, userID, "👍")
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -9,54 +9,86 @@ import (
 	pubsub "GitHub/go-chat/backend/internal/infra/redis"
 	ws "GitHub/go-chat/backend/internal/websocket"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 )
 
+type MessageBroker interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+	Subscribe(ctx context.Context, channels ...string) (pubsub.Subscription, error)
+}
+
+type BroadcastReceiver interface {
+	DeliverToConversation(ctx context.Context, conversationID uuid.UUID, notification ws.OutgoingNotification)
+	DeliverToConversationExcept(ctx context.Context, conversationID uuid.UUID, exceptUserID uuid.UUID, notification ws.OutgoingNotification)
+	DeliverToUser(ctx context.Context, userID uuid.UUID, notification ws.OutgoingNotification)
+	InvalidateLocalMembership(ctx context.Context, userID uuid.UUID) error
+}
+
 type RedisBroadcaster interface {
-	PublishNotification(ctx context.Context, notification ws.OutgoingNotification, serverID string) error
+	PublishNotification(ctx context.Context, conversationID uuid.UUID, notification ws.OutgoingNotification) error
+	PublishUserNotification(ctx context.Context, userID uuid.UUID, notification ws.OutgoingNotification) error
+	PublishTyping(ctx context.Context, conversationID uuid.UUID, notification ws.OutgoingNotification) error
 	PublishInvalidate(ctx context.Context, userID uuid.UUID) error
-	Subscribe(ctx context.Context) error
+	Subscribe(ctx context.Context, receiver BroadcastReceiver) error
 	Close() error
 }
 
 type redisBroadcaster struct {
-	redisClient         *redis.Client
+	broker              MessageBroker
+	serverID            string
 	subscriptionChannel string
 	chatChannel         string
 
-	notificationService NotificationService
-	chatPubsub          *redis.PubSub
-	subPubsub           *redis.PubSub
+	subscription pubsub.Subscription
 }
 
-func NewRedisBroadcaster(redisClient *redis.Client, notificationService NotificationService) RedisBroadcaster {
+func NewRedisBroadcaster(broker MessageBroker, serverID string) RedisBroadcaster {
 	return &redisBroadcaster{
-		redisClient:         redisClient,
+		broker:              broker,
+		serverID:            serverID,
 		subscriptionChannel: pubsub.SubscriptionChannel,
 		chatChannel:         pubsub.ChatChannel,
-		notificationService: notificationService,
 	}
 }
 
-func (b *redisBroadcaster) PublishNotification(ctx context.Context, notification ws.OutgoingNotification, serverID string) error {
-	messageID := uuid.New().String()
+func (b *redisBroadcaster) PublishNotification(ctx context.Context, conversationID uuid.UUID, notification ws.OutgoingNotification) error {
+	return b.publishNotification(ctx, BroadcastMessage{
+		Payload:        notification,
+		UserID:         notification.UserID,
+		ConversationID: conversationID,
+	})
+}
+
+func (b *redisBroadcaster) PublishUserNotification(ctx context.Context, userID uuid.UUID, notification ws.OutgoingNotification) error {
+	return b.publishNotification(ctx, BroadcastMessage{
+		Payload:     notification,
+		UserID:      notification.UserID,
+		RecipientID: userID,
+	})
+}
 
-	bMessage := BroadcastMessage{
+// PublishTyping fans out an ephemeral typing event. It skips the event log and
+// is not delivered back to the typing user's own clients.
+func (b *redisBroadcaster) PublishTyping(ctx context.Context, conversationID uuid.UUID, notification ws.OutgoingNotification) error {
+	return b.publishNotification(ctx, BroadcastMessage{
 		Payload:        notification,
 		UserID:         notification.UserID,
-		MessageID:      messageID,
-		ServerID:       serverID,
-		ConversationID: uuid.Nil,
-	}
+		ConversationID: conversationID,
+		ExceptSender:   true,
+	})
+}
+
+func (b *redisBroadcaster) publishNotification(ctx context.Context, bMessage BroadcastMessage) error {
+	bMessage.MessageID = uuid.New().String()
+	bMessage.ServerID = b.serverID
 
 	data, err := json.Marshal(bMessage)
 	if err != nil {
 		return fmt.Errorf("json marshal error: %w", err)
 	}
 
-	if err := b.redisClient.Publish(ctx, b.chatChannel, data).Err(); err != nil {
-		return fmt.Errorf("redis publish error: %w", err)
+	if err := b.broker.Publish(ctx, b.chatChannel, data); err != nil {
+		return fmt.Errorf("broker publish error: %w", err)
 	}
 
 	return nil
@@ -64,8 +96,9 @@ func (b *redisBroadcaster) PublishNotification(ctx context.Context, notification
 
 func (b *redisBroadcaster) PublishInvalidate(ctx context.Context, userID uuid.UUID) error {
 	event := SubscriptionEvent{
-		Action: "invalidate",
-		UserID: userID,
+		Action:   "invalidate",
+		UserID:   userID,
+		ServerID: b.serverID,
 	}
 
 	data, err := json.Marshal(event)
@@ -73,22 +106,21 @@ func (b *redisBroadcaster) PublishInvalidate(ctx context.Context, userID uuid.UU
 		return fmt.Errorf("json marshal error: %w", err)
 	}
 
-	if err := b.redisClient.Publish(ctx, b.subscriptionChannel, data).Err(); err != nil {
-		return fmt.Errorf("redis publish error: %w", err)
+	if err := b.broker.Publish(ctx, b.subscriptionChannel, data); err != nil {
+		return fmt.Errorf("broker publish error: %w", err)
 	}
 
 	return nil
 }
 
-func (b *redisBroadcaster) Subscribe(ctx context.Context) error {
-	chatPubsub := b.redisClient.Subscribe(ctx, b.chatChannel)
-	b.chatPubsub = chatPubsub
-
-	subPubsub := b.redisClient.Subscribe(ctx, b.subscriptionChannel)
-	b.subPubsub = subPubsub
+func (b *redisBroadcaster) Subscribe(ctx context.Context, receiver BroadcastReceiver) error {
+	subscription, err := b.broker.Subscribe(ctx, b.chatChannel, b.subscriptionChannel)
+	if err != nil {
+		return fmt.Errorf("broker subscribe error: %w", err)
+	}
+	b.subscription = subscription
 
-	chatCh := chatPubsub.Channel()
-	subCh := subPubsub.Channel()
+	messages := subscription.Messages()
 
 	go func() {
 		defer func() {
@@ -101,35 +133,16 @@ func (b *redisBroadcaster) Subscribe(ctx context.Context) error {
 			select {
 			case <-ctx.Done():
 				return
-			case msg, ok := <-chatCh:
-				if !ok {
-					return
-				}
-
-				var broadcastMsg BroadcastMessage
-				if err := json.Unmarshal([]byte(msg.Payload), &broadcastMsg); err != nil {
-					log.Printf("Error unmarshaling broadcast message: %v, payload: %s", err, msg.Payload)
-					continue
-				}
-
-				if err := b.notificationService.Broadcast(ctx, broadcastMsg.ConversationID, broadcastMsg.Payload); err != nil {
-					log.Printf("Error broadcasting message: %v", err)
-				}
-			case msg, ok := <-subCh:
+			case msg, ok := <-messages:
 				if !ok {
 					return
 				}
 
-				var event SubscriptionEvent
-				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
-					log.Printf("Error unmarshaling subscription event: %v, payload: %s", err, msg.Payload)
-					continue
-				}
-
-				if event.Action == "invalidate" {
-					if err := b.notificationService.InvalidateMembership(ctx, event.UserID); err != nil {
-						log.Printf("Error invalidating membership: %v", err)
-					}
+				switch msg.Channel {
+				case b.chatChannel:
+					b.handleBroadcastMessage(ctx, receiver, msg.Payload)
+				case b.subscriptionChannel:
+					b.handleSubscriptionEvent(ctx, receiver, msg.Payload)
 				}
 			}
 		}
@@ -138,15 +151,52 @@ func (b *redisBroadcaster) Subscribe(ctx context.Context) error {
 	return nil
 }
 
-func (b *redisBroadcaster) Close() error {
-	if b.chatPubsub != nil {
-		if err := b.chatPubsub.Close(); err != nil {
-			log.Printf("Error closing chat pubsub: %v", err)
+func (b *redisBroadcaster) handleBroadcastMessage(ctx context.Context, receiver BroadcastReceiver, payload string) {
+	var broadcastMsg BroadcastMessage
+	if err := json.Unmarshal([]byte(payload), &broadcastMsg); err != nil {
+		log.Printf("Error unmarshaling broadcast message: %v, payload: %s", err, payload)
+		return
+	}
+
+	if broadcastMsg.ServerID == b.serverID {
+		return
+	}
+
+	if broadcastMsg.RecipientID != uuid.Nil {
+		receiver.DeliverToUser(ctx, broadcastMsg.RecipientID, broadcastMsg.Payload)
+		return
+	}
+
+	if broadcastMsg.ExceptSender {
+		receiver.DeliverToConversationExcept(ctx, broadcastMsg.ConversationID, broadcastMsg.UserID, broadcastMsg.Payload)
+		return
+	}
+
+	receiver.DeliverToConversation(ctx, broadcastMsg.ConversationID, broadcastMsg.Payload)
+}
+
+func (b *redisBroadcaster) handleSubscriptionEvent(ctx context.Context, receiver BroadcastReceiver, payload string) {
+	var event SubscriptionEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		log.Printf("Error unmarshaling subscription event: %v, payload: %s", err, payload)
+		return
+	}
+
+	if event.ServerID == b.serverID {
+		return
+	}
+
+	if event.Action == "invalidate" {
+		if err := receiver.InvalidateLocalMembership(ctx, event.UserID); err != nil {
+			log.Printf("Error invalidating membership: %v", err)
 		}
 	}
-	if b.subPubsub != nil {
-		if err := b.subPubsub.Close(); err != nil {
-			log.Printf("Error closing sub pubsub: %v", err)
+}
+
+func (b *redisBroadcaster) Close() error {
+	if b.subscription != nil {
+		if err := b.subscription.Close(); err != nil {
+			log.Printf("Error closing broadcast subscription: %v", err)
 		}
 	}
 	return nil
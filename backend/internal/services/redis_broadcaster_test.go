@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	pubsub "GitHub/go-chat/backend/internal/infra/redis"
+	ws "GitHub/go-chat/backend/internal/websocket"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSubscription struct {
+	channels map[string]struct{}
+	messages chan pubsub.Message
+}
+
+func (s *fakeSubscription) Messages() <-chan pubsub.Message {
+	return s.messages
+}
+
+func (s *fakeSubscription) Close() error {
+	return nil
+}
+
+type fakeMessageBroker struct {
+	mu            sync.Mutex
+	subscriptions []*fakeSubscription
+}
+
+func (b *fakeMessageBroker) Publish(ctx context.Context, channel string, payload []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, subscription := range b.subscriptions {
+		if _, ok := subscription.channels[channel]; ok {
+			subscription.messages <- pubsub.Message{Channel: channel, Payload: string(payload)}
+		}
+	}
+	return nil
+}
+
+func (b *fakeMessageBroker) Subscribe(ctx context.Context, channels ...string) (pubsub.Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subscription := &fakeSubscription{
+		channels: make(map[string]struct{}, len(channels)),
+		messages: make(chan pubsub.Message, 100),
+	}
+	for _, channel := range channels {
+		subscription.channels[channel] = struct{}{}
+	}
+	b.subscriptions = append(b.subscriptions, subscription)
+	return subscription, nil
+}
+
+func (b *fakeMessageBroker) subscriptionsCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.subscriptions)
+}
+
+type delivery struct {
+	kind         string
+	id           uuid.UUID
+	exceptUserID uuid.UUID
+	notification ws.OutgoingNotification
+}
+
+type recordingActiveClients struct {
+	deliveries chan delivery
+	subscribed map[uuid.UUID]bool
+	muted      map[uuid.UUID]bool
+}
+
+func newRecordingActiveClients() *recordingActiveClients {
+	return &recordingActiveClients{
+		deliveries: make(chan delivery, 100),
+		subscribed: make(map[uuid.UUID]bool),
+		muted:      make(map[uuid.UUID]bool),
+	}
+}
+
+func (r *recordingActiveClients) AddClient(c *ws.Client) uuid.UUID {
+	return c.Id
+}
+
+func (r *recordingActiveClients) RemoveClient(c *ws.Client) {}
+
+func (r *recordingActiveClients) InvalidateMembership(ctx context.Context, userID uuid.UUID) error {
+	r.deliveries <- delivery{kind: "invalidate", id: userID}
+	return nil
+}
+
+func (r *recordingActiveClients) NotifyChannelClients(ctx context.Context, channelID uuid.UUID, notification ws.OutgoingNotification) {
+	r.deliveries <- delivery{kind: "channel", id: channelID, notification: notification}
+}
+
+func (r *recordingActiveClients) NotifyUserClients(ctx context.Context, userID uuid.UUID, notification ws.OutgoingNotification) {
+	r.deliveries <- delivery{kind: "user", id: userID, notification: notification}
+}
+
+func (r *recordingActiveClients) NotifyChannelClientsExcept(ctx context.Context, channelID uuid.UUID, exceptUserID uuid.UUID, notification ws.OutgoingNotification) {
+	r.deliveries <- delivery{kind: "channel_except", id: channelID, exceptUserID: exceptUserID, notification: notification}
+}
+
+func (r *recordingActiveClients) NotifyChannelsClientsExcept(ctx context.Context, channelIDs []uuid.UUID, exceptUserID uuid.UUID, notification ws.OutgoingNotification) {
+}
+
+func (r *recordingActiveClients) IsSubscribed(c *ws.Client, channelID uuid.UUID) bool {
+	return r.subscribed[channelID]
+}
+
+func (r *recordingActiveClients) Prepare(c *ws.Client, channelID uuid.UUID, notification ws.OutgoingNotification) ws.OutgoingNotification {
+	notification.Silent = r.muted[channelID]
+	return notification
+}
+
+func (r *recordingActiveClients) receiveOnce(t *testing.T) delivery {
+	t.Helper()
+
+	var received delivery
+	select {
+	case received = <-r.deliveries:
+	case <-time.After(time.Second):
+		t.Fatal("notification was not delivered")
+	}
+
+	select {
+	case extra := <-r.deliveries:
+		t.Fatalf("notification delivered more than once: %+v", extra)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	return received
+}
+
+func TestNotificationService_CrossServerFanout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := &fakeMessageBroker{}
+	events := newFakeEventLog(100)
+
+	firstClients := newRecordingActiveClients()
+	first := NewNotificationService(ctx, "server-1", firstClients, nil, NewRedisBroadcaster(broker, "server-1"), events)
+	secondClients := newRecordingActiveClients()
+	second := NewNotificationService(ctx, "server-2", secondClients, nil, NewRedisBroadcaster(broker, "server-2"), events)
+
+	go first.Run()
+	go second.Run()
+	defer first.Shutdown()
+	defer second.Shutdown()
+
+	assert.Eventually(t, func() bool { return broker.subscriptionsCount() == 2 }, time.Second, 10*time.Millisecond)
+
+	t.Run("broadcast reaches conversation clients on every server once", func(t *testing.T) {
+		conversationID := uuid.New()
+		notification := ws.OutgoingNotification{Type: "message", UserID: uuid.New(), Payload: "hello"}
+
+		err := first.Broadcast(ctx, conversationID, notification)
+
+		assert.NoError(t, err)
+		for _, clients := range []*recordingActiveClients{firstClients, secondClients} {
+			received := clients.receiveOnce(t)
+			assert.Equal(t, "channel", received.kind)
+			assert.Equal(t, conversationID, received.id)
+			assert.Equal(t, notification.Type, received.notification.Type)
+			assert.Equal(t, notification.UserID, received.notification.UserID)
+			assert.Equal(t, "hello", received.notification.Payload)
+			assert.Equal(t, int64(1), received.notification.Seq)
+			assert.Equal(t, &conversationID, received.notification.ConversationID)
+		}
+	})
+
+	t.Run("user notification reaches the user on every server once", func(t *testing.T) {
+		userID := uuid.New()
+
+		err := second.NotifyUser(ctx, userID, ws.OutgoingNotification{Type: "message_deleted"})
+
+		assert.NoError(t, err)
+		for _, clients := range []*recordingActiveClients{firstClients, secondClients} {
+			received := clients.receiveOnce(t)
+			assert.Equal(t, "user", received.kind)
+			assert.Equal(t, userID, received.id)
+			assert.Equal(t, "message_deleted", received.notification.Type)
+		}
+	})
+
+	t.Run("typing event reaches other servers without the event log", func(t *testing.T) {
+		conversationID := uuid.New()
+		typistID := uuid.New()
+
+		err := first.(*notificationService).broadcaster.PublishTyping(ctx, conversationID, ws.OutgoingNotification{Type: ws.TypingStartedEvent, UserID: typistID})
+
+		assert.NoError(t, err)
+		received := secondClients.receiveOnce(t)
+		assert.Equal(t, "channel_except", received.kind)
+		assert.Equal(t, conversationID, received.id)
+		assert.Equal(t, typistID, received.exceptUserID)
+		assert.Equal(t, ws.TypingStartedEvent, received.notification.Type)
+		assert.Zero(t, received.notification.Seq)
+		assert.Empty(t, firstClients.deliveries)
+	})
+
+	t.Run("membership invalidation reaches every server once", func(t *testing.T) {
+		userID := uuid.New()
+
+		err := first.InvalidateMembership(ctx, userID)
+
+		assert.NoError(t, err)
+		for _, clients := range []*recordingActiveClients{firstClients, secondClients} {
+			received := clients.receiveOnce(t)
+			assert.Equal(t, "invalidate", received.kind)
+			assert.Equal(t, userID, received.id)
+		}
+	})
+}
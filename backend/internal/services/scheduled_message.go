@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+	"GitHub/go-chat/backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type ScheduledMessageService interface {
+	Schedule(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, content string, scheduledAt time.Time) (uuid.UUID, error)
+	Edit(ctx context.Context, id uuid.UUID, userID uuid.UUID, content string, scheduledAt time.Time) error
+	Cancel(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	GetScheduledMessages(userID uuid.UUID, conversationID *uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ScheduledMessageDTO, error)
+}
+
+type scheduledMessageService struct {
+	scheduled repository.ScheduledMessageRepository
+	queries   readModel.QueriesRepository
+}
+
+func NewScheduledMessageService(
+	scheduled repository.ScheduledMessageRepository,
+	queries readModel.QueriesRepository,
+) ScheduledMessageService {
+	return &scheduledMessageService{
+		scheduled: scheduled,
+		queries:   queries,
+	}
+}
+
+func (s *scheduledMessageService) Schedule(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, content string, scheduledAt time.Time) (uuid.UUID, error) {
+	isMember, err := s.queries.IsMember(conversationID, userID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("is member error: %w", err)
+	}
+	if !isMember {
+		return uuid.Nil, fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation)
+	}
+
+	message, err := domain.NewScheduledMessage(conversationID, userID, content, scheduledAt, time.Now())
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("new scheduled message error: %w", err)
+	}
+
+	if err := s.scheduled.Store(ctx, message); err != nil {
+		return uuid.Nil, fmt.Errorf("store scheduled message error: %w", err)
+	}
+
+	return message.ID, nil
+}
+
+func (s *scheduledMessageService) Edit(ctx context.Context, id uuid.UUID, userID uuid.UUID, content string, scheduledAt time.Time) error {
+	message, err := s.scheduled.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get scheduled message error: %w", err)
+	}
+
+	if err := message.Edit(userID, content, scheduledAt, time.Now()); err != nil {
+		return fmt.Errorf("edit scheduled message error: %w", err)
+	}
+
+	if err := s.scheduled.Update(ctx, message); err != nil {
+		return fmt.Errorf("update scheduled message error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *scheduledMessageService) Cancel(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	message, err := s.scheduled.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get scheduled message error: %w", err)
+	}
+
+	if err := message.Cancel(userID); err != nil {
+		return fmt.Errorf("cancel scheduled message error: %w", err)
+	}
+
+	if err := s.scheduled.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete scheduled message error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *scheduledMessageService) GetScheduledMessages(userID uuid.UUID, conversationID *uuid.UUID, paginationInfo readModel.PaginationInfo) ([]readModel.ScheduledMessageDTO, error) {
+	messages, err := s.queries.GetScheduledMessages(userID, conversationID, paginationInfo)
+	if err != nil {
+		return nil, fmt.Errorf("get scheduled messages error: %w", err)
+	}
+
+	return messages, nil
+}
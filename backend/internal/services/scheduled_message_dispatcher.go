@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+	"GitHub/go-chat/backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type ScheduledMessageDispatcher interface {
+	Run()
+	Shutdown()
+}
+
+type scheduledMessageDispatcher struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	scheduled   repository.ScheduledMessageRepository
+	queries     readModel.QueriesRepository
+	messages    MessageService
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+	retryBase   time.Duration
+	retryMax    time.Duration
+}
+
+func NewScheduledMessageDispatcher(
+	ctx context.Context,
+	scheduled repository.ScheduledMessageRepository,
+	queries readModel.QueriesRepository,
+	messages MessageService,
+	interval time.Duration,
+	batchSize int,
+	maxAttempts int,
+	retryBase time.Duration,
+	retryMax time.Duration,
+) ScheduledMessageDispatcher {
+	dispatcherCtx, cancel := context.WithCancel(ctx)
+
+	return &scheduledMessageDispatcher{
+		ctx:         dispatcherCtx,
+		cancel:      cancel,
+		scheduled:   scheduled,
+		queries:     queries,
+		messages:    messages,
+		interval:    interval,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		retryBase:   retryBase,
+		retryMax:    retryMax,
+	}
+}
+
+func (d *scheduledMessageDispatcher) Run() {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.dispatchDue()
+
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *scheduledMessageDispatcher) Shutdown() {
+	d.cancel()
+}
+
+func (d *scheduledMessageDispatcher) dispatchDue() {
+	for {
+		claimed, err := d.scheduled.ClaimDue(d.ctx, time.Now(), d.batchSize, d.retryBase, d.retryMax)
+		if err != nil {
+			log.Printf("Error claiming scheduled messages: %v", err)
+			return
+		}
+
+		for _, scheduled := range claimed {
+			if err := d.dispatch(scheduled); err != nil {
+				log.Printf("Error recording scheduled message %s failure: %v", scheduled.ID, err)
+			}
+		}
+
+		if len(claimed) < d.batchSize || d.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// dispatch hands a claimed message to MessageService.Send and then removes the
+// scheduled row. Claiming already pushed the row's next attempt into the
+// future, so a failure only needs recording. The sent message reuses the
+// scheduled ID: if a previous attempt stored it but did not remove the row,
+// Send reports ErrorMessageAlreadyExists and the row is removed without
+// sending the message twice.
+func (d *scheduledMessageDispatcher) dispatch(scheduled *domain.ScheduledMessage) error {
+	isMember, err := d.queries.IsMember(scheduled.ConversationID, scheduled.UserID)
+	if err != nil {
+		return d.fail(scheduled, fmt.Errorf("is member error: %w", err))
+	}
+	if !isMember {
+		return d.fail(scheduled, fmt.Errorf("user is not member: %w", domain.ErrorUserNotInConversation))
+	}
+
+	dto, err := d.messages.Send(d.ctx, scheduled.Message())
+	switch {
+	case err == nil, errors.Is(err, domain.ErrorMessageAlreadyExists):
+	case dto.ID != uuid.Nil:
+		// The message is stored, only its fanout failed.
+		log.Printf("Error publishing scheduled message %s: %v", scheduled.ID, err)
+	default:
+		return d.fail(scheduled, fmt.Errorf("send message error: %w", err))
+	}
+
+	if err := d.scheduled.Delete(d.ctx, scheduled.ID); err != nil && !errors.Is(err, domain.ErrorScheduledMessageNotFound) {
+		return fmt.Errorf("delete scheduled message error: %w", err)
+	}
+
+	return nil
+}
+
+func (d *scheduledMessageDispatcher) fail(scheduled *domain.ScheduledMessage, err error) error {
+	log.Printf("Error sending scheduled message %s: %v", scheduled.ID, err)
+
+	var failedAt *time.Time
+	permanent := errors.Is(err, domain.ErrorUserNotInConversation) || errors.Is(err, domain.ErrorUserBlocked)
+	if permanent || scheduled.Attempts >= d.maxAttempts {
+		now := time.Now()
+		failedAt = &now
+	}
+
+	return d.scheduled.RecordFailure(d.ctx, scheduled.ID, err.Error(), failedAt)
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"GitHub/go-chat/backend/internal/domain"
+	"GitHub/go-chat/backend/internal/readModel"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockScheduledMessageRepository struct {
+	mock.Mock
+}
+
+func (m *MockScheduledMessageRepository) Store(ctx context.Context, message *domain.ScheduledMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+func (m *MockScheduledMessageRepository) Update(ctx context.Context, message *domain.ScheduledMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+func (m *MockScheduledMessageRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockScheduledMessageRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ScheduledMessage, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScheduledMessage), args.Error(1)
+}
+
+func (m *MockScheduledMessageRepository) ClaimDue(ctx context.Context, now time.Time, limit int, retryBase time.Duration, retryMax time.Duration) ([]*domain.ScheduledMessage, error) {
+	args := m.Called(ctx, now, limit, retryBase, retryMax)
+	return args.Get(0).([]*domain.ScheduledMessage), args.Error(1)
+}
+
+func (m *MockScheduledMessageRepository) RecordFailure(ctx context.Context, id uuid.UUID, reason string, failedAt *time.Time) error {
+	args := m.Called(ctx, id, reason, failedAt)
+	return args.Error(0)
+}
+
+func TestScheduledMessageService_Schedule(t *testing.T) {
+	ctx := context.Background()
+	conversationID := uuid.New()
+	userID := uuid.New()
+	scheduledAt := time.Now().Add(time.Hour)
+
+	t.Run("member schedules a message", func(t *testing.T) {
+		mockScheduled := new(MockScheduledMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		service := NewScheduledMessageService(mockScheduled, mockQueries)
+
+		mockQueries.On("IsMember", conversationID, userID).Return(true, nil)
+		mockScheduled.On("Store", mock.Anything, mock.MatchedBy(func(m *domain.ScheduledMessage) bool {
+			return m.ConversationID == conversationID && m.UserID == userID && m.ScheduledAt.Equal(scheduledAt)
+		})).Return(nil)
+
+		id, err := service.Schedule(ctx, conversationID, userID, "later", scheduledAt)
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, id)
+		mockScheduled.AssertExpectations(t)
+	})
+
+	t.Run("not member", func(t *testing.T) {
+		mockQueries := new(MockQueriesRepository)
+		service := NewScheduledMessageService(new(MockScheduledMessageRepository), mockQueries)
+
+		mockQueries.On("IsMember", conversationID, userID).Return(false, nil)
+
+		_, err := service.Schedule(ctx, conversationID, userID, "later", scheduledAt)
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotInConversation)
+	})
+}
+
+func TestScheduledMessageService_Cancel(t *testing.T) {
+	ctx := context.Background()
+	authorID := uuid.New()
+	message, _ := domain.NewScheduledMessage(uuid.New(), authorID, "later", time.Now().Add(time.Hour), time.Now())
+
+	t.Run("author cancels", func(t *testing.T) {
+		mockScheduled := new(MockScheduledMessageRepository)
+		service := NewScheduledMessageService(mockScheduled, new(MockQueriesRepository))
+
+		mockScheduled.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+		mockScheduled.On("Delete", mock.Anything, message.ID).Return(nil)
+
+		err := service.Cancel(ctx, message.ID, authorID)
+
+		assert.NoError(t, err)
+		mockScheduled.AssertExpectations(t)
+	})
+
+	t.Run("other user cannot cancel", func(t *testing.T) {
+		mockScheduled := new(MockScheduledMessageRepository)
+		service := NewScheduledMessageService(mockScheduled, new(MockQueriesRepository))
+
+		mockScheduled.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+
+		err := service.Cancel(ctx, message.ID, uuid.New())
+
+		assert.ErrorIs(t, err, domain.ErrorUserNotAuthor)
+		mockScheduled.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestScheduledMessageDispatcher_Dispatch(t *testing.T) {
+	now := time.Now()
+	newDispatcher := func(scheduled *MockScheduledMessageRepository, queries *MockQueriesRepository, messages *MockMessageService) *scheduledMessageDispatcher {
+		return NewScheduledMessageDispatcher(context.Background(), scheduled, queries, messages, time.Second, 10, 3, time.Second, time.Minute).(*scheduledMessageDispatcher)
+	}
+
+	t.Run("sends and removes the scheduled row", func(t *testing.T) {
+		scheduled, _ := domain.NewScheduledMessage(uuid.New(), uuid.New(), "later", now.Add(time.Minute), now)
+		mockScheduled := new(MockScheduledMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		mockMessages := new(MockMessageService)
+		dispatcher := newDispatcher(mockScheduled, mockQueries, mockMessages)
+
+		mockQueries.On("IsMember", scheduled.ConversationID, scheduled.UserID).Return(true, nil)
+		mockMessages.On("Send", mock.Anything, mock.MatchedBy(func(m *domain.Message) bool {
+			return m.ID == scheduled.ID && m.ConversationID == scheduled.ConversationID && m.UserID == scheduled.UserID && m.Content.String() == "later"
+		})).Return(readModel.MessageDTO{ID: scheduled.ID}, nil)
+		mockScheduled.On("Delete", mock.Anything, scheduled.ID).Return(nil)
+
+		assert.NoError(t, dispatcher.dispatch(scheduled))
+		mockScheduled.AssertExpectations(t)
+		mockMessages.AssertExpectations(t)
+	})
+
+	t.Run("fanout failure does not resend", func(t *testing.T) {
+		scheduled, _ := domain.NewScheduledMessage(uuid.New(), uuid.New(), "later", now.Add(time.Minute), now)
+		mockScheduled := new(MockScheduledMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		mockMessages := new(MockMessageService)
+		dispatcher := newDispatcher(mockScheduled, mockQueries, mockMessages)
+
+		mockQueries.On("IsMember", scheduled.ConversationID, scheduled.UserID).Return(true, nil)
+		mockMessages.On("Send", mock.Anything, mock.Anything).Return(readModel.MessageDTO{ID: scheduled.ID}, errors.New("redis down"))
+		mockScheduled.On("Delete", mock.Anything, scheduled.ID).Return(nil)
+
+		assert.NoError(t, dispatcher.dispatch(scheduled))
+		mockScheduled.AssertExpectations(t)
+		mockScheduled.AssertNotCalled(t, "RecordFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("already sent message only removes the row", func(t *testing.T) {
+		scheduled, _ := domain.NewScheduledMessage(uuid.New(), uuid.New(), "later", now.Add(time.Minute), now)
+		mockScheduled := new(MockScheduledMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		mockMessages := new(MockMessageService)
+		dispatcher := newDispatcher(mockScheduled, mockQueries, mockMessages)
+
+		mockQueries.On("IsMember", scheduled.ConversationID, scheduled.UserID).Return(true, nil)
+		mockMessages.On("Send", mock.Anything, mock.Anything).Return(readModel.MessageDTO{}, fmt.Errorf("store message error: %w", domain.ErrorMessageAlreadyExists))
+		mockScheduled.On("Delete", mock.Anything, scheduled.ID).Return(nil)
+
+		assert.NoError(t, dispatcher.dispatch(scheduled))
+		mockScheduled.AssertExpectations(t)
+		mockScheduled.AssertNotCalled(t, "RecordFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("dead letters message when author left", func(t *testing.T) {
+		scheduled, _ := domain.NewScheduledMessage(uuid.New(), uuid.New(), "later", now.Add(time.Minute), now)
+		mockScheduled := new(MockScheduledMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		mockMessages := new(MockMessageService)
+		dispatcher := newDispatcher(mockScheduled, mockQueries, mockMessages)
+
+		mockQueries.On("IsMember", scheduled.ConversationID, scheduled.UserID).Return(false, nil)
+		mockScheduled.On("RecordFailure", mock.Anything, scheduled.ID, mock.Anything, mock.MatchedBy(func(failedAt *time.Time) bool {
+			return failedAt != nil
+		})).Return(nil)
+
+		assert.NoError(t, dispatcher.dispatch(scheduled))
+		mockScheduled.AssertExpectations(t)
+		mockScheduled.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("retries on transient error", func(t *testing.T) {
+		scheduled, _ := domain.NewScheduledMessage(uuid.New(), uuid.New(), "later", now.Add(time.Minute), now)
+		scheduled.Attempts = 1
+		mockScheduled := new(MockScheduledMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		mockMessages := new(MockMessageService)
+		dispatcher := newDispatcher(mockScheduled, mockQueries, mockMessages)
+
+		mockQueries.On("IsMember", scheduled.ConversationID, scheduled.UserID).Return(true, nil)
+		mockMessages.On("Send", mock.Anything, mock.Anything).Return(readModel.MessageDTO{}, errors.New("connection reset"))
+		mockScheduled.On("RecordFailure", mock.Anything, scheduled.ID, mock.Anything, (*time.Time)(nil)).Return(nil)
+
+		assert.NoError(t, dispatcher.dispatch(scheduled))
+		mockScheduled.AssertExpectations(t)
+		mockScheduled.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dead letters after max attempts", func(t *testing.T) {
+		scheduled, _ := domain.NewScheduledMessage(uuid.New(), uuid.New(), "later", now.Add(time.Minute), now)
+		scheduled.Attempts = 3
+		mockScheduled := new(MockScheduledMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		dispatcher := newDispatcher(mockScheduled, mockQueries, new(MockMessageService))
+
+		mockQueries.On("IsMember", scheduled.ConversationID, scheduled.UserID).Return(false, errors.New("connection reset"))
+		mockScheduled.On("RecordFailure", mock.Anything, scheduled.ID, mock.Anything, mock.MatchedBy(func(failedAt *time.Time) bool {
+			return failedAt != nil
+		})).Return(nil)
+
+		assert.NoError(t, dispatcher.dispatch(scheduled))
+		mockScheduled.AssertExpectations(t)
+	})
+
+	t.Run("row cancelled while sending is not a failure", func(t *testing.T) {
+		scheduled, _ := domain.NewScheduledMessage(uuid.New(), uuid.New(), "later", now.Add(time.Minute), now)
+		mockScheduled := new(MockScheduledMessageRepository)
+		mockQueries := new(MockQueriesRepository)
+		mockMessages := new(MockMessageService)
+		dispatcher := newDispatcher(mockScheduled, mockQueries, mockMessages)
+
+		mockQueries.On("IsMember", scheduled.ConversationID, scheduled.UserID).Return(true, nil)
+		mockMessages.On("Send", mock.Anything, mock.Anything).Return(readModel.MessageDTO{ID: scheduled.ID}, nil)
+		mockScheduled.On("Delete", mock.Anything, scheduled.ID).Return(domain.ErrorScheduledMessageNotFound)
+
+		assert.NoError(t, dispatcher.dispatch(scheduled))
+		mockScheduled.AssertNotCalled(t, "RecordFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"log"
 	"sync"
+	"time"
 
+	"GitHub/go-chat/backend/internal/domain"
 	"GitHub/go-chat/backend/internal/repository"
 
 	"github.com/google/uuid"
@@ -15,13 +17,23 @@ type ActiveClients interface {
 	RemoveClient(c *Client)
 	InvalidateMembership(ctx context.Context, userID uuid.UUID) error
 	NotifyChannelClients(ctx context.Context, channelID uuid.UUID, notification OutgoingNotification)
+	NotifyUserClients(ctx context.Context, userID uuid.UUID, notification OutgoingNotification)
+	NotifyChannelClientsExcept(ctx context.Context, channelID uuid.UUID, exceptUserID uuid.UUID, notification OutgoingNotification)
+	NotifyChannelsClientsExcept(ctx context.Context, channelIDs []uuid.UUID, exceptUserID uuid.UUID, notification OutgoingNotification)
+	IsSubscribed(c *Client, channelID uuid.UUID) bool
+	Prepare(c *Client, channelID uuid.UUID, notification OutgoingNotification) OutgoingNotification
+}
+
+var attentionEvents = map[string]bool{
+	"message":      true,
+	"thread_reply": true,
 }
 
 type activeClients struct {
 	mu               sync.RWMutex
 	byUserID         map[uuid.UUID]map[*Client]struct{}
 	byChannelID      map[uuid.UUID]map[*Client]struct{}
-	byClientChannels map[*Client]map[uuid.UUID]struct{}
+	byClientChannels map[*Client]map[uuid.UUID]domain.NotificationPreferences
 	participants     repository.ParticipantRepository
 }
 
@@ -29,17 +41,17 @@ func NewActiveClients(ctx context.Context, participants repository.ParticipantRe
 	return &activeClients{
 		byUserID:         make(map[uuid.UUID]map[*Client]struct{}),
 		byChannelID:      make(map[uuid.UUID]map[*Client]struct{}),
-		byClientChannels: make(map[*Client]map[uuid.UUID]struct{}),
+		byClientChannels: make(map[*Client]map[uuid.UUID]domain.NotificationPreferences),
 		participants:     participants,
 	}
 }
 
 func (ac *activeClients) AddClient(c *Client) uuid.UUID {
-	var conversationIDs []uuid.UUID
+	var conversations map[uuid.UUID]domain.NotificationPreferences
 	if ac.participants != nil {
-		ids, err := ac.participants.GetConversationIDsByUserID(context.Background(), c.UserID)
+		preferences, err := ac.participants.GetNotificationPreferencesByUserID(context.Background(), c.UserID)
 		if err == nil {
-			conversationIDs = ids
+			conversations = preferences
 		}
 	}
 
@@ -55,16 +67,16 @@ func (ac *activeClients) AddClient(c *Client) uuid.UUID {
 
 	channelIDs := ac.byClientChannels[c]
 	if channelIDs == nil {
-		channelIDs = make(map[uuid.UUID]struct{})
+		channelIDs = make(map[uuid.UUID]domain.NotificationPreferences)
 		ac.byClientChannels[c] = channelIDs
 	}
 
-	for _, conversationID := range conversationIDs {
+	for conversationID, preferences := range conversations {
 		if _, exists := ac.byChannelID[conversationID]; !exists {
 			ac.byChannelID[conversationID] = make(map[*Client]struct{})
 		}
 		ac.byChannelID[conversationID][c] = struct{}{}
-		channelIDs[conversationID] = struct{}{}
+		channelIDs[conversationID] = preferences
 	}
 
 	return c.Id
@@ -74,7 +86,7 @@ func (ac *activeClients) RemoveClient(c *Client) {
 	ac.mu.Lock()
 	defer ac.mu.Unlock()
 
-	close(c.sendChannel)
+	c.Close()
 
 	if userClients, exists := ac.byUserID[c.UserID]; exists {
 		delete(userClients, c)
@@ -110,16 +122,11 @@ func (ac *activeClients) InvalidateMembership(ctx context.Context, userID uuid.U
 	}
 	ac.mu.RUnlock()
 
-	conversationIDs, err := ac.participants.GetConversationIDsByUserID(ctx, userID)
+	desiredChannels, err := ac.participants.GetNotificationPreferencesByUserID(ctx, userID)
 	if err != nil {
 		return err
 	}
 
-	desiredChannels := make(map[uuid.UUID]struct{}, len(conversationIDs))
-	for _, conversationID := range conversationIDs {
-		desiredChannels[conversationID] = struct{}{}
-	}
-
 	ac.mu.Lock()
 	defer ac.mu.Unlock()
 
@@ -135,7 +142,7 @@ func (ac *activeClients) InvalidateMembership(ctx context.Context, userID uuid.U
 
 		currentChannels := ac.byClientChannels[client]
 		if currentChannels == nil {
-			currentChannels = make(map[uuid.UUID]struct{})
+			currentChannels = make(map[uuid.UUID]domain.NotificationPreferences)
 			ac.byClientChannels[client] = currentChannels
 		}
 
@@ -152,15 +159,12 @@ func (ac *activeClients) InvalidateMembership(ctx context.Context, userID uuid.U
 			delete(currentChannels, channelID)
 		}
 
-		for channelID := range desiredChannels {
-			if _, exists := currentChannels[channelID]; exists {
-				continue
-			}
+		for channelID, preferences := range desiredChannels {
+			currentChannels[channelID] = preferences
 			if _, exists := ac.byChannelID[channelID]; !exists {
 				ac.byChannelID[channelID] = make(map[*Client]struct{})
 			}
 			ac.byChannelID[channelID][client] = struct{}{}
-			currentChannels[channelID] = struct{}{}
 		}
 	}
 
@@ -177,9 +181,101 @@ func (ac *activeClients) NotifyChannelClients(ctx context.Context, channelID uui
 
 	defer ac.mu.RUnlock()
 
+	now := time.Now()
+	for client := range clients {
+		if err := client.SendNotification(ac.prepare(client, channelID, notification, now)); err != nil {
+			log.Printf("Error sending notification to client %s: %v", client.Id, err)
+		}
+	}
+}
+
+func (ac *activeClients) NotifyUserClients(ctx context.Context, userID uuid.UUID, notification OutgoingNotification) {
+	ac.mu.RLock()
+	clients, exists := ac.byUserID[userID]
+	if !exists {
+		ac.mu.RUnlock()
+		return
+	}
+
+	defer ac.mu.RUnlock()
+
 	for client := range clients {
 		if err := client.SendNotification(notification); err != nil {
 			log.Printf("Error sending notification to client %s: %v", client.Id, err)
 		}
 	}
 }
+
+func (ac *activeClients) NotifyChannelClientsExcept(ctx context.Context, channelID uuid.UUID, exceptUserID uuid.UUID, notification OutgoingNotification) {
+	ac.mu.RLock()
+	clients, exists := ac.byChannelID[channelID]
+	if !exists {
+		ac.mu.RUnlock()
+		return
+	}
+
+	defer ac.mu.RUnlock()
+
+	now := time.Now()
+	for client := range clients {
+		if client.UserID == exceptUserID {
+			continue
+		}
+		if err := client.SendNotification(ac.prepare(client, channelID, notification, now)); err != nil {
+			log.Printf("Error sending notification to client %s: %v", client.Id, err)
+		}
+	}
+}
+
+func (ac *activeClients) NotifyChannelsClientsExcept(ctx context.Context, channelIDs []uuid.UUID, exceptUserID uuid.UUID, notification OutgoingNotification) {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+
+	notified := make(map[*Client]struct{})
+	for _, channelID := range channelIDs {
+		for client := range ac.byChannelID[channelID] {
+			if client.UserID == exceptUserID {
+				continue
+			}
+			if _, ok := notified[client]; ok {
+				continue
+			}
+			notified[client] = struct{}{}
+
+			if err := client.SendNotification(notification); err != nil {
+				log.Printf("Error sending notification to client %s: %v", client.Id, err)
+			}
+		}
+	}
+}
+
+func (ac *activeClients) IsSubscribed(c *Client, channelID uuid.UUID) bool {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+
+	_, subscribed := ac.byClientChannels[c][channelID]
+	return subscribed
+}
+
+// Prepare applies the client's notification preferences for the channel, the
+// same way live delivery does, to an event sent outside of it such as a replay.
+func (ac *activeClients) Prepare(c *Client, channelID uuid.UUID, notification OutgoingNotification) OutgoingNotification {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+
+	return ac.prepare(c, channelID, notification, time.Now())
+}
+
+func (ac *activeClients) prepare(c *Client, channelID uuid.UUID, notification OutgoingNotification, now time.Time) OutgoingNotification {
+	if !attentionEvents[notification.Type] {
+		return notification
+	}
+
+	preferences, exists := ac.byClientChannels[c][channelID]
+	if !exists {
+		return notification
+	}
+
+	notification.Silent = !preferences.ShouldAlert(notification.Mentions(c.UserID), now)
+	return notification
+}
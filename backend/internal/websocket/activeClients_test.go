@@ -3,6 +3,7 @@ package ws
 import (
 	"context"
 	"testing"
+	"time"
 
 	"GitHub/go-chat/backend/internal/domain"
 
@@ -12,6 +13,7 @@ import (
 
 type mockParticipantRepository struct {
 	conversationIDs map[uuid.UUID][]uuid.UUID
+	preferences     map[uuid.UUID]domain.NotificationPreferences
 }
 
 func (m *mockParticipantRepository) Store(ctx context.Context, participant *domain.Participant) error {
@@ -34,6 +36,30 @@ func (m *mockParticipantRepository) GetConversationIDsByUserID(ctx context.Conte
 	return m.conversationIDs[userID], nil
 }
 
+func (m *mockParticipantRepository) MarkRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, messageID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (m *mockParticipantRepository) UpdateRole(ctx context.Context, participant *domain.Participant) error {
+	return nil
+}
+
+func (m *mockParticipantRepository) UpdateNotificationPreferences(ctx context.Context, participant *domain.Participant) error {
+	return nil
+}
+
+func (m *mockParticipantRepository) GetNotificationPreferencesByUserID(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]domain.NotificationPreferences, error) {
+	preferences := make(map[uuid.UUID]domain.NotificationPreferences)
+	for _, conversationID := range m.conversationIDs[userID] {
+		if conversationPreferences, ok := m.preferences[conversationID]; ok {
+			preferences[conversationID] = conversationPreferences
+			continue
+		}
+		preferences[conversationID] = domain.DefaultNotificationPreferences()
+	}
+	return preferences, nil
+}
+
 func TestNewActiveClients(t *testing.T) {
 	ac := NewActiveClients(context.Background(), nil)
 
@@ -172,6 +198,82 @@ func TestActiveClients_MultipleClientsSameChannel(t *testing.T) {
 	assert.Len(t, clients, 2)
 }
 
+func TestActiveClients_NotifyChannelsClientsExcept(t *testing.T) {
+	channelID1 := uuid.New()
+	channelID2 := uuid.New()
+	senderID := uuid.New()
+	receiverID := uuid.New()
+	mockRepo := &mockParticipantRepository{
+		conversationIDs: map[uuid.UUID][]uuid.UUID{
+			senderID:   {channelID1, channelID2},
+			receiverID: {channelID1, channelID2},
+		},
+	}
+	ac := NewActiveClients(context.Background(), mockRepo)
+
+	sender := newBenchmarkClient(senderID)
+	receiver := newBenchmarkClient(receiverID)
+	ac.AddClient(sender)
+	ac.AddClient(receiver)
+
+	ac.NotifyChannelsClientsExcept(context.Background(), []uuid.UUID{channelID1, channelID2}, senderID, OutgoingNotification{Type: "test"})
+
+	assert.Len(t, sender.sendChannel, 0)
+	assert.Len(t, receiver.sendChannel, 1)
+}
+
+func TestActiveClients_NotifyChannelClients_RespectsPreferences(t *testing.T) {
+	channelID := uuid.New()
+	userID := uuid.New()
+	mutedUntil := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name         string
+		preferences  domain.NotificationPreferences
+		notification OutgoingNotification
+		silent       bool
+	}{
+		{"all", domain.DefaultNotificationPreferences(), OutgoingNotification{Type: "message"}, false},
+		{"muted message", domain.NotificationPreferences{Level: domain.NotificationLevelAll, MutedUntil: &mutedUntil}, OutgoingNotification{Type: "message"}, true},
+		{"muted sync event", domain.NotificationPreferences{Level: domain.NotificationLevelAll, MutedUntil: &mutedUntil}, OutgoingNotification{Type: "message_edited"}, false},
+		{"mentions only", domain.NotificationPreferences{Level: domain.NotificationLevelMentions}, OutgoingNotification{Type: "thread_reply"}, true},
+		{"mentions only when mentioned", domain.NotificationPreferences{Level: domain.NotificationLevelMentions}, OutgoingNotification{Type: "message", MentionedUserIDs: []uuid.UUID{userID}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ac := NewActiveClients(context.Background(), &mockParticipantRepository{
+				conversationIDs: map[uuid.UUID][]uuid.UUID{userID: {channelID}},
+				preferences:     map[uuid.UUID]domain.NotificationPreferences{channelID: tt.preferences},
+			})
+			client := newBenchmarkClient(userID)
+			ac.AddClient(client)
+
+			ac.NotifyChannelClients(context.Background(), channelID, tt.notification)
+
+			assert.Len(t, client.sendChannel, 1)
+			delivered := <-client.sendChannel
+			assert.Equal(t, tt.silent, delivered.Silent)
+		})
+	}
+}
+
+func TestActiveClients_Prepare(t *testing.T) {
+	channelID := uuid.New()
+	userID := uuid.New()
+	mutedUntil := time.Now().Add(time.Hour)
+	ac := NewActiveClients(context.Background(), &mockParticipantRepository{
+		conversationIDs: map[uuid.UUID][]uuid.UUID{userID: {channelID}},
+		preferences:     map[uuid.UUID]domain.NotificationPreferences{channelID: {Level: domain.NotificationLevelAll, MutedUntil: &mutedUntil}},
+	})
+	client := newBenchmarkClient(userID)
+	ac.AddClient(client)
+
+	assert.True(t, ac.Prepare(client, channelID, OutgoingNotification{Type: "message"}).Silent)
+	assert.False(t, ac.Prepare(client, channelID, OutgoingNotification{Type: "message_edited"}).Silent)
+	assert.False(t, ac.Prepare(client, uuid.New(), OutgoingNotification{Type: "message"}).Silent)
+}
+
 func newBenchmarkActiveClients(conversationIDs map[uuid.UUID][]uuid.UUID) *activeClients {
 	mockRepo := &mockParticipantRepository{
 		conversationIDs: conversationIDs,
@@ -4,16 +4,32 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
 
+const ResyncRequiredEvent = "resync_required"
+
 type OutgoingNotification struct {
-	Type    string      `json:"type"`
-	UserID  uuid.UUID   `json:"user_id"`
-	Payload interface{} `json:"data"`
+	Type             string      `json:"type"`
+	UserID           uuid.UUID   `json:"user_id"`
+	Payload          interface{} `json:"data"`
+	ConversationID   *uuid.UUID  `json:"conversation_id,omitempty"`
+	Seq              int64       `json:"seq,omitempty"`
+	MentionedUserIDs []uuid.UUID `json:"mentioned_user_ids,omitempty"`
+	Silent           bool        `json:"silent,omitempty"`
+}
+
+func (n OutgoingNotification) Mentions(userID uuid.UUID) bool {
+	for _, mentionedUserID := range n.MentionedUserIDs {
+		if mentionedUserID == userID {
+			return true
+		}
+	}
+	return false
 }
 
 type NotificationEvent struct {
@@ -26,10 +42,14 @@ type BatchOutgoingNotification struct {
 	Events []NotificationEvent `json:"events"`
 }
 
+const HeartbeatEvent = "heartbeat"
+
 type IncomingNotification struct {
-	Type   string
-	Data   json.RawMessage
-	UserID uuid.UUID
+	Type     string          `json:"type"`
+	Data     json.RawMessage `json:"data"`
+	UserID   uuid.UUID       `json:"-"`
+	ClientID uuid.UUID       `json:"-"`
+	client   *Client
 }
 
 type connectionOptions struct {
@@ -45,16 +65,23 @@ type Client struct {
 	connection        *websocket.Conn
 	sendChannel       chan OutgoingNotification
 	unregisterChannel chan *Client
+	incomingChannel   chan IncomingNotification
 	connectionOptions connectionOptions
+
+	mu        sync.Mutex
+	closed    bool
+	replaying bool
+	pending   []OutgoingNotification
 }
 
-func NewClient(conn *websocket.Conn, unregisterChannel chan *Client, userID uuid.UUID) *Client {
+func NewClient(conn *websocket.Conn, unregisterChannel chan *Client, incomingChannel chan IncomingNotification, userID uuid.UUID) *Client {
 	return &Client{
 		Id:                uuid.New(),
 		UserID:            userID,
 		connection:        conn,
 		sendChannel:       make(chan OutgoingNotification, SendChannelSize),
 		unregisterChannel: unregisterChannel,
+		incomingChannel:   incomingChannel,
 		connectionOptions: connectionOptions{
 			writeWait:      WriteWait,
 			pongWait:       PongWait,
@@ -82,7 +109,7 @@ func (c *Client) ReadPump() {
 	})
 
 	for {
-		_, _, err := c.connection.ReadMessage()
+		_, data, err := c.connection.ReadMessage()
 
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
@@ -90,6 +117,42 @@ func (c *Client) ReadPump() {
 			}
 			break
 		}
+
+		c.handleIncoming(data)
+	}
+}
+
+func (c *Client) handleIncoming(data []byte) {
+	if c.incomingChannel == nil {
+		return
+	}
+
+	var notification IncomingNotification
+	if err := json.Unmarshal(data, &notification); err != nil {
+		log.Printf("Error unmarshaling incoming message from client %s: %v", c.Id, err)
+		return
+	}
+
+	c.pushIncoming(notification)
+}
+
+func (c *Client) heartbeat() {
+	if c.incomingChannel == nil {
+		return
+	}
+
+	c.pushIncoming(IncomingNotification{Type: HeartbeatEvent})
+}
+
+func (c *Client) pushIncoming(notification IncomingNotification) {
+	notification.UserID = c.UserID
+	notification.ClientID = c.Id
+	notification.client = c
+
+	select {
+	case c.incomingChannel <- notification:
+	default:
+		log.Printf("Incoming channel full, dropping %s from client %s", notification.Type, c.Id)
 	}
 }
 
@@ -127,11 +190,32 @@ func (c *Client) WritePump() {
 			if err := c.connection.WriteMessage(websocket.PingMessage, nil); err != nil {
 				log.Println(err)
 			}
+
+			c.heartbeat()
 		}
 	}
 }
 
 func (c *Client) SendNotification(notification OutgoingNotification) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return fmt.Errorf("client %s is closed", c.Id)
+	}
+
+	if c.replaying {
+		if len(c.pending) >= SendChannelSize {
+			return fmt.Errorf("pending queue full for client %s", c.Id)
+		}
+		c.pending = append(c.pending, notification)
+		return nil
+	}
+
+	return c.send(notification)
+}
+
+func (c *Client) send(notification OutgoingNotification) error {
 	select {
 	case c.sendChannel <- notification:
 		return nil
@@ -139,3 +223,59 @@ func (c *Client) SendNotification(notification OutgoingNotification) error {
 		return fmt.Errorf("send channel full for client %s", c.Id)
 	}
 }
+
+func (c *Client) BeginReplay() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.replaying = true
+}
+
+func (c *Client) FinishReplay(replayed []OutgoingNotification) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pending := c.pending
+	c.pending = nil
+	c.replaying = false
+
+	if c.closed {
+		return
+	}
+
+	replayedSeqs := make(map[uuid.UUID]int64)
+	for _, notification := range replayed {
+		if notification.ConversationID != nil && notification.Seq > replayedSeqs[*notification.ConversationID] {
+			replayedSeqs[*notification.ConversationID] = notification.Seq
+		}
+	}
+
+	for _, notification := range pending {
+		if notification.ConversationID != nil && notification.Seq > 0 && notification.Seq <= replayedSeqs[*notification.ConversationID] {
+			continue
+		}
+		replayed = append(replayed, notification)
+	}
+
+	for _, notification := range replayed {
+		if err := c.send(notification); err != nil {
+			log.Printf("Error replaying notification to client %s, closing connection: %v", c.Id, err)
+			if c.connection != nil {
+				_ = c.connection.Close()
+			}
+			return
+		}
+	}
+}
+
+func (c *Client) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	c.closed = true
+	close(c.sendChannel)
+}
@@ -11,7 +11,7 @@ func TestNewClient(t *testing.T) {
 	unregisterChannel := make(chan *Client)
 	userID := uuid.New()
 
-	client := NewClient(nil, unregisterChannel, userID)
+	client := NewClient(nil, unregisterChannel, nil, userID)
 
 	assert.NotNil(t, client)
 	assert.NotEqual(t, uuid.Nil, client.Id)
@@ -24,7 +24,7 @@ func TestClient_SendNotification(t *testing.T) {
 	unregisterChannel := make(chan *Client)
 	userID := uuid.New()
 
-	client := NewClient(nil, unregisterChannel, userID)
+	client := NewClient(nil, unregisterChannel, nil, userID)
 
 	notification := OutgoingNotification{
 		Type:    "test",
@@ -46,7 +46,7 @@ func TestClient_SendNotification_Multiple(t *testing.T) {
 	unregisterChannel := make(chan *Client)
 	userID := uuid.New()
 
-	client := NewClient(nil, unregisterChannel, userID)
+	client := NewClient(nil, unregisterChannel, nil, userID)
 
 	for i := 0; i < 5; i++ {
 		notification := OutgoingNotification{
@@ -65,10 +65,75 @@ func TestClient_ConnectionOptions(t *testing.T) {
 	unregisterChannel := make(chan *Client)
 	userID := uuid.New()
 
-	client := NewClient(nil, unregisterChannel, userID)
+	client := NewClient(nil, unregisterChannel, nil, userID)
 
 	assert.Equal(t, WriteWait, client.connectionOptions.writeWait)
 	assert.Equal(t, PongWait, client.connectionOptions.pongWait)
 	assert.Equal(t, PingPeriod, client.connectionOptions.pingPeriod)
 	assert.Equal(t, int64(MaxMessageSize), client.connectionOptions.maxMessageSize)
 }
+
+func TestClient_HandleIncoming(t *testing.T) {
+	incomingChannel := make(chan IncomingNotification, 1)
+	userID := uuid.New()
+
+	client := NewClient(nil, nil, incomingChannel, userID)
+
+	client.handleIncoming([]byte(`{"type":"typing_started","data":{"conversation_id":"` + uuid.NewString() + `"},"UserID":"` + uuid.NewString() + `"}`))
+	client.handleIncoming([]byte(`not json`))
+
+	assert.Len(t, incomingChannel, 1)
+	received := <-incomingChannel
+	assert.Equal(t, TypingStartedEvent, received.Type)
+	assert.Equal(t, userID, received.UserID)
+	assert.Equal(t, client.Id, received.ClientID)
+	assert.Equal(t, client, received.client)
+}
+
+func TestClient_Heartbeat(t *testing.T) {
+	incomingChannel := make(chan IncomingNotification, 1)
+	userID := uuid.New()
+
+	client := NewClient(nil, nil, incomingChannel, userID)
+
+	client.heartbeat()
+
+	received := <-incomingChannel
+	assert.Equal(t, HeartbeatEvent, received.Type)
+	assert.Equal(t, userID, received.UserID)
+	assert.Equal(t, client.Id, received.ClientID)
+}
+
+func TestClient_Replay(t *testing.T) {
+	conversationID := uuid.New()
+	client := NewClient(nil, nil, nil, uuid.New())
+
+	client.BeginReplay()
+	assert.NoError(t, client.SendNotification(OutgoingNotification{Type: "message", ConversationID: &conversationID, Seq: 2}))
+	assert.NoError(t, client.SendNotification(OutgoingNotification{Type: "message", ConversationID: &conversationID, Seq: 3}))
+	assert.NoError(t, client.SendNotification(OutgoingNotification{Type: "presence_changed"}))
+	assert.Len(t, client.sendChannel, 0)
+
+	client.FinishReplay([]OutgoingNotification{
+		{Type: "message", ConversationID: &conversationID, Seq: 1},
+		{Type: "message", ConversationID: &conversationID, Seq: 2},
+	})
+
+	assert.Len(t, client.sendChannel, 4)
+	assert.Equal(t, int64(1), (<-client.sendChannel).Seq)
+	assert.Equal(t, int64(2), (<-client.sendChannel).Seq)
+	assert.Equal(t, int64(3), (<-client.sendChannel).Seq)
+	assert.Equal(t, "presence_changed", (<-client.sendChannel).Type)
+
+	assert.NoError(t, client.SendNotification(OutgoingNotification{Type: "message", ConversationID: &conversationID, Seq: 4}))
+	assert.Len(t, client.sendChannel, 1)
+}
+
+func TestClient_Close(t *testing.T) {
+	client := NewClient(nil, nil, nil, uuid.New())
+
+	client.Close()
+	client.Close()
+
+	assert.Error(t, client.SendNotification(OutgoingNotification{Type: "message"}))
+}
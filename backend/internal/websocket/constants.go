@@ -8,4 +8,8 @@ const (
 	PingPeriod      = (60 * time.Second * 9) / 10
 	MaxMessageSize  = 512
 	SendChannelSize = 1024
+	TypingTimeout   = 6 * time.Second
+	TypingQueueSize = 1000
+	PresenceTTL     = 2 * PingPeriod
+	ReplayLimit     = SendChannelSize / 2
 )
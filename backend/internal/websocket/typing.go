@@ -0,0 +1,150 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TypingStartedEvent = "typing_started"
+	TypingStoppedEvent = "typing_stopped"
+)
+
+type typingEvent struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+}
+
+// TypingPublisher fans typing events out to clients connected to other servers.
+type TypingPublisher interface {
+	PublishTyping(ctx context.Context, conversationID uuid.UUID, notification OutgoingNotification) error
+}
+
+type typingKey struct {
+	conversationID uuid.UUID
+	userID         uuid.UUID
+}
+
+type typingPublication struct {
+	conversationID uuid.UUID
+	notification   OutgoingNotification
+}
+
+type TypingIndicators struct {
+	mu        sync.Mutex
+	clients   ActiveClients
+	publisher TypingPublisher
+	ttl       time.Duration
+	active    map[typingKey]*time.Timer
+	publishes chan typingPublication
+}
+
+func NewTypingIndicators(clients ActiveClients, publisher TypingPublisher, ttl time.Duration) *TypingIndicators {
+	return &TypingIndicators{
+		clients:   clients,
+		publisher: publisher,
+		ttl:       ttl,
+		active:    make(map[typingKey]*time.Timer),
+		publishes: make(chan typingPublication, TypingQueueSize),
+	}
+}
+
+// Run publishes queued typing events to other servers until ctx is done. A
+// single loop keeps each user's started and stopped events in order.
+func (t *TypingIndicators) Run(ctx context.Context) {
+	for {
+		select {
+		case publication := <-t.publishes:
+			if err := t.publisher.PublishTyping(ctx, publication.conversationID, publication.notification); err != nil {
+				log.Printf("Error publishing %s for user %s: %v", publication.notification.Type, publication.notification.UserID, err)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (t *TypingIndicators) Handle(ctx context.Context, notification IncomingNotification) {
+	var event typingEvent
+	if err := json.Unmarshal(notification.Data, &event); err != nil {
+		log.Printf("Error unmarshaling typing event from user %s: %v", notification.UserID, err)
+		return
+	}
+
+	if notification.client == nil || !t.clients.IsSubscribed(notification.client, event.ConversationID) {
+		return
+	}
+
+	key := typingKey{conversationID: event.ConversationID, userID: notification.UserID}
+
+	switch notification.Type {
+	case TypingStartedEvent:
+		t.start(ctx, key)
+	case TypingStoppedEvent:
+		t.stop(ctx, key)
+	}
+}
+
+func (t *TypingIndicators) start(ctx context.Context, key typingKey) {
+	t.mu.Lock()
+	if timer, exists := t.active[key]; exists && timer.Stop() {
+		timer.Reset(t.ttl)
+		t.mu.Unlock()
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(t.ttl, func() {
+		t.mu.Lock()
+		if t.active[key] != timer {
+			t.mu.Unlock()
+			return
+		}
+		delete(t.active, key)
+		t.mu.Unlock()
+
+		t.notify(ctx, key, TypingStoppedEvent)
+	})
+	t.active[key] = timer
+	t.mu.Unlock()
+
+	t.notify(ctx, key, TypingStartedEvent)
+}
+
+func (t *TypingIndicators) stop(ctx context.Context, key typingKey) {
+	t.mu.Lock()
+	timer, exists := t.active[key]
+	if !exists {
+		t.mu.Unlock()
+		return
+	}
+	timer.Stop()
+	delete(t.active, key)
+	t.mu.Unlock()
+
+	t.notify(ctx, key, TypingStoppedEvent)
+}
+
+func (t *TypingIndicators) notify(ctx context.Context, key typingKey, eventType string) {
+	notification := OutgoingNotification{
+		Type:   eventType,
+		UserID: key.userID,
+		Payload: map[string]interface{}{
+			"conversation_id": key.conversationID,
+			"user_id":         key.userID,
+		},
+	}
+
+	t.clients.NotifyChannelClientsExcept(ctx, key.conversationID, key.userID, notification)
+
+	select {
+	case t.publishes <- typingPublication{conversationID: key.conversationID, notification: notification}:
+	default:
+		log.Printf("Typing queue full, dropping %s for user %s", eventType, key.userID)
+	}
+}
@@ -0,0 +1,161 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTypingPublisher struct {
+	mu        sync.Mutex
+	published []OutgoingNotification
+}
+
+func (p *fakeTypingPublisher) PublishTyping(ctx context.Context, conversationID uuid.UUID, notification OutgoingNotification) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.published = append(p.published, notification)
+	return nil
+}
+
+func (p *fakeTypingPublisher) types() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	types := make([]string, len(p.published))
+	for i, notification := range p.published {
+		types[i] = notification.Type
+	}
+	return types
+}
+
+type blockingTypingPublisher chan struct{}
+
+func (p blockingTypingPublisher) PublishTyping(ctx context.Context, conversationID uuid.UUID, notification OutgoingNotification) error {
+	select {
+	case <-p:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+func newTypingNotification(t *testing.T, client *Client, eventType string, conversationID uuid.UUID) IncomingNotification {
+	data, err := json.Marshal(typingEvent{ConversationID: conversationID})
+	assert.NoError(t, err)
+
+	return IncomingNotification{
+		Type:   eventType,
+		Data:   data,
+		UserID: client.UserID,
+		client: client,
+	}
+}
+
+func setupTypingClients(t *testing.T, conversationID uuid.UUID) (*activeClients, *Client, *Client) {
+	senderID := uuid.New()
+	receiverID := uuid.New()
+	ac := NewActiveClients(context.Background(), &mockParticipantRepository{
+		conversationIDs: map[uuid.UUID][]uuid.UUID{
+			senderID:   {conversationID},
+			receiverID: {conversationID},
+		},
+	})
+
+	sender := NewClient(nil, nil, nil, senderID)
+	receiver := NewClient(nil, nil, nil, receiverID)
+	ac.AddClient(sender)
+	ac.AddClient(receiver)
+
+	return ac, sender, receiver
+}
+
+func TestTypingIndicators_StartAndStop(t *testing.T) {
+	conversationID := uuid.New()
+	ac, sender, receiver := setupTypingClients(t, conversationID)
+	publisher := &fakeTypingPublisher{}
+	typing := NewTypingIndicators(ac, publisher, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go typing.Run(ctx)
+
+	typing.Handle(context.Background(), newTypingNotification(t, sender, TypingStartedEvent, conversationID))
+	typing.Handle(context.Background(), newTypingNotification(t, sender, TypingStartedEvent, conversationID))
+
+	assert.Len(t, sender.sendChannel, 0)
+	assert.Len(t, receiver.sendChannel, 1)
+	started := <-receiver.sendChannel
+	assert.Equal(t, TypingStartedEvent, started.Type)
+	assert.Equal(t, sender.UserID, started.UserID)
+
+	typing.Handle(context.Background(), newTypingNotification(t, sender, TypingStoppedEvent, conversationID))
+
+	assert.Len(t, receiver.sendChannel, 1)
+	stopped := <-receiver.sendChannel
+	assert.Equal(t, TypingStoppedEvent, stopped.Type)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{TypingStartedEvent, TypingStoppedEvent}, publisher.types())
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestTypingIndicators_SlowPublisherDoesNotBlockDelivery(t *testing.T) {
+	conversationID := uuid.New()
+	ac, sender, receiver := setupTypingClients(t, conversationID)
+	release := make(chan struct{})
+	defer close(release)
+	typing := NewTypingIndicators(ac, blockingTypingPublisher(release), time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go typing.Run(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		typing.Handle(context.Background(), newTypingNotification(t, sender, TypingStartedEvent, conversationID))
+		typing.Handle(context.Background(), newTypingNotification(t, sender, TypingStoppedEvent, conversationID))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("typing events waited on the publisher")
+	}
+	assert.Len(t, receiver.sendChannel, 2)
+}
+
+func TestTypingIndicators_Expires(t *testing.T) {
+	conversationID := uuid.New()
+	ac, sender, receiver := setupTypingClients(t, conversationID)
+	publisher := &fakeTypingPublisher{}
+	typing := NewTypingIndicators(ac, publisher, 10*time.Millisecond)
+
+	typing.Handle(context.Background(), newTypingNotification(t, sender, TypingStartedEvent, conversationID))
+
+	started := <-receiver.sendChannel
+	assert.Equal(t, TypingStartedEvent, started.Type)
+
+	select {
+	case stopped := <-receiver.sendChannel:
+		assert.Equal(t, TypingStoppedEvent, stopped.Type)
+	case <-time.After(time.Second):
+		t.Fatal("typing indicator did not expire")
+	}
+}
+
+func TestTypingIndicators_IgnoresUnsubscribedChannel(t *testing.T) {
+	conversationID := uuid.New()
+	ac, sender, receiver := setupTypingClients(t, conversationID)
+	publisher := &fakeTypingPublisher{}
+	typing := NewTypingIndicators(ac, publisher, time.Minute)
+
+	typing.Handle(context.Background(), newTypingNotification(t, sender, TypingStartedEvent, uuid.New()))
+	typing.Handle(context.Background(), newTypingNotification(t, sender, TypingStoppedEvent, conversationID))
+
+	assert.Len(t, receiver.sendChannel, 0)
+	assert.Empty(t, publisher.types())
+}